@@ -7,9 +7,11 @@ import (
 	"os/signal"
 	"syscall"
 
+	"connect4-multiplayer/internal/achievements"
 	"connect4-multiplayer/internal/analytics"
 	"connect4-multiplayer/internal/config"
 	"connect4-multiplayer/internal/database"
+	"connect4-multiplayer/internal/notifications"
 )
 
 func main() {
@@ -20,7 +22,7 @@ func main() {
 	}
 
 	// Initialize database
-	db, _, err := database.Initialize(cfg.Database)
+	db, repoManager, err := database.Initialize(cfg.Database)
 	if err != nil {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
@@ -31,6 +33,19 @@ func main() {
 		log.Fatalf("Failed to create analytics service: %v", err)
 	}
 
+	// Evaluate achievements for events consumed from the stream. Unlocks are
+	// unique per player and achievement, so events already handled in-process
+	// are not double counted, and only the process that unlocks notifies.
+	// Notifications stored here reach players on their next login.
+	achievementService := achievements.NewAchievementService(
+		repoManager.Achievement,
+		repoManager.GameSession,
+		achievements.DefaultServiceConfig(),
+	)
+	notificationService := notifications.NewNotificationService(repoManager.Notification, notifications.DefaultServiceConfig())
+	achievementService.SetUnlockCallback(achievements.NotifyUnlocks(notificationService, nil))
+	analyticsService.AddEventHandler(achievementService)
+
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
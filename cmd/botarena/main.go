@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"connect4-multiplayer/internal/bot/arena"
+)
+
+func main() {
+	defaults := arena.DefaultConfig()
+	sprtDefaults := arena.DefaultSPRTConfig()
+	var (
+		specA        = flag.String("a", "minimax:depth=5", "First bot, e.g. minimax:depth=5,three=120,two=8,center=4, mcts:playouts=5000 or solver")
+		specB        = flag.String("b", "minimax:depth=4", "Second bot, same format as -a")
+		games        = flag.Int("games", defaults.Games, "Maximum number of games")
+		workers      = flag.Int("workers", defaults.Workers, "Games played in parallel")
+		openingPlies = flag.Int("opening-plies", defaults.OpeningPlies, "Random moves at the start of each game pair")
+		moveTimeout  = flag.Duration("move-timeout", defaults.MoveTimeout, "Time per move for bots without a fixed depth")
+		seed         = flag.Int64("seed", defaults.Seed, "Seed for the random openings")
+		sprt         = flag.Bool("sprt", false, "Stop early with a sequential probability ratio test; exits with status 1 if H0 is accepted")
+		elo0         = flag.Float64("elo0", sprtDefaults.Elo0, "SPRT null hypothesis Elo difference")
+		elo1         = flag.Float64("elo1", sprtDefaults.Elo1, "SPRT alternative hypothesis Elo difference")
+		alpha        = flag.Float64("alpha", sprtDefaults.Alpha, "SPRT false positive rate")
+		beta         = flag.Float64("beta", sprtDefaults.Beta, "SPRT false negative rate")
+		jsonOutput   = flag.Bool("json", false, "Print the final result as JSON")
+	)
+	flag.Parse()
+
+	a, err := arena.ParseContestant(*specA)
+	if err != nil {
+		log.Fatalf("Invalid bot -a: %v", err)
+	}
+	b, err := arena.ParseContestant(*specB)
+	if err != nil {
+		log.Fatalf("Invalid bot -b: %v", err)
+	}
+
+	config := arena.Config{
+		Games:        *games,
+		Workers:      *workers,
+		OpeningPlies: *openingPlies,
+		MoveTimeout:  *moveTimeout,
+		Seed:         *seed,
+	}
+	if *sprt {
+		config.SPRT = &arena.SPRTConfig{Elo0: *elo0, Elo1: *elo1, Alpha: *alpha, Beta: *beta}
+	}
+
+	lastReport := time.Now()
+	config.Progress = func(result arena.Result) {
+		if time.Since(lastReport) > 5*time.Second {
+			lastReport = time.Now()
+			log.Print(summary(result))
+		}
+	}
+
+	// Stop on interrupt and report the games played so far
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("Playing %s vs %s: up to %d games on %d workers", a.Name, b.Name, config.Games, config.Workers)
+	result, err := arena.Run(ctx, a, b, config)
+	if err != nil {
+		log.Fatalf("Arena failed: %v", err)
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			log.Fatalf("Failed to encode result: %v", err)
+		}
+	} else {
+		fmt.Printf("%s vs %s\n%s\n", a.Name, b.Name, summary(result))
+	}
+
+	if result.SPRT != nil && result.SPRT.Decision == arena.SPRTAcceptH0 {
+		os.Exit(1)
+	}
+}
+
+// summary formats a result for humans
+func summary(result arena.Result) string {
+	line := fmt.Sprintf("Games: %d  W/D/L: %d/%d/%d  Score: %.1f%%  Elo: %+.1f [%+.1f, %+.1f]",
+		result.Games, result.Wins, result.Draws, result.Losses, 100*result.Score(),
+		result.Elo.Diff, result.Elo.Low, result.Elo.High)
+	if result.Forfeits > 0 {
+		line += fmt.Sprintf("  Forfeits: %d", result.Forfeits)
+	}
+	if result.SPRT != nil {
+		line += fmt.Sprintf("  LLR: %.2f (%.2f, %.2f) %s",
+			result.SPRT.LLR, result.SPRT.Lower, result.SPRT.Upper, result.SPRT.Decision)
+	}
+	return line + fmt.Sprintf("  Time: %s", result.Duration.Round(time.Second))
+}
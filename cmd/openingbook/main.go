@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"connect4-multiplayer/internal/bot"
+)
+
+func main() {
+	defaults := bot.DefaultBookGeneratorConfig()
+	var (
+		maxPly  = flag.Int("ply", defaults.MaxPly, "Include every position with up to this many stones")
+		workers = flag.Int("workers", defaults.Workers, "Number of positions solved in parallel")
+		output  = flag.String("out", "opening_book.bin", "Output file")
+		timeout = flag.Duration("timeout", 0, "Give up after this long (0 for no limit)")
+	)
+	flag.Parse()
+
+	// Stop cleanly on interrupt; a partial book is never written
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	lastReport := start
+	config := bot.BookGeneratorConfig{
+		MaxPly:  *maxPly,
+		Workers: *workers,
+		Progress: func(ply, done, total int) {
+			if done == total || time.Since(lastReport) > 10*time.Second {
+				lastReport = time.Now()
+				log.Printf("Ply %d: solved %d/%d positions (%s elapsed)", ply, done, total, time.Since(start).Round(time.Second))
+			}
+		},
+	}
+
+	log.Printf("Generating opening book up to ply %d with %d workers...", config.MaxPly, config.Workers)
+	book, err := bot.GenerateOpeningBook(ctx, config)
+	if err != nil {
+		log.Fatalf("Failed to generate opening book: %v", err)
+	}
+
+	// Write to a temporary file first so an existing book is never left half written
+	tmp, err := os.CreateTemp(filepath.Dir(*output), ".openingbook-*")
+	if err != nil {
+		log.Fatalf("Failed to create output file: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	size, err := book.WriteTo(tmp)
+	if err != nil {
+		log.Fatalf("Failed to write opening book: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		log.Fatalf("Failed to write opening book: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), *output); err != nil {
+		log.Fatalf("Failed to write opening book: %v", err)
+	}
+
+	log.Printf("Wrote %d positions (%d bytes, format v%d) to %s in %s",
+		book.Len(), size, bot.OpeningBookVersion, *output, time.Since(start).Round(time.Second))
+}
@@ -12,14 +12,22 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"connect4-multiplayer/internal/achievements"
 	"connect4-multiplayer/internal/analytics"
 	"connect4-multiplayer/internal/api/handlers"
 	"connect4-multiplayer/internal/api/routes"
 	"connect4-multiplayer/internal/auth"
+	"connect4-multiplayer/internal/bot"
+	"connect4-multiplayer/internal/botaccounts"
 	"connect4-multiplayer/internal/config"
 	"connect4-multiplayer/internal/database"
 	"connect4-multiplayer/internal/game"
 	"connect4-multiplayer/internal/matchmaking"
+	"connect4-multiplayer/internal/notifications"
+	"connect4-multiplayer/internal/puzzles"
+	"connect4-multiplayer/internal/review"
+	"connect4-multiplayer/internal/social"
+	"connect4-multiplayer/internal/stats"
 	"connect4-multiplayer/internal/websocket"
 )
 
@@ -71,9 +79,87 @@ func main() {
 		analyticsProducer = analytics.NewNoopProducer()
 	}
 
+	// Limit the goroutines bot searches use so they cannot starve players
+	bot.SetSearchWorkers(cfg.Bot.SearchWorkers)
+
+	// Load the precomputed opening book used by Impossible bots
+	if cfg.Bot.OpeningBookPath != "" {
+		book, err := bot.LoadOpeningBook(cfg.Bot.OpeningBookPath)
+		if err != nil {
+			log.Printf("Warning: Failed to load opening book, using built-in book: %v", err)
+		} else {
+			bot.UseOpeningBook(book)
+			log.Printf("Loaded opening book with %d positions up to ply %d", book.Len(), book.MaxPly())
+		}
+	}
+
+	// Load tuned evaluation weights used by minimax bots
+	if cfg.Bot.WeightsPath != "" {
+		weights, err := bot.LoadEvaluationWeights(cfg.Bot.WeightsPath)
+		if err == nil {
+			err = bot.UseEvaluationWeights(weights)
+		}
+		if err != nil {
+			log.Printf("Warning: Failed to load evaluation weights, using built-in weights: %v", err)
+		} else {
+			log.Printf("Loaded evaluation weights %+v", weights)
+		}
+	}
+
+	// Register external engines members can play against
+	for _, engineCfg := range cfg.Bot.Engines {
+		engine, err := bot.NewExternalEngine(bot.EngineConfig{
+			Name:        engineCfg.Name,
+			Path:        engineCfg.Path,
+			Args:        engineCfg.Args,
+			Dir:         engineCfg.Dir,
+			Env:         engineCfg.Env,
+			Options:     engineCfg.Options,
+			MoveTime:    time.Duration(engineCfg.MoveTimeMs) * time.Millisecond,
+			MaxRestarts: engineCfg.MaxRestarts,
+			Processes:   engineCfg.Processes,
+		})
+		if err == nil {
+			err = bot.RegisterEngine(engine)
+		}
+		if err != nil {
+			log.Printf("Warning: Failed to register engine %s: %v", engineCfg.Name, err)
+			continue
+		}
+		log.Printf("Registered engine %s", engineCfg.Name)
+	}
+
+	// Initialize achievements engine, fed by in-process game events
+	achievementService := achievements.NewAchievementService(
+		repoManager.Achievement,
+		repoManager.GameSession,
+		achievements.DefaultServiceConfig(),
+	)
+
+	// Block lists are enforced by custom rooms, matchmaking, friends and challenges
+	blockService := social.NewBlockService(repoManager.Block, repoManager.Friendship, nil)
+
+	// Post-game reviews run on their own small worker pool
+	reviewService := review.NewReviewService(
+		repoManager.Review,
+		repoManager.GameSession,
+		repoManager.Move,
+		review.DefaultServiceConfig(),
+	)
+
+	// Puzzles are mined from completed games on another small worker pool
+	puzzleService := puzzles.NewPuzzleService(
+		repoManager.Puzzle,
+		repoManager.GameSession,
+		repoManager.Move,
+		puzzles.DefaultServiceConfig(),
+	)
+
 	// Initialize services with analytics producer
 	serviceConfig := game.DefaultServiceConfig()
 	serviceConfig.AnalyticsProducer = analyticsProducer
+	serviceConfig.EventListeners = []game.EventListener{achievementService, reviewService, puzzleService}
+	serviceConfig.BlockChecker = blockService
 
 	gameService := game.NewGameService(
 		repoManager.GameSession,
@@ -84,19 +170,62 @@ func main() {
 	)
 
 	// Initialize matchmaking service
+	matchmakingConfig := matchmaking.DefaultServiceConfig()
+	matchmakingConfig.BlockChecker = blockService
 	matchmakingService := matchmaking.NewMatchmakingService(
 		gameService,
-		matchmaking.DefaultServiceConfig(),
+		matchmakingConfig,
 	)
 
 	// Initialize WebSocket service
 	wsService := websocket.NewService(gameService, matchmakingService)
+	wsService.SetBotSchedulerConfig(&websocket.BotSchedulerConfig{
+		Workers:   cfg.Bot.MoveWorkers,
+		MoveDelay: time.Duration(cfg.Bot.MoveDelayMs) * time.Millisecond,
+	})
+
+	// Head-to-head records for the REST API and game started messages
+	headToHeadService := stats.NewHeadToHeadService(repoManager.GameSession)
+	wsService.SetHeadToHeadService(headToHeadService)
+
+	// Adaptive bots estimate a player's strength from their stats and games
+	wsService.SetBotStrengthService(stats.NewBotStrengthService(repoManager.GameSession, repoManager.PlayerStats))
+
+	// Persistent notifications, pushed live and summarised on login
+	notificationService := notifications.NewNotificationService(repoManager.Notification, notifications.DefaultServiceConfig())
+	notificationService.SetPublisher(wsService)
+	wsService.SetNotificationService(notificationService)
+
+	// Friends with online presence and direct challenges
+	friendService := social.NewFriendService(repoManager.Friendship, nil)
+	friendService.SetPresenceChecker(wsService)
+	friendService.SetBlockChecker(blockService)
+	friendService.SetNotifier(notificationService)
+	challengeConfig := social.DefaultChallengeConfig()
+	challengeConfig.BlockChecker = blockService
+	challengeConfig.Notifier = notificationService
+	challengeService := social.NewChallengeService(gameService, challengeConfig)
+	wsService.SetChallengeService(challengeService)
+
+	// Bot accounts played by developers' programs, kept out of matchmaking
+	botAccountConfig := botaccounts.DefaultServiceConfig()
+	botAccountConfig.MaxBotsPerOwner = cfg.Bot.API.MaxAccountsPerOwner
+	botAccountConfig.MessagesPerSecond = cfg.Bot.API.MessagesPerSecond
+	botAccountConfig.MessageBurst = cfg.Bot.API.MessageBurst
+	botAccountService := botaccounts.NewBotAccountService(repoManager.BotAccount, botAccountConfig)
+	wsService.SetBotAccounts(botAccountService)
+
+	// Keep achievement unlocks in the inbox, which pushes them to connected
+	// players
+	achievementService.SetUnlockCallback(achievements.NotifyUnlocks(notificationService, nil))
 
 	// Start WebSocket service
 	ctx := context.Background()
 	if err := wsService.Start(ctx); err != nil {
 		log.Fatalf("Failed to start WebSocket service: %v", err)
 	}
+	reviewService.Start(ctx)
+	puzzleService.Start(ctx)
 
 	// Initialize handlers
 	gameHandler := handlers.NewGameHandler(gameService)
@@ -105,6 +234,15 @@ func main() {
 	// Initialize Supabase Auth and Auth Handler
 	supabaseAuth := auth.NewSupabaseAuth(cfg.Supabase.URL, cfg.Supabase.ServiceKey)
 	authHandler := handlers.NewAuthHandler(supabaseAuth, repoManager.Player)
+	achievementHandler := handlers.NewAchievementHandler(achievementService)
+	headToHeadHandler := handlers.NewHeadToHeadHandler(headToHeadService)
+	friendHandler := handlers.NewFriendHandler(friendService)
+	blockHandler := handlers.NewBlockHandler(blockService)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	analysisHandler := handlers.NewAnalysisHandler(bot.NewPositionAnalyzer(bot.DefaultAnalysisConfig()))
+	reviewHandler := handlers.NewReviewHandler(reviewService)
+	puzzleHandler := handlers.NewPuzzleHandler(puzzleService)
+	botAccountHandler := handlers.NewBotAccountHandler(botAccountService)
 
 	// Set Gin mode based on environment
 	if cfg.Environment == "production" {
@@ -115,7 +253,7 @@ func main() {
 	router := gin.New()
 
 	// Setup routes and middleware
-	routes.SetupRoutes(router, cfg, gameHandler, leaderboardHandler, authHandler, wsService.GetWebSocketHandler(), supabaseAuth)
+	routes.SetupRoutes(router, cfg, gameHandler, leaderboardHandler, authHandler, achievementHandler, headToHeadHandler, friendHandler, blockHandler, notificationHandler, analysisHandler, reviewHandler, puzzleHandler, botAccountHandler, wsService.GetWebSocketHandler(), supabaseAuth, repoManager.Player)
 
 	// Create HTTP server
 	srv := &http.Server{
@@ -143,11 +281,21 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	// Stop pending challenge timers
+	challengeService.Stop()
+
+	// Finish in-flight reviews; queued ones resume on the next start
+	reviewService.Stop()
+	puzzleService.Stop()
+
 	// Stop WebSocket service
 	if err := wsService.Stop(); err != nil {
 		log.Printf("Error stopping WebSocket service: %v", err)
 	}
 
+	// Stop engine processes once no game can ask them for a move
+	bot.CloseEngines()
+
 	// Close analytics producer
 	if err := analyticsProducer.Close(); err != nil {
 		log.Printf("Error closing analytics producer: %v", err)
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"connect4-multiplayer/internal/bot"
+	"connect4-multiplayer/internal/config"
+	"connect4-multiplayer/internal/database"
+	"connect4-multiplayer/internal/database/repositories"
+)
+
+func main() {
+	dataDefaults := bot.DefaultTuningDataConfig()
+	tuneDefaults := bot.DefaultTuningConfig()
+	var (
+		source       = flag.String("source", "solver", "Where labelled positions come from: solver (random positions, solved) or games (completed games in the database)")
+		positions    = flag.Int("positions", dataDefaults.Positions, "Positions to label with the solver")
+		minPly       = flag.Int("min-ply", dataDefaults.MinPly, "Skip positions with fewer stones")
+		maxPly       = flag.Int("max-ply", dataDefaults.MaxPly, "Most stones in solver positions")
+		workers      = flag.Int("workers", dataDefaults.Workers, "Positions solved in parallel")
+		solveTimeout = flag.Duration("solve-timeout", dataDefaults.SolveTimeout, "Skip positions the solver cannot finish in this time")
+		games        = flag.Int("games", 5000, "Most completed games to read with -source=games")
+		iterations   = flag.Int("iterations", tuneDefaults.MaxIterations, "Most passes over the weights")
+		step         = flag.Int("step", tuneDefaults.InitialStep, "First change tried on each weight")
+		seed         = flag.Int64("seed", 0, "Random seed for solver positions (0 uses the clock)")
+		output       = flag.String("out", "evaluation_weights.json", "Output file")
+		timeout      = flag.Duration("timeout", 0, "Give up after this long (0 for no limit)")
+	)
+	flag.Parse()
+
+	// Stop cleanly on interrupt; the weights file is only written on success
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	var (
+		data []bot.TuningPosition
+		err  error
+	)
+	switch *source {
+	case "solver":
+		data, err = solverPositions(ctx, bot.TuningDataConfig{
+			Positions:    *positions,
+			MinPly:       *minPly,
+			MaxPly:       *maxPly,
+			Workers:      *workers,
+			SolveTimeout: *solveTimeout,
+			Seed:         *seed,
+		})
+	case "games":
+		data, err = gamePositions(ctx, *games, *minPly)
+	default:
+		log.Fatalf("Invalid source: %s. Use 'solver' or 'games'", *source)
+	}
+	if err != nil {
+		log.Fatalf("Failed to collect positions: %v", err)
+	}
+	log.Printf("Collected %d positions in %s", len(data), time.Since(start).Round(time.Second))
+
+	lastReport := time.Now()
+	result, err := bot.TuneEvaluationWeights(ctx, data, bot.BuiltinEvaluationWeights(), bot.TuningConfig{
+		MaxIterations: *iterations,
+		InitialStep:   *step,
+		Progress: func(iteration int, loss float64, weights bot.EvaluationWeights) {
+			if time.Since(lastReport) > 5*time.Second {
+				lastReport = time.Now()
+				log.Printf("Iteration %d: loss %.6f with %+v", iteration, loss, weights)
+			}
+		},
+	})
+	if err != nil {
+		log.Fatalf("Failed to tune weights: %v", err)
+	}
+	log.Printf("Tuned in %d iterations (scale %.1f): loss %.6f -> %.6f", result.Iterations, result.Scale, result.InitialLoss, result.Loss)
+	log.Printf("Built-in %+v", bot.BuiltinEvaluationWeights())
+	log.Printf("Tuned    %+v", result.Weights)
+
+	// Write to a temporary file first so existing weights are never left half written
+	tmp, err := os.CreateTemp(filepath.Dir(*output), ".evaluationweights-*")
+	if err != nil {
+		log.Fatalf("Failed to create output file: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := bot.WriteEvaluationWeights(tmp, result.Weights); err != nil {
+		log.Fatalf("Failed to write weights: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		log.Fatalf("Failed to write weights: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), *output); err != nil {
+		log.Fatalf("Failed to write weights: %v", err)
+	}
+	log.Printf("Wrote %s; load it with bot.weights_path", *output)
+}
+
+// solverPositions labels random quiet positions with the solver
+func solverPositions(ctx context.Context, dataConfig bot.TuningDataConfig) ([]bot.TuningPosition, error) {
+	lastReport := time.Now()
+	dataConfig.Progress = func(done, total int) {
+		if done == total || time.Since(lastReport) > 10*time.Second {
+			lastReport = time.Now()
+			log.Printf("Solved %d/%d positions", done, total)
+		}
+	}
+
+	log.Printf("Solving %d random positions with %d-%d stones using %d workers...",
+		dataConfig.Positions, dataConfig.MinPly, dataConfig.MaxPly, dataConfig.Workers)
+	return bot.GenerateTuningPositions(ctx, dataConfig)
+}
+
+// gamePositions labels the positions of completed games with their results
+func gamePositions(ctx context.Context, maxGames, minPly int) ([]bot.TuningPosition, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	_, repoManager, err := database.Initialize(cfg.Database)
+	if err != nil {
+		return nil, err
+	}
+	return readGames(ctx, repoManager, maxGames, minPly)
+}
+
+func readGames(ctx context.Context, repoManager *repositories.Manager, maxGames, minPly int) ([]bot.TuningPosition, error) {
+	const pageSize = 100
+
+	var data []bot.TuningPosition
+	read := 0
+	for offset := 0; read < maxGames; offset += pageSize {
+		sessions, err := repoManager.GameSession.GetGameHistory(ctx, pageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, session := range sessions {
+			if read == maxGames {
+				break
+			}
+			read++
+			if session.Handicap != nil {
+				// Handicap games do not start from the empty board
+				continue
+			}
+
+			moves, err := repoManager.Move.GetByGameID(ctx, session.ID)
+			if err != nil {
+				return nil, err
+			}
+			positions, err := bot.TuningPositionsFromGame(moves, session.Winner, minPly)
+			if err != nil {
+				log.Printf("Skipping game %s: %v", session.ID, err)
+				continue
+			}
+			data = append(data, positions...)
+		}
+		if len(sessions) < pageSize {
+			break
+		}
+	}
+
+	log.Printf("Read %d completed games", read)
+	return data, nil
+}
@@ -0,0 +1,147 @@
+package achievements
+
+import (
+	"time"
+
+	"connect4-multiplayer/internal/bot"
+	"connect4-multiplayer/pkg/models"
+)
+
+// Achievement identifiers
+const (
+	AchievementFirstGame   = "first_game"
+	AchievementFirstWin    = "first_win"
+	AchievementFirstDraw   = "first_draw"
+	AchievementHatTrick    = "hat_trick"
+	AchievementWinStreak10 = "win_streak_10"
+	AchievementQuickWin    = "quick_win"
+	AchievementBeatHardBot = "beat_hard_bot"
+	AchievementVeteran     = "veteran_100"
+)
+
+// quickWinMaxMoves is the number of the winner's own discs below which a win
+// counts as a quick win
+const quickWinMaxMoves = 10
+
+// Rule declares an achievement and the condition under which it unlocks.
+// Conditions must be pure functions of the PlayerContext so that evaluating
+// the same event twice always yields the same result.
+type Rule struct {
+	ID          string                       `json:"id"`
+	Name        string                       `json:"name"`
+	Description string                       `json:"description"`
+	Trigger     models.EventType             `json:"trigger"`
+	Condition   func(pc *PlayerContext) bool `json:"-"`
+}
+
+// PlayerContext is the view of a finished game from one participant's side
+type PlayerContext struct {
+	Username  string
+	Opponent  string
+	Session   *models.GameSession
+	Won       bool
+	Lost      bool
+	Draw      bool
+	MoveCount int // discs the player dropped
+	Duration  time.Duration
+
+	// Completed games including the current one, most recent first
+	History []*models.GameSession
+}
+
+// WinStreak returns the number of consecutive wins ending with the current game
+func (pc *PlayerContext) WinStreak() int {
+	streak := 0
+	for _, game := range pc.History {
+		if !game.IsCompleted() {
+			continue
+		}
+		if game.Winner == nil || game.GetPlayerColor(pc.Username) != *game.Winner {
+			break
+		}
+		streak++
+	}
+	return streak
+}
+
+// GamesPlayed returns the number of completed games in the player's history
+func (pc *PlayerContext) GamesPlayed() int {
+	played := 0
+	for _, game := range pc.History {
+		if game.IsCompleted() {
+			played++
+		}
+	}
+	return played
+}
+
+// DefaultRules returns the built-in achievement catalogue
+func DefaultRules() []Rule {
+	return []Rule{
+		{
+			ID:          AchievementFirstGame,
+			Name:        "First Steps",
+			Description: "Finish your first game",
+			Trigger:     models.EventGameCompleted,
+			Condition:   func(pc *PlayerContext) bool { return true },
+		},
+		{
+			ID:          AchievementFirstWin,
+			Name:        "First Victory",
+			Description: "Win your first game",
+			Trigger:     models.EventGameCompleted,
+			Condition:   func(pc *PlayerContext) bool { return pc.Won },
+		},
+		{
+			ID:          AchievementFirstDraw,
+			Name:        "Stalemate",
+			Description: "Fill the board without a winner",
+			Trigger:     models.EventGameCompleted,
+			Condition:   func(pc *PlayerContext) bool { return pc.Draw },
+		},
+		{
+			ID:          AchievementHatTrick,
+			Name:        "Hat Trick",
+			Description: "Win 3 games in a row",
+			Trigger:     models.EventGameCompleted,
+			Condition:   func(pc *PlayerContext) bool { return pc.Won && pc.WinStreak() >= 3 },
+		},
+		{
+			ID:          AchievementWinStreak10,
+			Name:        "Unstoppable",
+			Description: "Win 10 games in a row",
+			Trigger:     models.EventGameCompleted,
+			Condition:   func(pc *PlayerContext) bool { return pc.Won && pc.WinStreak() >= 10 },
+		},
+		{
+			ID:          AchievementQuickWin,
+			Name:        "Blitz",
+			Description: "Win a game in under 10 moves",
+			Trigger:     models.EventGameCompleted,
+			Condition: func(pc *PlayerContext) bool {
+				return pc.Won && pc.MoveCount > 0 && pc.MoveCount < quickWinMaxMoves
+			},
+		},
+		{
+			ID:          AchievementBeatHardBot,
+			Name:        "Machine Breaker",
+			Description: "Defeat a Hard bot",
+			Trigger:     models.EventGameCompleted,
+			Condition: func(pc *PlayerContext) bool {
+				if !pc.Won || !bot.IsBotUsername(pc.Opponent) {
+					return false
+				}
+				// Adaptive bots may have weakened to a beginner's level
+				difficulty := bot.SessionDifficulty(pc.Session)
+				return difficulty == bot.DifficultyHard || difficulty == bot.DifficultyImpossible
+			},
+		},
+		{
+			ID:          AchievementVeteran,
+			Name:        "Veteran",
+			Description: "Finish 100 games",
+			Trigger:     models.EventGameCompleted,
+			Condition:   func(pc *PlayerContext) bool { return pc.GamesPlayed() >= 100 },
+		},
+	}
+}
@@ -0,0 +1,318 @@
+package achievements
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"connect4-multiplayer/internal/bot"
+	"connect4-multiplayer/internal/database/repositories"
+	"connect4-multiplayer/pkg/models"
+)
+
+// UnlockCallback is called whenever a player unlocks a new achievement
+type UnlockCallback func(ctx context.Context, username string, rule Rule, achievement *models.PlayerAchievement)
+
+// Notifier stores a notification in a player's inbox
+type Notifier interface {
+	Notify(ctx context.Context, username string, notificationType models.NotificationType, title, body string, data models.NotificationData) (*models.Notification, error)
+}
+
+// NotifyUnlocks returns an UnlockCallback that sends every unlock to the
+// player's notification inbox, which pushes it on to players online
+func NotifyUnlocks(notifier Notifier, logger *slog.Logger) UnlockCallback {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return func(ctx context.Context, username string, rule Rule, achievement *models.PlayerAchievement) {
+		_, err := notifier.Notify(ctx, username, models.NotificationAchievement,
+			"Achievement unlocked: "+rule.Name, rule.Description,
+			models.NotificationData{"achievementId": rule.ID, "gameId": achievement.GameID})
+		if err != nil {
+			logger.Warn("failed to store achievement notification",
+				"player", username,
+				"achievement", rule.ID,
+				"error", err,
+			)
+		}
+	}
+}
+
+// AchievementService evaluates achievement rules against game events
+type AchievementService interface {
+	// OnGameEvent consumes an event from the game service or analytics stream
+	OnGameEvent(ctx context.Context, event *models.GameEvent)
+	// HandleEvent evaluates all rules triggered by the event and returns newly unlocked achievements
+	HandleEvent(ctx context.Context, event *models.GameEvent) ([]*models.PlayerAchievement, error)
+
+	// GetPlayerAchievements returns the unlocked achievements for a player
+	GetPlayerAchievements(ctx context.Context, username string) ([]*UnlockedAchievement, error)
+	// Rules returns the achievement catalogue
+	Rules() []Rule
+
+	// SetUnlockCallback registers a callback for newly unlocked achievements
+	SetUnlockCallback(callback UnlockCallback)
+}
+
+// UnlockedAchievement combines a rule definition with the time it was unlocked
+type UnlockedAchievement struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	GameID      string    `json:"gameId,omitempty"`
+	UnlockedAt  time.Time `json:"unlockedAt"`
+}
+
+// achievementService implements AchievementService interface
+type achievementService struct {
+	achievementRepo repositories.AchievementRepository
+	gameRepo        repositories.GameSessionRepository
+	rules           []Rule
+	logger          *slog.Logger
+
+	unlockCallback UnlockCallback
+	callbackMutex  sync.RWMutex
+}
+
+// ServiceConfig holds configuration for the achievement service
+type ServiceConfig struct {
+	Rules  []Rule
+	Logger *slog.Logger
+}
+
+// DefaultServiceConfig returns default service configuration
+func DefaultServiceConfig() *ServiceConfig {
+	return &ServiceConfig{
+		Rules:  DefaultRules(),
+		Logger: slog.Default(),
+	}
+}
+
+// NewAchievementService creates a new AchievementService instance
+func NewAchievementService(
+	achievementRepo repositories.AchievementRepository,
+	gameRepo repositories.GameSessionRepository,
+	config *ServiceConfig,
+) AchievementService {
+	if config == nil {
+		config = DefaultServiceConfig()
+	}
+	if config.Rules == nil {
+		config.Rules = DefaultRules()
+	}
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+
+	return &achievementService{
+		achievementRepo: achievementRepo,
+		gameRepo:        gameRepo,
+		rules:           config.Rules,
+		logger:          config.Logger.With("component", "achievements"),
+	}
+}
+
+// SetUnlockCallback registers a callback for newly unlocked achievements
+func (s *achievementService) SetUnlockCallback(callback UnlockCallback) {
+	s.callbackMutex.Lock()
+	defer s.callbackMutex.Unlock()
+	s.unlockCallback = callback
+}
+
+// Rules returns the achievement catalogue
+func (s *achievementService) Rules() []Rule {
+	rules := make([]Rule, len(s.rules))
+	copy(rules, s.rules)
+	return rules
+}
+
+// OnGameEvent consumes an event and logs evaluation failures
+func (s *achievementService) OnGameEvent(ctx context.Context, event *models.GameEvent) {
+	if _, err := s.HandleEvent(ctx, event); err != nil {
+		s.logger.Warn("failed to evaluate achievements",
+			"eventType", event.EventType,
+			"gameID", event.GameID,
+			"error", err,
+		)
+	}
+}
+
+// HandleEvent evaluates all rules triggered by the event. Evaluation is
+// idempotent: replaying an event never unlocks the same achievement twice.
+func (s *achievementService) HandleEvent(ctx context.Context, event *models.GameEvent) ([]*models.PlayerAchievement, error) {
+	if event == nil {
+		return nil, fmt.Errorf("event cannot be nil")
+	}
+
+	triggered := s.rulesFor(event.EventType)
+	if len(triggered) == 0 {
+		return nil, nil
+	}
+
+	session, err := s.gameRepo.GetByID(ctx, event.GameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game %s: %w", event.GameID, err)
+	}
+	// Handicap games count towards no achievements, as they count towards
+	// no player stats
+	if !session.IsCompleted() || session.Handicap != nil {
+		return nil, nil
+	}
+
+	var unlocked []*models.PlayerAchievement
+	for _, username := range []string{session.Player1, session.Player2} {
+		if bot.IsBotUsername(username) {
+			continue
+		}
+
+		pc, err := s.buildPlayerContext(ctx, username, session)
+		if err != nil {
+			return unlocked, err
+		}
+
+		for _, rule := range triggered {
+			if !rule.Condition(pc) {
+				continue
+			}
+
+			achievement := &models.PlayerAchievement{
+				Username:      username,
+				AchievementID: rule.ID,
+				GameID:        session.ID,
+			}
+			created, err := s.achievementRepo.Unlock(ctx, achievement)
+			if err != nil {
+				return unlocked, fmt.Errorf("failed to unlock %s for %s: %w", rule.ID, username, err)
+			}
+			if !created {
+				continue
+			}
+
+			unlocked = append(unlocked, achievement)
+			s.logger.Info("achievement unlocked",
+				"player", username,
+				"achievement", rule.ID,
+				"gameID", session.ID,
+			)
+			s.notifyUnlock(ctx, username, rule, achievement)
+		}
+	}
+
+	return unlocked, nil
+}
+
+// GetPlayerAchievements returns the unlocked achievements for a player
+func (s *achievementService) GetPlayerAchievements(ctx context.Context, username string) ([]*UnlockedAchievement, error) {
+	if username == "" {
+		return nil, fmt.Errorf("username cannot be empty")
+	}
+
+	records, err := s.achievementRepo.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]Rule, len(s.rules))
+	for _, rule := range s.rules {
+		byID[rule.ID] = rule
+	}
+
+	result := make([]*UnlockedAchievement, 0, len(records))
+	for _, record := range records {
+		rule, ok := byID[record.AchievementID]
+		if !ok {
+			// Retired achievement; keep the record but expose only its ID
+			rule = Rule{ID: record.AchievementID, Name: record.AchievementID}
+		}
+		result = append(result, &UnlockedAchievement{
+			ID:          rule.ID,
+			Name:        rule.Name,
+			Description: rule.Description,
+			GameID:      record.GameID,
+			UnlockedAt:  record.UnlockedAt,
+		})
+	}
+
+	return result, nil
+}
+
+// rulesFor returns the rules triggered by an event type
+func (s *achievementService) rulesFor(eventType models.EventType) []Rule {
+	var triggered []Rule
+	for _, rule := range s.rules {
+		if rule.Trigger == eventType && rule.Condition != nil {
+			triggered = append(triggered, rule)
+		}
+	}
+	return triggered
+}
+
+// buildPlayerContext assembles the facts the rules are evaluated against
+func (s *achievementService) buildPlayerContext(ctx context.Context, username string, session *models.GameSession) (*PlayerContext, error) {
+	history, err := s.gameRepo.GetGamesByPlayer(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load history for %s: %w", username, err)
+	}
+
+	// Only count games up to and including this one so that replays of
+	// older events evaluate against the same history
+	var relevant []*models.GameSession
+	for _, game := range history {
+		if game.ID == session.ID || !game.CreatedAt.After(session.CreatedAt) {
+			relevant = append(relevant, game)
+		}
+	}
+
+	opponent := session.Player2
+	if username == session.Player2 {
+		opponent = session.Player1
+	}
+
+	pc := &PlayerContext{
+		Username:  username,
+		Opponent:  opponent,
+		Session:   session,
+		MoveCount: countDiscs(&session.Board, session.GetPlayerColor(username)),
+		History:   relevant,
+	}
+
+	if session.Winner == nil {
+		pc.Draw = true
+	} else if session.GetPlayerColor(username) == *session.Winner {
+		pc.Won = true
+	} else {
+		pc.Lost = true
+	}
+
+	if session.EndTime != nil {
+		pc.Duration = session.EndTime.Sub(session.StartTime)
+	}
+
+	return pc, nil
+}
+
+// notifyUnlock invokes the unlock callback if one is registered
+func (s *achievementService) notifyUnlock(ctx context.Context, username string, rule Rule, achievement *models.PlayerAchievement) {
+	s.callbackMutex.RLock()
+	callback := s.unlockCallback
+	s.callbackMutex.RUnlock()
+
+	if callback != nil {
+		callback(ctx, username, rule, achievement)
+	}
+}
+
+// countDiscs returns the number of a player's discs on the board
+func countDiscs(board *models.Board, color models.PlayerColor) int {
+	total := 0
+	for _, row := range board.Grid {
+		for _, cell := range row {
+			if cell == color {
+				total++
+			}
+		}
+	}
+	return total
+}
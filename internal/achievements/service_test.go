@@ -0,0 +1,244 @@
+package achievements
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"connect4-multiplayer/internal/database/repositories"
+	"connect4-multiplayer/pkg/models"
+)
+
+type AchievementServiceTestSuite struct {
+	suite.Suite
+	db       *gorm.DB
+	gameRepo repositories.GameSessionRepository
+	service  AchievementService
+	ctx      context.Context
+	start    time.Time
+}
+
+func (suite *AchievementServiceTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	suite.Require().NoError(err)
+
+	err = db.AutoMigrate(&models.GameSession{}, &models.Move{}, &models.PlayerAchievement{})
+	suite.Require().NoError(err)
+
+	suite.db = db
+	suite.gameRepo = repositories.NewGameSessionRepository(db)
+	suite.service = NewAchievementService(repositories.NewAchievementRepository(db), suite.gameRepo, nil)
+	suite.ctx = context.Background()
+	suite.start = time.Now().Add(-time.Hour)
+}
+
+// finishGame stores a completed game and returns its game_completed event
+func (suite *AchievementServiceTestSuite) finishGame(player1, player2 string, winner *models.PlayerColor, moves int) *models.GameEvent {
+	return suite.finishHandicapGame(player1, player2, winner, moves, nil)
+}
+
+// finishHandicapGame stores a completed game with the given handicap and
+// returns its game_completed event
+func (suite *AchievementServiceTestSuite) finishHandicapGame(player1, player2 string, winner *models.PlayerColor, moves int, handicap *models.Handicap) *models.GameEvent {
+	session := &models.GameSession{Player1: player1, Player2: player2, Status: models.StatusInProgress, Handicap: handicap}
+	suite.Require().NoError(suite.gameRepo.Create(suite.ctx, session))
+
+	// Spread discs over the columns to simulate the number of moves played,
+	// red first
+	player := models.PlayerColorRed
+	for i := 0; i < moves; i++ {
+		suite.Require().NoError(session.Board.MakeMove(i%7, player))
+		if player == models.PlayerColorRed {
+			player = models.PlayerColorYellow
+		} else {
+			player = models.PlayerColorRed
+		}
+	}
+	suite.start = suite.start.Add(time.Minute)
+	end := suite.start.Add(30 * time.Second)
+	session.Status = models.StatusCompleted
+	session.Winner = winner
+	session.CreatedAt = suite.start
+	session.EndTime = &end
+	suite.Require().NoError(suite.db.Save(session).Error)
+
+	return models.NewGameCompletedEvent(session.ID, "", "", 30)
+}
+
+func (suite *AchievementServiceTestSuite) unlockedIDs(username string) []string {
+	achievements, err := suite.service.GetPlayerAchievements(suite.ctx, username)
+	suite.Require().NoError(err)
+	ids := make([]string, 0, len(achievements))
+	for _, a := range achievements {
+		ids = append(ids, a.ID)
+	}
+	return ids
+}
+
+func (suite *AchievementServiceTestSuite) TestFirstWinAndFirstGame() {
+	red := models.PlayerColorRed
+	event := suite.finishGame("alice", "bobby", &red, 20)
+
+	unlocked, err := suite.service.HandleEvent(suite.ctx, event)
+	suite.Require().NoError(err)
+	assert.Len(suite.T(), unlocked, 3)
+
+	assert.ElementsMatch(suite.T(), []string{AchievementFirstGame, AchievementFirstWin}, suite.unlockedIDs("alice"))
+	assert.ElementsMatch(suite.T(), []string{AchievementFirstGame}, suite.unlockedIDs("bobby"))
+}
+
+func (suite *AchievementServiceTestSuite) TestReplayIsIdempotent() {
+	red := models.PlayerColorRed
+	event := suite.finishGame("alice", "bobby", &red, 20)
+
+	var notified int
+	suite.service.SetUnlockCallback(func(ctx context.Context, username string, rule Rule, achievement *models.PlayerAchievement) {
+		notified++
+	})
+
+	_, err := suite.service.HandleEvent(suite.ctx, event)
+	suite.Require().NoError(err)
+	unlocked, err := suite.service.HandleEvent(suite.ctx, event)
+	suite.Require().NoError(err)
+
+	assert.Empty(suite.T(), unlocked)
+	assert.Equal(suite.T(), 3, notified)
+	assert.Len(suite.T(), suite.unlockedIDs("alice"), 2)
+}
+
+func (suite *AchievementServiceTestSuite) TestQuickWin() {
+	red, yellow := models.PlayerColorRed, models.PlayerColorYellow
+
+	// Only the winner's own discs count: nine each is still quick
+	_, err := suite.service.HandleEvent(suite.ctx, suite.finishGame("alice", "bobby", &yellow, 18))
+	suite.Require().NoError(err)
+	assert.Contains(suite.T(), suite.unlockedIDs("bobby"), AchievementQuickWin)
+	assert.NotContains(suite.T(), suite.unlockedIDs("alice"), AchievementQuickWin)
+
+	// Ten moves of the winner's own are not
+	_, err = suite.service.HandleEvent(suite.ctx, suite.finishGame("carol", "dave", &red, 19))
+	suite.Require().NoError(err)
+	assert.NotContains(suite.T(), suite.unlockedIDs("carol"), AchievementQuickWin)
+}
+
+func (suite *AchievementServiceTestSuite) TestHandicapGamesUnlockNothing() {
+	red := models.PlayerColorRed
+	handicap := &models.Handicap{Player: models.PlayerColorRed, Discs: []int{3}}
+	event := suite.finishHandicapGame("alice", "bobby", &red, 7, handicap)
+
+	unlocked, err := suite.service.HandleEvent(suite.ctx, event)
+	suite.Require().NoError(err)
+	assert.Empty(suite.T(), unlocked)
+	assert.Empty(suite.T(), suite.unlockedIDs("alice"))
+}
+
+func (suite *AchievementServiceTestSuite) TestWinStreaks() {
+	red := models.PlayerColorRed
+	for i := 0; i < 10; i++ {
+		event := suite.finishGame("alice", "bobby", &red, 20)
+		_, err := suite.service.HandleEvent(suite.ctx, event)
+		suite.Require().NoError(err)
+
+		ids := suite.unlockedIDs("alice")
+		assert.Equal(suite.T(), i >= 2, contains(ids, AchievementHatTrick), "game %d", i+1)
+		assert.Equal(suite.T(), i >= 9, contains(ids, AchievementWinStreak10), "game %d", i+1)
+	}
+}
+
+func (suite *AchievementServiceTestSuite) TestStreakBrokenByLoss() {
+	red, yellow := models.PlayerColorRed, models.PlayerColorYellow
+	for _, winner := range []*models.PlayerColor{&red, &red, &yellow, &red} {
+		event := suite.finishGame("alice", "bobby", winner, 20)
+		_, err := suite.service.HandleEvent(suite.ctx, event)
+		suite.Require().NoError(err)
+	}
+
+	assert.NotContains(suite.T(), suite.unlockedIDs("alice"), AchievementHatTrick)
+}
+
+func (suite *AchievementServiceTestSuite) TestBeatHardBot() {
+	red := models.PlayerColorRed
+	_, err := suite.service.HandleEvent(suite.ctx, suite.finishGame("alice", "Bot_Medium_1", &red, 20))
+	suite.Require().NoError(err)
+	assert.NotContains(suite.T(), suite.unlockedIDs("alice"), AchievementBeatHardBot)
+
+	// Adaptive bots rank above Hard but may have been playing far weaker
+	_, err = suite.service.HandleEvent(suite.ctx, suite.finishGame("alice", "Bot_Adaptive_3", &red, 20))
+	suite.Require().NoError(err)
+	assert.NotContains(suite.T(), suite.unlockedIDs("alice"), AchievementBeatHardBot)
+
+	_, err = suite.service.HandleEvent(suite.ctx, suite.finishGame("alice", "Bot_Hard_2", &red, 20))
+	suite.Require().NoError(err)
+	assert.Contains(suite.T(), suite.unlockedIDs("alice"), AchievementBeatHardBot)
+
+	// Bots never collect achievements
+	assert.Empty(suite.T(), suite.unlockedIDs("Bot_Hard_2"))
+}
+
+func (suite *AchievementServiceTestSuite) TestDraw() {
+	event := suite.finishGame("alice", "bobby", nil, 42)
+
+	_, err := suite.service.HandleEvent(suite.ctx, event)
+	suite.Require().NoError(err)
+
+	assert.Contains(suite.T(), suite.unlockedIDs("alice"), AchievementFirstDraw)
+	assert.Contains(suite.T(), suite.unlockedIDs("bobby"), AchievementFirstDraw)
+}
+
+func (suite *AchievementServiceTestSuite) TestIgnoresOtherEvents() {
+	red := models.PlayerColorRed
+	event := suite.finishGame("alice", "bobby", &red, 20)
+
+	unlocked, err := suite.service.HandleEvent(suite.ctx, models.NewPlayerJoinedEvent(event.GameID, "alice"))
+	suite.Require().NoError(err)
+	assert.Empty(suite.T(), unlocked)
+}
+
+// recordingNotifier keeps the notifications it is asked to send
+type recordingNotifier struct {
+	notifications []*models.Notification
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, username string, notificationType models.NotificationType, title, body string, data models.NotificationData) (*models.Notification, error) {
+	notification := &models.Notification{Username: username, Type: notificationType, Title: title, Body: body, Data: data}
+	n.notifications = append(n.notifications, notification)
+	return notification, nil
+}
+
+func (suite *AchievementServiceTestSuite) TestNotifyUnlocks() {
+	notifier := &recordingNotifier{}
+	suite.service.SetUnlockCallback(NotifyUnlocks(notifier, nil))
+
+	red := models.PlayerColorRed
+	event := suite.finishGame("alice", "bobby", &red, 20)
+	for i := 0; i < 2; i++ {
+		_, err := suite.service.HandleEvent(suite.ctx, event)
+		suite.Require().NoError(err)
+	}
+
+	suite.Require().Len(notifier.notifications, 3, "replays notify nobody")
+	first := notifier.notifications[0]
+	assert.Equal(suite.T(), models.NotificationAchievement, first.Type)
+	assert.Equal(suite.T(), event.GameID, first.Data["gameId"])
+}
+
+func contains(ids []string, id string) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAchievementServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AchievementServiceTestSuite))
+}
@@ -75,6 +75,12 @@ func NewGameMetrics() *GameMetrics {
 	}
 }
 
+// EventHandler receives every event consumed from the analytics stream
+// after it has been stored. Handlers must be safe for concurrent use.
+type EventHandler interface {
+	OnGameEvent(ctx context.Context, event *models.GameEvent)
+}
+
 // Service handles analytics event processing with enhanced features
 type Service struct {
 	reader  *kafka.Reader
@@ -83,6 +89,10 @@ type Service struct {
 	logger  *slog.Logger
 	metrics *GameMetrics
 
+	// Downstream consumers of processed events
+	handlers      []EventHandler
+	handlersMutex sync.RWMutex
+
 	// Processing state
 	eventsProcessed atomic.Int64
 	eventsFailed    atomic.Int64
@@ -159,6 +169,13 @@ func NewServiceWithConfig(cfg config.KafkaConfig, db *gorm.DB, serviceCfg *Servi
 	return service, nil
 }
 
+// AddEventHandler registers a handler that is invoked for each processed event
+func (s *Service) AddEventHandler(handler EventHandler) {
+	s.handlersMutex.Lock()
+	defer s.handlersMutex.Unlock()
+	s.handlers = append(s.handlers, handler)
+}
+
 // Start starts the analytics service (Requirement 10.1)
 func (s *Service) Start(ctx context.Context) error {
 	s.logger.Info("Starting analytics service...")
@@ -360,6 +377,14 @@ func (s *Service) processMessage(ctx context.Context, msg *kafka.Message) error
 		}
 	}
 
+	// Fan out to registered handlers
+	s.handlersMutex.RLock()
+	handlers := s.handlers
+	s.handlersMutex.RUnlock()
+	for _, handler := range handlers {
+		handler.OnGameEvent(ctx, &event)
+	}
+
 	s.logger.Debug("Event processed successfully",
 		"eventType", event.EventType,
 		"gameID", event.GameID,
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"connect4-multiplayer/internal/achievements"
+)
+
+// AchievementHandler handles achievement-related HTTP requests
+type AchievementHandler struct {
+	achievementService achievements.AchievementService
+}
+
+// NewAchievementHandler creates a new AchievementHandler instance
+func NewAchievementHandler(achievementService achievements.AchievementService) *AchievementHandler {
+	return &AchievementHandler{
+		achievementService: achievementService,
+	}
+}
+
+// ListAchievements returns the achievement catalogue
+// @Summary List achievements
+// @Description Retrieve every achievement that can be unlocked
+// @Tags achievements
+// @Accept json
+// @Produce json
+// @Success 200 {array} achievements.Rule
+// @Router /achievements [get]
+func (h *AchievementHandler) ListAchievements(c *gin.Context) {
+	c.JSON(http.StatusOK, h.achievementService.Rules())
+}
+
+// GetPlayerAchievements retrieves the achievements unlocked by a player
+// @Summary Get player achievements
+// @Description Retrieve the badges a player has unlocked
+// @Tags players
+// @Accept json
+// @Produce json
+// @Param id path string true "Player username"
+// @Success 200 {array} achievements.UnlockedAchievement
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /players/{id}/achievements [get]
+func (h *AchievementHandler) GetPlayerAchievements(c *gin.Context) {
+	username := c.Param("id")
+	if username == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Player username is required",
+		})
+		return
+	}
+
+	unlocked, err := h.achievementService.GetPlayerAchievements(c.Request.Context(), username)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to retrieve achievements",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, unlocked)
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"connect4-multiplayer/internal/bot"
+	"connect4-multiplayer/pkg/models"
+)
+
+// AnalysisHandler handles position analysis HTTP requests
+type AnalysisHandler struct {
+	analyzer bot.PositionAnalyzer
+}
+
+// NewAnalysisHandler creates a new AnalysisHandler instance
+func NewAnalysisHandler(analyzer bot.PositionAnalyzer) *AnalysisHandler {
+	return &AnalysisHandler{
+		analyzer: analyzer,
+	}
+}
+
+// AnalysisRequest is a position given either as the columns played from
+// the empty board, red first, or as a board. The side to move is derived
+// from the number of discs.
+type AnalysisRequest struct {
+	Moves []int         `json:"moves,omitempty"`
+	Board *models.Board `json:"board,omitempty"`
+}
+
+// AnalyzePosition evaluates every column of a position
+// @Summary Analyze a position
+// @Description Return the engine's score, principal variation and, when proven, the outcome of every column, along with the search depth and nodes
+// @Tags analysis
+// @Accept json
+// @Produce json
+// @Param request body AnalysisRequest true "Position as a move sequence or a board"
+// @Success 200 {object} bot.PositionAnalysis
+// @Failure 400 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /analysis [post]
+func (h *AnalysisHandler) AnalyzePosition(c *gin.Context) {
+	board, player, ok := bindPosition(c)
+	if !ok {
+		return
+	}
+
+	analysis, err := h.analyzer.Analyze(c.Request.Context(), &board, player)
+	switch {
+	case errors.Is(err, bot.ErrPositionDecided), errors.Is(err, bot.ErrBoardFull):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Position is already decided",
+			Details: err.Error(),
+		})
+		return
+	case errors.Is(err, bot.ErrAnalyzerBusy):
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error: "Too many analyses in progress, try again shortly",
+		})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to analyze position",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, analysis)
+}
+
+// AnalyzeThreats lists the threats in a position
+// @Summary Analyze threats
+// @Description List each side's threats (empty cells that would complete four) with their row parity and urgency, and say who controls zugzwang
+// @Tags analysis
+// @Accept json
+// @Produce json
+// @Param request body AnalysisRequest true "Position as a move sequence or a board"
+// @Success 200 {object} bot.ThreatAnalysis
+// @Failure 400 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Router /analysis/threats [post]
+func (h *AnalysisHandler) AnalyzeThreats(c *gin.Context) {
+	board, player, ok := bindPosition(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, bot.AnalyzeThreats(&board, player))
+}
+
+// bindPosition reads the position of an AnalysisRequest and returns it with
+// the side to move. It writes a 400 response and reports false if the
+// request or the position is invalid.
+func bindPosition(c *gin.Context) (models.Board, models.PlayerColor, bool) {
+	var req AnalysisRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return models.Board{}, "", false
+	}
+	if req.Board != nil && req.Moves != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Provide either moves or a board, not both",
+		})
+		return models.Board{}, "", false
+	}
+
+	var (
+		board  models.Board
+		player models.PlayerColor
+		err    error
+	)
+	if req.Board != nil {
+		board = *req.Board
+		player, err = bot.ValidatePosition(&board)
+	} else {
+		board, player, err = bot.PositionFromMoves(req.Moves)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid position",
+			Details: err.Error(),
+		})
+		return models.Board{}, "", false
+	}
+	return board, player, true
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"connect4-multiplayer/internal/social"
+)
+
+// BlockHandler handles block list HTTP requests
+type BlockHandler struct {
+	blockService social.BlockService
+	validator    *validator.Validate
+}
+
+// NewBlockHandler creates a new BlockHandler instance
+func NewBlockHandler(blockService social.BlockService) *BlockHandler {
+	return &BlockHandler{
+		blockService: blockService,
+		validator:    validator.New(),
+	}
+}
+
+// BlockRequest represents the request to block a player
+type BlockRequest struct {
+	Username string `json:"username" validate:"required,min=3,max=20"`
+}
+
+// ListBlocked retrieves the players a player has blocked
+// @Summary List blocked players
+// @Description Retrieve the players a player has blocked
+// @Tags blocks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Player username"
+// @Success 200 {array} models.PlayerBlock
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /players/{id}/blocks [get]
+func (h *BlockHandler) ListBlocked(c *gin.Context) {
+	blocks, err := h.blockService.ListBlocked(c.Request.Context(), c.GetString("username"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to retrieve blocked players",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, blocks)
+}
+
+// Block adds a player to the block list
+// @Summary Block player
+// @Description Block a player; blocked players are never matched, cannot challenge or send friend requests, and cannot join each other's rooms
+// @Tags blocks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Player username"
+// @Param request body BlockRequest true "Player to block"
+// @Success 201 {object} models.PlayerBlock
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /players/{id}/blocks [post]
+func (h *BlockHandler) Block(c *gin.Context) {
+	var req BlockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	block, err := h.blockService.Block(c.Request.Context(), c.GetString("username"), req.Username)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, social.ErrSelfRelation) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, ErrorResponse{
+			Error:   "Failed to block player",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, block)
+}
+
+// Unblock removes a player from the block list
+// @Summary Unblock player
+// @Description Remove a player from the block list
+// @Tags blocks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Player username"
+// @Param blocked path string true "Blocked player username"
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /players/{id}/blocks/{blocked} [delete]
+func (h *BlockHandler) Unblock(c *gin.Context) {
+	if err := h.blockService.Unblock(c.Request.Context(), c.GetString("username"), c.Param("blocked")); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to unblock player",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
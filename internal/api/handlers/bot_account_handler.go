@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"connect4-multiplayer/internal/botaccounts"
+	"connect4-multiplayer/pkg/models"
+)
+
+// BotAccountHandler handles bot account HTTP requests
+type BotAccountHandler struct {
+	botAccountService botaccounts.BotAccountService
+	validator         *validator.Validate
+}
+
+// NewBotAccountHandler creates a new BotAccountHandler instance
+func NewBotAccountHandler(botAccountService botaccounts.BotAccountService) *BotAccountHandler {
+	return &BotAccountHandler{
+		botAccountService: botAccountService,
+		validator:         validator.New(),
+	}
+}
+
+// CreateBotRequest represents a request to register a bot account
+type CreateBotRequest struct {
+	Username    string `json:"username" validate:"required,min=3,max=20"`
+	Description string `json:"description" validate:"max=500"`
+}
+
+// BotTokenResponse carries a bot's API token, which is only shown once
+type BotTokenResponse struct {
+	Bot   *models.BotAccount `json:"bot,omitempty"`
+	Token string             `json:"token"`
+}
+
+// CreateBot registers a bot account for the signed-in developer
+// @Summary Create bot account
+// @Description Register a bot account that a program plays through the bot WebSocket API. The response holds the bot's API token, which is not shown again.
+// @Tags bots
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateBotRequest true "Bot username and description"
+// @Success 201 {object} BotTokenResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots [post]
+func (h *BotAccountHandler) CreateBot(c *gin.Context) {
+	ownerID := c.GetString("userID")
+	if ownerID == "" {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+
+	var req CreateBotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	account, token, err := h.botAccountService.CreateBot(c.Request.Context(), ownerID, req.Username, req.Description)
+	switch {
+	case errors.Is(err, botaccounts.ErrInvalidUsername), errors.Is(err, botaccounts.ErrTooManyBots):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Cannot create bot",
+			Details: err.Error(),
+		})
+		return
+	case errors.Is(err, botaccounts.ErrUsernameTaken):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "Username is already taken",
+			Details: err.Error(),
+		})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to create bot",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, BotTokenResponse{Bot: account, Token: token})
+}
+
+// ListBots lists the signed-in developer's bot accounts
+// @Summary List bot accounts
+// @Description List the bot accounts owned by the signed-in developer
+// @Tags bots
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.BotAccount
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots [get]
+func (h *BotAccountHandler) ListBots(c *gin.Context) {
+	ownerID := c.GetString("userID")
+	if ownerID == "" {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+
+	accounts, err := h.botAccountService.ListBots(c.Request.Context(), ownerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list bots",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, accounts)
+}
+
+// RotateToken replaces a bot's API token
+// @Summary Rotate bot token
+// @Description Issue a new API token for one of the signed-in developer's bots. The old token stops working; open connections stay up until they close.
+// @Tags bots
+// @Produce json
+// @Security BearerAuth
+// @Param username path string true "Bot username"
+// @Success 200 {object} BotTokenResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /bots/{username}/token [post]
+func (h *BotAccountHandler) RotateToken(c *gin.Context) {
+	ownerID := c.GetString("userID")
+	if ownerID == "" {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+
+	token, err := h.botAccountService.RotateToken(c.Request.Context(), ownerID, c.Param("username"))
+	if err != nil {
+		if errors.Is(err, botaccounts.ErrBotNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Bot not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to rotate token",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, BotTokenResponse{Token: token})
+}
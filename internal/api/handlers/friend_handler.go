@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"connect4-multiplayer/internal/social"
+)
+
+// FriendHandler handles friend list HTTP requests
+type FriendHandler struct {
+	friendService social.FriendService
+	validator     *validator.Validate
+}
+
+// NewFriendHandler creates a new FriendHandler instance
+func NewFriendHandler(friendService social.FriendService) *FriendHandler {
+	return &FriendHandler{
+		friendService: friendService,
+		validator:     validator.New(),
+	}
+}
+
+// FriendRequest represents the request to add a friend
+type FriendRequest struct {
+	Username string `json:"username" validate:"required,min=3,max=20"`
+}
+
+// ListFriends retrieves a player's friends with their online presence
+// @Summary List friends
+// @Description Retrieve a player's accepted friends and whether they are online
+// @Tags friends
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Player username"
+// @Success 200 {array} social.Friend
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /players/{id}/friends [get]
+func (h *FriendHandler) ListFriends(c *gin.Context) {
+	friends, err := h.friendService.ListFriends(c.Request.Context(), c.GetString("username"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to retrieve friends",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, friends)
+}
+
+// SendRequest sends a friend request
+// @Summary Send friend request
+// @Description Send a friend request; if the other player already asked, the friendship is accepted
+// @Tags friends
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Player username"
+// @Param request body FriendRequest true "Player to befriend"
+// @Success 201 {object} models.Friendship
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /players/{id}/friends [post]
+func (h *FriendHandler) SendRequest(c *gin.Context) {
+	var req FriendRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	friendship, err := h.friendService.SendRequest(c.Request.Context(), c.GetString("username"), req.Username)
+	if err != nil {
+		h.writeError(c, err, "Failed to send friend request")
+		return
+	}
+
+	c.JSON(http.StatusCreated, friendship)
+}
+
+// ListPendingRequests retrieves friend requests awaiting the player's answer
+// @Summary List pending friend requests
+// @Description Retrieve friend requests sent to a player that have not been answered
+// @Tags friends
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Player username"
+// @Success 200 {array} models.Friendship
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /players/{id}/friends/requests [get]
+func (h *FriendHandler) ListPendingRequests(c *gin.Context) {
+	requests, err := h.friendService.ListPendingRequests(c.Request.Context(), c.GetString("username"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to retrieve friend requests",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// AcceptRequest accepts a pending friend request
+// @Summary Accept friend request
+// @Description Accept a friend request addressed to the player
+// @Tags friends
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Player username"
+// @Param requestId path string true "Friend request ID"
+// @Success 200 {object} models.Friendship
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /players/{id}/friends/requests/{requestId}/accept [post]
+func (h *FriendHandler) AcceptRequest(c *gin.Context) {
+	friendship, err := h.friendService.AcceptRequest(c.Request.Context(), c.GetString("username"), c.Param("requestId"))
+	if err != nil {
+		h.writeError(c, err, "Failed to accept friend request")
+		return
+	}
+
+	c.JSON(http.StatusOK, friendship)
+}
+
+// RemoveFriend removes a friend or declines a pending request
+// @Summary Remove friend
+// @Description Remove a friend, decline a request from them or cancel a request sent to them
+// @Tags friends
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Player username"
+// @Param friend path string true "Friend username"
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /players/{id}/friends/{friend} [delete]
+func (h *FriendHandler) RemoveFriend(c *gin.Context) {
+	if err := h.friendService.RemoveFriend(c.Request.Context(), c.GetString("username"), c.Param("friend")); err != nil {
+		h.writeError(c, err, "Failed to remove friend")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// writeError maps social errors to HTTP responses
+func (h *FriendHandler) writeError(c *gin.Context, err error, message string) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, social.ErrSelfRelation):
+		status = http.StatusBadRequest
+	case errors.Is(err, social.ErrAlreadyFriends), errors.Is(err, social.ErrRequestPending):
+		status = http.StatusConflict
+	case errors.Is(err, social.ErrRequestNotFound), errors.Is(err, social.ErrNotFriends):
+		status = http.StatusNotFound
+	case errors.Is(err, social.ErrPlayerBlocked):
+		status = http.StatusForbidden
+	}
+
+	c.JSON(status, ErrorResponse{
+		Error:   message,
+		Details: err.Error(),
+	})
+}
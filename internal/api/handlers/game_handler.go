@@ -214,8 +214,20 @@ func (h *GameHandler) MakeMove(c *gin.Context) {
 		return
 	}
 
-	// Make the move
+	// Handicap games may restrict the stronger player's opening
 	playerColor := session.GetPlayerColor(req.Player)
+	if session.Handicap != nil {
+		if err := session.Handicap.CheckMove(&session.Board, playerColor, req.Column); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid move: the handicap forbids this opening",
+				Details: err.Error(),
+			})
+			return
+		}
+	}
+
+	// Make the move
+	row := session.Board.Height[req.Column]
 	if err := session.Board.MakeMove(req.Column, playerColor); err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error: "Failed to make move",
@@ -224,6 +236,15 @@ func (h *GameHandler) MakeMove(c *gin.Context) {
 		return
 	}
 
+	// Record the move before the game can complete
+	if err := h.gameService.RecordMove(c.Request.Context(), gameID, playerColor, req.Column, row); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to record move",
+			Details: err.Error(),
+		})
+		return
+	}
+
 	// Check for win or draw
 	winner := session.Board.CheckWin()
 	if winner != nil {
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"connect4-multiplayer/internal/stats"
+)
+
+// HeadToHeadHandler handles head-to-head record HTTP requests
+type HeadToHeadHandler struct {
+	headToHeadService stats.HeadToHeadService
+}
+
+// NewHeadToHeadHandler creates a new HeadToHeadHandler instance
+func NewHeadToHeadHandler(headToHeadService stats.HeadToHeadService) *HeadToHeadHandler {
+	return &HeadToHeadHandler{
+		headToHeadService: headToHeadService,
+	}
+}
+
+// GetHeadToHead retrieves the record between two players
+// @Summary Get head-to-head record
+// @Description Retrieve wins, losses, draws, per-color results, average game length and recent games of a player against an opponent
+// @Tags players
+// @Accept json
+// @Produce json
+// @Param id path string true "Player username"
+// @Param opponent path string true "Opponent username"
+// @Success 200 {object} stats.HeadToHead
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /players/{id}/vs/{opponent} [get]
+func (h *HeadToHeadHandler) GetHeadToHead(c *gin.Context) {
+	player := c.Param("id")
+	opponent := c.Param("opponent")
+	if player == "" || opponent == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Both player usernames are required",
+		})
+		return
+	}
+	if player == opponent {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Players must be different",
+		})
+		return
+	}
+
+	record, err := h.headToHeadService.GetHeadToHead(c.Request.Context(), player, opponent)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to retrieve head-to-head record",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, record)
+}
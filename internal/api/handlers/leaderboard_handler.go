@@ -23,11 +23,12 @@ func NewLeaderboardHandler(statsRepo repositories.PlayerStatsRepository) *Leader
 
 // GetLeaderboard retrieves the top players leaderboard
 // @Summary Get leaderboard
-// @Description Retrieve the top players ranked by wins
+// @Description Retrieve the top players ranked by wins. Bot accounts are ranked on a separate leaderboard.
 // @Tags leaderboard
 // @Accept json
 // @Produce json
 // @Param limit query int false "Number of players to return (default: 10, max: 100)"
+// @Param type query string false "Leaderboard to return: players (default) or bots"
 // @Success 200 {array} models.PlayerStats
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -45,8 +46,22 @@ func (h *LeaderboardHandler) GetLeaderboard(c *gin.Context) {
 		limit = 100
 	}
 
+	// Humans and bot accounts are ranked separately
+	getLeaderboard := h.statsRepo.GetLeaderboard
+	switch c.DefaultQuery("type", "players") {
+	case "players":
+	case "bots":
+		getLeaderboard = h.statsRepo.GetBotLeaderboard
+	default:
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid leaderboard type",
+			Details: "type must be players or bots",
+		})
+		return
+	}
+
 	// Get leaderboard data
-	leaderboard, err := h.statsRepo.GetLeaderboard(c.Request.Context(), limit)
+	leaderboard, err := getLeaderboard(c.Request.Context(), limit)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error: "Failed to retrieve leaderboard",
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"connect4-multiplayer/internal/notifications"
+	"connect4-multiplayer/pkg/models"
+)
+
+// NotificationHandler handles notification inbox HTTP requests
+type NotificationHandler struct {
+	notificationService notifications.NotificationService
+}
+
+// NewNotificationHandler creates a new NotificationHandler instance
+func NewNotificationHandler(notificationService notifications.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// NotificationListResponse represents a page of a player's notifications
+type NotificationListResponse struct {
+	Notifications []*models.Notification `json:"notifications"`
+	UnreadCount   int64                  `json:"unreadCount"`
+}
+
+// MarkAllReadResponse represents the result of marking the inbox as read
+type MarkAllReadResponse struct {
+	Updated int64 `json:"updated"`
+}
+
+// ListNotifications retrieves a player's notifications
+// @Summary List notifications
+// @Description Retrieve a player's notifications, newest first, with the number still unread
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Player username"
+// @Param unread query bool false "Only return unread notifications"
+// @Param limit query int false "Number of notifications to return (default: 20, max: 100)"
+// @Param offset query int false "Number of notifications to skip"
+// @Success 200 {object} NotificationListResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /players/{id}/notifications [get]
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	username := c.GetString("username")
+	unreadOnly := c.Query("unread") == "true"
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	list, err := h.notificationService.List(c.Request.Context(), username, unreadOnly, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to retrieve notifications",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	unread, err := h.notificationService.UnreadCount(c.Request.Context(), username)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to count unread notifications",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, NotificationListResponse{
+		Notifications: list,
+		UnreadCount:   unread,
+	})
+}
+
+// MarkRead marks a notification as read
+// @Summary Mark notification read
+// @Description Mark one of the player's notifications as read
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Player username"
+// @Param notificationId path string true "Notification ID"
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /players/{id}/notifications/{notificationId}/read [post]
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	err := h.notificationService.MarkRead(c.Request.Context(), c.GetString("username"), c.Param("notificationId"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, notifications.ErrNotificationNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, ErrorResponse{
+			Error:   "Failed to mark notification as read",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// MarkAllRead marks all of a player's notifications as read
+// @Summary Mark all notifications read
+// @Description Mark every unread notification of the player as read
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Player username"
+// @Success 200 {object} MarkAllReadResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /players/{id}/notifications/read [post]
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	updated, err := h.notificationService.MarkAllRead(c.Request.Context(), c.GetString("username"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to mark notifications as read",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, MarkAllReadResponse{Updated: updated})
+}
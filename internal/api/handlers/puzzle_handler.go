@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"connect4-multiplayer/internal/puzzles"
+	"connect4-multiplayer/pkg/models"
+)
+
+// PuzzleHandler handles puzzle HTTP requests
+type PuzzleHandler struct {
+	puzzleService puzzles.PuzzleService
+	validator     *validator.Validate
+}
+
+// NewPuzzleHandler creates a new PuzzleHandler instance
+func NewPuzzleHandler(puzzleService puzzles.PuzzleService) *PuzzleHandler {
+	return &PuzzleHandler{
+		puzzleService: puzzleService,
+		validator:     validator.New(),
+	}
+}
+
+// AttemptRequest represents a player's move in a puzzle
+type AttemptRequest struct {
+	Username string `json:"username" validate:"required,min=3,max=20"`
+	Column   *int   `json:"column" validate:"required,min=0,max=6"`
+}
+
+// NextPuzzle serves the player a puzzle they have not tried
+// @Summary Get next puzzle
+// @Description Serve the untried puzzle whose rating is closest to the player's puzzle rating. Puzzles are forced wins mined from completed games; the solutions are revealed by an attempt.
+// @Tags puzzles
+// @Produce json
+// @Param username query string true "Player username"
+// @Success 200 {object} puzzles.Challenge
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /puzzles/next [get]
+func (h *PuzzleHandler) NextPuzzle(c *gin.Context) {
+	username := c.Query("username")
+	if err := h.validator.Var(username, "required,min=3,max=20"); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid username",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	challenge, err := h.puzzleService.NextPuzzle(c.Request.Context(), username)
+	if err != nil {
+		if errors.Is(err, puzzles.ErrNoPuzzles) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "No puzzles left to try",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to retrieve puzzle",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, challenge)
+}
+
+// Attempt checks a player's move in a puzzle
+// @Summary Attempt a puzzle
+// @Description Check the column a player chose against the puzzle's solutions. The first attempt at each puzzle adjusts both the player's and the puzzle's rating.
+// @Tags puzzles
+// @Accept json
+// @Produce json
+// @Param id path string true "Puzzle ID"
+// @Param request body AttemptRequest true "Player and chosen column"
+// @Success 200 {object} puzzles.AttemptResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /puzzles/{id}/attempt [post]
+func (h *PuzzleHandler) Attempt(c *gin.Context) {
+	var req AttemptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.puzzleService.Attempt(c.Request.Context(), c.Param("id"), req.Username, *req.Column)
+	switch {
+	case errors.Is(err, puzzles.ErrPuzzleNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Puzzle not found",
+		})
+		return
+	case errors.Is(err, models.ErrInvalidMove):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid column",
+			Details: err.Error(),
+		})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to record attempt",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
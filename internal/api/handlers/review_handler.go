@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"connect4-multiplayer/internal/review"
+	"connect4-multiplayer/pkg/models"
+)
+
+// ReviewHandler handles post-game review HTTP requests
+type ReviewHandler struct {
+	reviewService review.ReviewService
+}
+
+// NewReviewHandler creates a new ReviewHandler instance
+func NewReviewHandler(reviewService review.ReviewService) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+	}
+}
+
+// GetReview retrieves the engine review of a completed game
+// @Summary Get game review
+// @Description Retrieve per-move classifications (best, good, inaccuracy, mistake, blunder) and per-player accuracy. Reviews run in the background after a game ends; until then the review is returned with status pending and code 202.
+// @Tags games
+// @Produce json
+// @Param id path string true "Game ID"
+// @Success 200 {object} models.GameReview
+// @Success 202 {object} models.GameReview
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /games/{id}/review [get]
+func (h *ReviewHandler) GetReview(c *gin.Context) {
+	gameID := c.Param("id")
+	if gameID == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Game ID is required",
+		})
+		return
+	}
+
+	gameReview, err := h.reviewService.GetReview(c.Request.Context(), gameID)
+	switch {
+	case errors.Is(err, models.ErrGameNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Game not found",
+		})
+		return
+	case errors.Is(err, review.ErrGameNotCompleted):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error: "Game is still in progress",
+		})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to retrieve review",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if !gameReview.IsDone() {
+		c.JSON(http.StatusAccepted, gameReview)
+		return
+	}
+	c.JSON(http.StatusOK, gameReview)
+}
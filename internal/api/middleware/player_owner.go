@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"connect4-multiplayer/internal/database/repositories"
+	"connect4-multiplayer/pkg/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PlayerOwnerMiddleware only lets the player named by the :id path parameter
+// act on their own resources. It runs after SupabaseAuthMiddleware, looks up
+// the authenticated user's player and stores its username in the context.
+func PlayerOwnerMiddleware(playerRepo repositories.PlayerRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetString("userID")
+		if userID == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Authentication required",
+			})
+			c.Abort()
+			return
+		}
+
+		player, err := playerRepo.GetByAuthUserID(c.Request.Context(), userID)
+		if errors.Is(err, models.ErrPlayerNotFound) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "No player is linked to this account",
+			})
+			c.Abort()
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to look up player",
+			})
+			c.Abort()
+			return
+		}
+
+		if player.Username != c.Param("id") {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "Cannot act on behalf of another player",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Set("username", player.Username)
+		c.Next()
+	}
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"connect4-multiplayer/internal/database/repositories"
+	"connect4-multiplayer/pkg/models"
+)
+
+// stubPlayerRepository finds players by auth user ID
+type stubPlayerRepository struct {
+	repositories.PlayerRepository
+	players map[string]*models.Player
+}
+
+func (r *stubPlayerRepository) GetByAuthUserID(ctx context.Context, authUserID string) (*models.Player, error) {
+	if player, ok := r.players[authUserID]; ok {
+		return player, nil
+	}
+	return nil, models.ErrPlayerNotFound
+}
+
+func TestPlayerOwnerMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &stubPlayerRepository{players: map[string]*models.Player{
+		"auth-alice": {Username: "alice"},
+	}}
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if userID := c.GetHeader("X-Test-User"); userID != "" {
+			c.Set("userID", userID)
+		}
+	})
+	router.GET("/players/:id/friends", PlayerOwnerMiddleware(repo), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString("username"))
+	})
+
+	for name, tc := range map[string]struct {
+		userID string
+		player string
+		status int
+	}{
+		"unauthenticated":   {player: "alice", status: http.StatusUnauthorized},
+		"no linked player":  {userID: "auth-bobby", player: "bobby", status: http.StatusForbidden},
+		"another player":    {userID: "auth-alice", player: "bobby", status: http.StatusForbidden},
+		"the player itself": {userID: "auth-alice", player: "alice", status: http.StatusOK},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/players/"+tc.player+"/friends", nil)
+		req.Header.Set("X-Test-User", tc.userID)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, tc.status, rec.Code, name)
+		if tc.status == http.StatusOK {
+			assert.Equal(t, "alice", rec.Body.String(), name)
+		}
+	}
+}
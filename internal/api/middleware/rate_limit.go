@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxIdleBuckets is how many client buckets are kept before full ones are
+// dropped; a full bucket behaves like a new one
+const maxIdleBuckets = 10000
+
+// RateLimitMiddleware limits each client IP to rate requests per second,
+// with bursts of up to burst requests. Requests over the limit get 429.
+func RateLimitMiddleware(rate float64, burst int) gin.HandlerFunc {
+	limiter := newIPRateLimiter(rate, burst)
+	return func(c *gin.Context) {
+		if !limiter.allow(c.ClientIP()) {
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error": "Too many requests, try again shortly",
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// ipRateLimiter is a token bucket per client IP. Each bucket holds up to
+// burst tokens and refills at rate tokens per second.
+type ipRateLimiter struct {
+	rate  float64
+	burst float64
+	now   func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// bucket is one client's remaining tokens as of updated
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+func newIPRateLimiter(rate float64, burst int) *ipRateLimiter {
+	return &ipRateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// allow takes a token from key's bucket and reports whether there was one
+func (l *ipRateLimiter) allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, exists := l.buckets[key]
+	if !exists {
+		if len(l.buckets) >= maxIdleBuckets {
+			l.dropFullBuckets(now)
+		}
+		b = &bucket{tokens: l.burst, updated: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.updated).Seconds()*l.rate)
+	b.updated = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// dropFullBuckets forgets clients whose buckets have refilled
+func (l *ipRateLimiter) dropFullBuckets(now time.Time) {
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.updated).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/analysis", RateLimitMiddleware(1, 2), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	post := func(ip string) int {
+		req := httptest.NewRequest(http.MethodPost, "/analysis", nil)
+		req.RemoteAddr = ip + ":1234"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// A client gets its burst, then is refused
+	assert.Equal(t, http.StatusOK, post("10.0.0.1"))
+	assert.Equal(t, http.StatusOK, post("10.0.0.1"))
+	assert.Equal(t, http.StatusTooManyRequests, post("10.0.0.1"))
+
+	// Limits are per client IP
+	assert.Equal(t, http.StatusOK, post("10.0.0.2"))
+}
+
+func TestIPRateLimiter(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	limiter := newIPRateLimiter(2, 3)
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, limiter.allow("10.0.0.1"))
+	}
+	assert.False(t, limiter.allow("10.0.0.1"))
+
+	// Half a second refills one token at two per second
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, limiter.allow("10.0.0.1"))
+	assert.False(t, limiter.allow("10.0.0.1"))
+
+	// Refilled buckets are dropped once there are too many
+	now = now.Add(time.Minute)
+	limiter.dropFullBuckets(now)
+	assert.Empty(t, limiter.buckets)
+}
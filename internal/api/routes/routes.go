@@ -9,6 +9,7 @@ import (
 	"connect4-multiplayer/internal/api/middleware"
 	"connect4-multiplayer/internal/auth"
 	"connect4-multiplayer/internal/config"
+	"connect4-multiplayer/internal/database/repositories"
 	"connect4-multiplayer/internal/websocket"
 )
 
@@ -19,14 +20,24 @@ func SetupRoutes(
 	gameHandler *handlers.GameHandler,
 	leaderboardHandler *handlers.LeaderboardHandler,
 	authHandler *handlers.AuthHandler,
+	achievementHandler *handlers.AchievementHandler,
+	headToHeadHandler *handlers.HeadToHeadHandler,
+	friendHandler *handlers.FriendHandler,
+	blockHandler *handlers.BlockHandler,
+	notificationHandler *handlers.NotificationHandler,
+	analysisHandler *handlers.AnalysisHandler,
+	reviewHandler *handlers.ReviewHandler,
+	puzzleHandler *handlers.PuzzleHandler,
+	botAccountHandler *handlers.BotAccountHandler,
 	wsHandler *websocket.WebSocketHandler,
 	supabaseAuth *auth.SupabaseAuth,
+	playerRepo repositories.PlayerRepository,
 ) {
 	// Setup middleware
 	setupMiddleware(router, cfg)
 
 	// Setup API routes
-	setupAPIRoutes(router, gameHandler, leaderboardHandler, authHandler, supabaseAuth)
+	setupAPIRoutes(router, gameHandler, leaderboardHandler, authHandler, achievementHandler, headToHeadHandler, friendHandler, blockHandler, notificationHandler, analysisHandler, reviewHandler, puzzleHandler, botAccountHandler, supabaseAuth, playerRepo)
 
 	// Setup WebSocket routes
 	setupWebSocketRoutes(router, wsHandler)
@@ -68,13 +79,34 @@ func setupMiddleware(router *gin.Engine, cfg *config.Config) {
 	router.Use(middleware.Validation(validationConfig))
 }
 
+const (
+	// analysisRequestsPerSecond and analysisBurst limit each client's
+	// analysis board requests; every analysis searches for up to two seconds
+	analysisRequestsPerSecond = 0.5
+	analysisBurst             = 5
+	// threatRequestsPerSecond and threatBurst limit threat requests, which
+	// only scan the board and may follow every move on the analysis board
+	threatRequestsPerSecond = 5
+	threatBurst             = 20
+)
+
 // setupAPIRoutes configures all API routes
 func setupAPIRoutes(
 	router *gin.Engine,
 	gameHandler *handlers.GameHandler,
 	leaderboardHandler *handlers.LeaderboardHandler,
 	authHandler *handlers.AuthHandler,
+	achievementHandler *handlers.AchievementHandler,
+	headToHeadHandler *handlers.HeadToHeadHandler,
+	friendHandler *handlers.FriendHandler,
+	blockHandler *handlers.BlockHandler,
+	notificationHandler *handlers.NotificationHandler,
+	analysisHandler *handlers.AnalysisHandler,
+	reviewHandler *handlers.ReviewHandler,
+	puzzleHandler *handlers.PuzzleHandler,
+	botAccountHandler *handlers.BotAccountHandler,
 	supabaseAuth *auth.SupabaseAuth,
+	playerRepo repositories.PlayerRepository,
 ) {
 	// Health check endpoint
 	router.GET("/health", func(c *gin.Context) {
@@ -103,15 +135,64 @@ func setupAPIRoutes(
 			games.POST("", gameHandler.CreateGame)
 			games.GET("/:id", gameHandler.GetGameState)
 			games.POST("/:id/moves", gameHandler.MakeMove)
+			games.GET("/:id/review", reviewHandler.GetReview)
 		}
 
 		// Leaderboard endpoints
 		v1.GET("/leaderboard", leaderboardHandler.GetLeaderboard)
 
+		// Position analysis for the analysis board, open to guests but
+		// limited per client
+		v1.POST("/analysis", middleware.RateLimitMiddleware(analysisRequestsPerSecond, analysisBurst), analysisHandler.AnalyzePosition)
+		v1.POST("/analysis/threats", middleware.RateLimitMiddleware(threatRequestsPerSecond, threatBurst), analysisHandler.AnalyzeThreats)
+
+		// Puzzles mined from completed games
+		puzzleGroup := v1.Group("/puzzles")
+		{
+			puzzleGroup.GET("/next", puzzleHandler.NextPuzzle)
+			puzzleGroup.POST("/:id/attempt", puzzleHandler.Attempt)
+		}
+
+		// Bot accounts registered developers play through the bot WebSocket
+		botGroup := v1.Group("/bots")
+		botGroup.Use(middleware.SupabaseAuthMiddleware(supabaseAuth))
+		{
+			botGroup.POST("", botAccountHandler.CreateBot)
+			botGroup.GET("", botAccountHandler.ListBots)
+			botGroup.POST("/:username/token", botAccountHandler.RotateToken)
+		}
+
+		// Achievement catalogue
+		v1.GET("/achievements", achievementHandler.ListAchievements)
+
 		// Player statistics endpoints
 		players := v1.Group("/players")
 		{
 			players.GET("/:id/stats", leaderboardHandler.GetPlayerStats)
+			players.GET("/:id/achievements", achievementHandler.GetPlayerAchievements)
+			players.GET("/:id/vs/:opponent", headToHeadHandler.GetHeadToHead)
+		}
+
+		// A player's own social resources, only for that signed-in player
+		owner := players.Group("/:id")
+		owner.Use(middleware.SupabaseAuthMiddleware(supabaseAuth), middleware.PlayerOwnerMiddleware(playerRepo))
+		{
+			// Friends
+			owner.GET("/friends", friendHandler.ListFriends)
+			owner.POST("/friends", friendHandler.SendRequest)
+			owner.GET("/friends/requests", friendHandler.ListPendingRequests)
+			owner.POST("/friends/requests/:requestId/accept", friendHandler.AcceptRequest)
+			owner.DELETE("/friends/:friend", friendHandler.RemoveFriend)
+
+			// Block lists
+			owner.GET("/blocks", blockHandler.ListBlocked)
+			owner.POST("/blocks", blockHandler.Block)
+			owner.DELETE("/blocks/:blocked", blockHandler.Unblock)
+
+			// Notifications inbox
+			owner.GET("/notifications", notificationHandler.ListNotifications)
+			owner.POST("/notifications/read", notificationHandler.MarkAllRead)
+			owner.POST("/notifications/:notificationId/read", notificationHandler.MarkRead)
 		}
 	}
 }
@@ -126,4 +207,10 @@ func setupDocumentation(router *gin.Engine) {
 func setupWebSocketRoutes(router *gin.Engine, wsHandler *websocket.WebSocketHandler) {
 	// WebSocket endpoint for real-time game communication
 	router.GET("/ws", wsHandler.HandleWebSocket)
+
+	// Bot accounts connect with their API token
+	router.GET("/ws/bot", wsHandler.HandleBotWebSocket)
+
+	// Connection and bot scheduler metrics
+	router.GET("/metrics", wsHandler.HandleMetrics)
 }
@@ -0,0 +1,131 @@
+package bot
+
+import (
+	"fmt"
+	"math"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+// Adaptive bots play at a strength between 0 (barely looks ahead, blunders
+// often) and 1 (a hard bot). The strength is estimated from the human's
+// results and nudged during the game so the human's expected score stays near
+// 50%.
+const (
+	// DefaultAdaptiveStrength is used when nothing is known about the human
+	DefaultAdaptiveStrength = 0.5
+	// NewPlayerAdaptiveStrength is used for players with few games, so a
+	// newcomer starts against a gentle bot
+	NewPlayerAdaptiveStrength = 0.2
+	// adaptiveProvisionalGames is how many games a player needs before their
+	// win rate is trusted
+	adaptiveProvisionalGames = 10
+	// adaptiveStep is how far one result moves the strength: a human win
+	// raises it by half a step, a loss lowers it by half a step
+	adaptiveStep = 0.16
+	// adaptiveInGameSwing bounds the in-game adjustment either way
+	adaptiveInGameSwing = 0.25
+)
+
+// adaptiveLevel is the search and play style at one strength
+type adaptiveLevel struct {
+	strength    float64
+	depth       float64
+	temperature float64
+	blunderRate float64
+}
+
+// adaptiveLevels are interpolated linearly. Strength 0.2 and 0.5 play like
+// easy and medium bots and strength 1 like a hard bot.
+var adaptiveLevels = []adaptiveLevel{
+	{strength: 0, depth: 1, temperature: 60, blunderRate: 0.3},
+	{strength: 0.2, depth: 2, temperature: 40, blunderRate: 0.2},
+	{strength: 0.5, depth: 4, temperature: 15, blunderRate: 0.07},
+	{strength: 1, depth: 7, temperature: 3, blunderRate: 0.02},
+}
+
+// adaptiveLevelAt interpolates adaptiveLevels at strength
+func adaptiveLevelAt(strength float64) adaptiveLevel {
+	strength = clampStrength(strength)
+	for i := 1; i < len(adaptiveLevels); i++ {
+		lo, hi := adaptiveLevels[i-1], adaptiveLevels[i]
+		if strength > hi.strength {
+			continue
+		}
+		if strength == hi.strength {
+			return hi
+		}
+		t := (strength - lo.strength) / (hi.strength - lo.strength)
+		return adaptiveLevel{
+			strength:    strength,
+			depth:       lo.depth + t*(hi.depth-lo.depth),
+			temperature: lo.temperature + t*(hi.temperature-lo.temperature),
+			blunderRate: lo.blunderRate + t*(hi.blunderRate-lo.blunderRate),
+		}
+	}
+	return adaptiveLevels[len(adaptiveLevels)-1]
+}
+
+// AdaptiveDepth returns the search depth of an adaptive bot at strength
+func AdaptiveDepth(strength float64) int {
+	return int(math.Round(adaptiveLevelAt(strength).depth))
+}
+
+// AdaptiveStyle returns the move selection style of an adaptive bot at
+// strength
+func AdaptiveStyle(strength float64) PlayStyle {
+	level := adaptiveLevelAt(strength)
+	return PlayStyle{
+		Temperature: level.temperature,
+		BlunderRate: level.blunderRate,
+		CloseMargin: scoreThreeInRow,
+	}
+}
+
+// InitialStrength estimates a player's strength from their overall record.
+// Players with fewer than ten games start at NewPlayerAdaptiveStrength.
+func InitialStrength(gamesPlayed int, winRate float64) float64 {
+	if gamesPlayed < adaptiveProvisionalGames {
+		return NewPlayerAdaptiveStrength
+	}
+	return clampStrength(0.15 + 0.7*winRate)
+}
+
+// UpdateStrength moves strength after a game against an adaptive bot.
+// humanScore is 1 if the human won, 0.5 for a draw and 0 if they lost, so
+// the strength settles where the human scores half the points.
+func UpdateStrength(strength, humanScore float64) float64 {
+	return clampStrength(strength + adaptiveStep*(humanScore-0.5))
+}
+
+// AdaptiveStrength adjusts strength to how the game is going: the bot eases
+// off when it is ahead on board and tightens up when it is behind. board is
+// evaluated for the bot's color.
+func AdaptiveStrength(strength float64, board *models.Board, botColor models.PlayerColor) float64 {
+	eval := newMinimaxBot(1, DefaultEvaluationWeights(), 0).EvaluatePosition(board, botColor)
+	swing := adaptiveInGameSwing * math.Tanh(float64(eval)/float64(2*scoreThreeInRow))
+	return clampStrength(strength - swing)
+}
+
+// CreateAdaptiveBot creates a minimax bot playing at strength
+func (s *botPlayerService) CreateAdaptiveBot(strength float64) *BotPlayer {
+	id := s.botCounter.Add(1)
+	strength = clampStrength(strength)
+
+	return &BotPlayer{
+		ID:         fmt.Sprintf("bot_%d", id),
+		Username:   fmt.Sprintf("%sAdaptive_%d", BotUsernamePrefix, id),
+		Difficulty: DifficultyAdaptive,
+		Style:      AdaptiveStyle(strength),
+		Algorithm:  AlgorithmMinimax,
+		Strength:   strength,
+		AI:         NewMinimaxBotWithDepth(AdaptiveDepth(strength)),
+	}
+}
+
+func clampStrength(strength float64) float64 {
+	if math.IsNaN(strength) {
+		return DefaultAdaptiveStrength
+	}
+	return math.Max(0, math.Min(1, strength))
+}
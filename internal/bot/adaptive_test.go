@@ -0,0 +1,89 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+func TestAdaptiveLevels(t *testing.T) {
+	// The anchors play like the fixed difficulties
+	assert.Equal(t, DifficultyEasy.SearchDepth(), AdaptiveDepth(0.2))
+	assert.Equal(t, DifficultyEasy.PlayStyle(), AdaptiveStyle(0.2))
+	assert.Equal(t, DifficultyMedium.SearchDepth(), AdaptiveDepth(0.5))
+	assert.Equal(t, DifficultyMedium.PlayStyle(), AdaptiveStyle(0.5))
+	assert.Equal(t, DifficultyHard.SearchDepth(), AdaptiveDepth(1))
+	assert.Equal(t, DifficultyHard.PlayStyle(), AdaptiveStyle(1))
+
+	// Stronger never plays worse
+	for s := 0.0; s < 1; s += 0.05 {
+		weaker, stronger := AdaptiveStyle(s), AdaptiveStyle(s+0.05)
+		assert.LessOrEqual(t, AdaptiveDepth(s), AdaptiveDepth(s+0.05))
+		assert.GreaterOrEqual(t, weaker.Temperature, stronger.Temperature)
+		assert.GreaterOrEqual(t, weaker.BlunderRate, stronger.BlunderRate)
+	}
+
+	assert.Equal(t, AdaptiveStyle(0), AdaptiveStyle(-3))
+	assert.Equal(t, AdaptiveStyle(1), AdaptiveStyle(7))
+}
+
+func TestStrengthEstimates(t *testing.T) {
+	assert.Equal(t, NewPlayerAdaptiveStrength, InitialStrength(3, 1))
+	assert.InDelta(t, 0.5, InitialStrength(40, 0.5), 1e-9)
+	assert.Greater(t, InitialStrength(40, 0.9), InitialStrength(40, 0.2))
+
+	assert.Greater(t, UpdateStrength(0.5, 1), 0.5)
+	assert.Equal(t, 0.5, UpdateStrength(0.5, 0.5))
+	assert.Less(t, UpdateStrength(0.5, 0), 0.5)
+	assert.Equal(t, 1.0, UpdateStrength(1, 1))
+	assert.Equal(t, 0.0, UpdateStrength(0, 0))
+}
+
+func TestAdaptiveStrength_EasesOffWhenAhead(t *testing.T) {
+	board := models.NewBoard()
+	assert.InDelta(t, 0.5, AdaptiveStrength(0.5, &board, models.PlayerColorRed), 1e-9)
+
+	// Red has an open three along the bottom
+	for _, col := range []int{1, 2, 3} {
+		require.NoError(t, board.MakeMove(col, models.PlayerColorRed))
+	}
+	require.NoError(t, board.MakeMove(1, models.PlayerColorYellow))
+	require.NoError(t, board.MakeMove(2, models.PlayerColorYellow))
+
+	ahead := AdaptiveStrength(0.5, &board, models.PlayerColorRed)
+	behind := AdaptiveStrength(0.5, &board, models.PlayerColorYellow)
+	assert.Less(t, ahead, 0.5)
+	assert.Greater(t, behind, 0.5)
+	assert.GreaterOrEqual(t, ahead, 0.5-adaptiveInGameSwing)
+}
+
+func TestCreateAdaptiveBot(t *testing.T) {
+	service := NewBotPlayerServiceWithSeed(1)
+
+	player := service.CreateAdaptiveBot(0.8)
+	assert.Equal(t, DifficultyAdaptive, player.Difficulty)
+	assert.Equal(t, 0.8, player.Strength)
+	assert.Equal(t, AdaptiveStyle(0.8), player.Style)
+	assert.True(t, IsBotUsername(player.Username))
+	difficulty, ok := DifficultyFromUsername(player.Username)
+	assert.True(t, ok)
+	assert.Equal(t, DifficultyAdaptive, difficulty)
+
+	player = service.CreateBot(DifficultyAdaptive)
+	assert.Equal(t, DefaultAdaptiveStrength, player.Strength)
+
+	board := models.NewBoard()
+	move, err := service.GetBotMove(context.Background(), service.CreateAdaptiveBot(0), &board, models.PlayerColorRed)
+	require.NoError(t, err)
+	assert.True(t, board.IsValidMove(move))
+
+	parsed, err := ParseDifficulty("Adaptive")
+	require.NoError(t, err)
+	assert.Equal(t, DifficultyAdaptive, parsed)
+	assert.True(t, parsed.IsValid())
+	assert.Equal(t, "adaptive", parsed.String())
+}
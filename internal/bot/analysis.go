@@ -0,0 +1,261 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+var (
+	// ErrInvalidPosition is returned for boards that cannot arise in a game
+	ErrInvalidPosition = errors.New("invalid position")
+	// ErrAnalyzerBusy is returned when too many analyses are running
+	ErrAnalyzerBusy = errors.New("too many analyses in progress")
+)
+
+// AnalysisConfig limits the work done for one analysis
+type AnalysisConfig struct {
+	// Timeout bounds the whole analysis. Up to half of it goes to solving
+	// the position exactly, the rest to the minimax search.
+	Timeout time.Duration
+	// MaxDepth is the deepest minimax search, in plies
+	MaxDepth int
+	// MaxConcurrent analyses may run at once; more fail with ErrAnalyzerBusy
+	MaxConcurrent int
+	// Background analyses search on a single goroutine and leave the shared
+	// search helpers to live games
+	Background bool
+}
+
+// DefaultAnalysisConfig returns the configuration for the analysis board.
+// Its analyses run in the background, a few at a time, so the public board
+// never takes CPU from live games.
+func DefaultAnalysisConfig() AnalysisConfig {
+	return AnalysisConfig{
+		Timeout:       2 * time.Second,
+		MaxDepth:      12,
+		MaxConcurrent: 2,
+		Background:    true,
+	}
+}
+
+// ColumnAnalysis is what the engine knows about playing one column
+type ColumnAnalysis struct {
+	Column   int  `json:"column"`
+	Playable bool `json:"playable"`
+	// Score is the minimax evaluation for the player to move
+	Score int `json:"score"`
+	// Outcome is set when the result is proven, either by the solver or by
+	// a forced four within the minimax horizon
+	Outcome Outcome `json:"outcome,omitempty"`
+	// Plies is the number of moves, including this one, until the game ends
+	// with best play, when Outcome is set
+	Plies int `json:"plies,omitempty"`
+	// PrincipalVariation is the expected line starting with this column
+	PrincipalVariation []int `json:"principalVariation,omitempty"`
+}
+
+// PositionAnalysis is the engine's view of a position
+type PositionAnalysis struct {
+	Player models.PlayerColor `json:"player"` // side to move
+	// Evaluation is the static evaluation for the side to move
+	Evaluation int              `json:"evaluation"`
+	BestMove   int              `json:"bestMove"`
+	Columns    []ColumnAnalysis `json:"columns"`
+	Depth      int              `json:"depth"`  // deepest completed minimax search
+	Nodes      uint64           `json:"nodes"`  // positions searched by the solver and minimax
+	Solved     bool             `json:"solved"` // every playable column has a proven outcome
+}
+
+// PositionAnalyzer evaluates positions for analysis boards
+type PositionAnalyzer interface {
+	// Analyze evaluates every column for player to move
+	Analyze(ctx context.Context, board *models.Board, player models.PlayerColor) (*PositionAnalysis, error)
+}
+
+// positionAnalyzer implements PositionAnalyzer with the solver and a
+// minimax search sharing one time budget
+type positionAnalyzer struct {
+	config AnalysisConfig
+	slots  chan struct{}
+}
+
+// NewPositionAnalyzer creates a new position analyzer
+func NewPositionAnalyzer(config AnalysisConfig) PositionAnalyzer {
+	if config.MaxConcurrent <= 0 {
+		config.MaxConcurrent = 1
+	}
+	return &positionAnalyzer{
+		config: config,
+		slots:  make(chan struct{}, config.MaxConcurrent),
+	}
+}
+
+// Analyze evaluates every column for player to move
+func (a *positionAnalyzer) Analyze(ctx context.Context, board *models.Board, player models.PlayerColor) (*PositionAnalysis, error) {
+	if board.CheckWin() != nil {
+		return nil, ErrPositionDecided
+	}
+	if board.IsFull() {
+		return nil, ErrBoardFull
+	}
+
+	select {
+	case a.slots <- struct{}{}:
+		defer func() { <-a.slots }()
+	default:
+		return nil, ErrAnalyzerBusy
+	}
+
+	deadline := time.Now().Add(a.config.Timeout)
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	searcher := &minimaxBot{
+		tableSize:  defaultMinimaxTableSize,
+		maxDepth:   max(1, a.config.MaxDepth),
+		weights:    DefaultEvaluationWeights(),
+		sequential: a.config.Background,
+	}
+	analysis := &PositionAnalysis{
+		Player:     player,
+		Evaluation: searcher.EvaluatePosition(board, player),
+		Columns:    make([]ColumnAnalysis, boardWidth),
+	}
+
+	// Exact outcomes when the position is small enough to solve in time
+	solver := NewSolver().(*solver)
+	solveCtx, cancelSolve := context.WithTimeout(ctx, a.config.Timeout/2)
+	solved, err := solver.Analyze(solveCtx, board, player)
+	cancelSolve()
+	analysis.Nodes += solver.LastSearchStats().Nodes
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	analysis.Solved = err == nil
+
+	searcher.startSearch(ctx, searcher.maxDepth)
+	scores, depth := searcher.scoreMovesIteratively(board, player, deadline)
+	searcher.finishSearch()
+	analysis.Nodes += searcher.LastSearchStats().Nodes
+	analysis.Depth = depth
+
+	for col := range analysis.Columns {
+		column := ColumnAnalysis{Column: col, Playable: scores[col].Playable, Score: scores[col].Score}
+		if column.Playable {
+			column.PrincipalVariation = searcher.principalVariation(board, player, col, depth)
+			column.Outcome, column.Plies = minimaxOutcome(column.Score, depth)
+			if analysis.Solved {
+				column.Outcome, column.Plies = solved[col].Outcome, solved[col].Plies
+			}
+		}
+		analysis.Columns[col] = column
+	}
+
+	if analysis.Solved {
+		analysis.BestMove = bestColumn(solved)
+	} else {
+		analysis.BestMove = bestColumn(scores)
+	}
+
+	return analysis, nil
+}
+
+// minimaxOutcome reads a forced result out of a column's minimax score from
+// a search of depth plies
+func minimaxOutcome(score, depth int) (Outcome, int) {
+	switch {
+	case score > scoreWin/2:
+		return OutcomeWin, depth - (score - scoreWin)
+	case score < scoreLose/2:
+		return OutcomeLoss, depth - (scoreLose - score)
+	default:
+		return "", 0
+	}
+}
+
+// principalVariation follows the transposition table's best moves from the
+// position after playing col, up to length moves in total
+func (b *minimaxBot) principalVariation(board *models.Board, player models.PlayerColor, col, length int) []int {
+	current := copyBoard(board)
+	hash := zobristHash(board, player)
+	mover := player
+	line := make([]int, 0, length)
+
+	for move := col; len(line) < length; {
+		row := current.Height[move]
+		if err := current.MakeMove(move, mover); err != nil {
+			break
+		}
+		hash ^= zobristKey(row, move, mover)
+		line = append(line, move)
+		if current.CheckWin() != nil || current.IsFull() || b.table == nil {
+			break
+		}
+
+		entry, ok := b.table.probe(hash)
+		if !ok || entry.move < 0 || !current.IsValidMove(int(entry.move)) {
+			break
+		}
+		move = int(entry.move)
+		mover = getOpponent(mover)
+	}
+
+	return line
+}
+
+// PositionFromMoves replays columns from the empty board, red first, and
+// returns the board and the player to move
+func PositionFromMoves(moves []int) (models.Board, models.PlayerColor, error) {
+	board := models.NewBoard()
+	player := models.PlayerColorRed
+	for i, col := range moves {
+		if board.CheckWin() != nil {
+			return board, player, fmt.Errorf("%w: move %d is played after the game ended", ErrInvalidPosition, i+1)
+		}
+		if err := board.MakeMove(col, player); err != nil {
+			return board, player, fmt.Errorf("%w: move %d in column %d is not playable", ErrInvalidPosition, i+1, col)
+		}
+		player = getOpponent(player)
+	}
+	return board, player, nil
+}
+
+// ValidatePosition checks that a board can arise in a game started by red
+// and returns the player to move. It recomputes the column heights from the
+// grid, so callers may leave them out.
+func ValidatePosition(board *models.Board) (models.PlayerColor, error) {
+	red, yellow := 0, 0
+	for col := 0; col < boardWidth; col++ {
+		height := 0
+		for row := 0; row < boardHeight; row++ {
+			switch board.Grid[row][col] {
+			case models.PlayerColorRed:
+				red++
+			case models.PlayerColorYellow:
+				yellow++
+			case "":
+				continue
+			default:
+				return "", fmt.Errorf("%w: unknown color %q", ErrInvalidPosition, board.Grid[row][col])
+			}
+			if row != height {
+				return "", fmt.Errorf("%w: floating disc in column %d", ErrInvalidPosition, col)
+			}
+			height++
+		}
+		board.Height[col] = height
+	}
+
+	switch red - yellow {
+	case 0:
+		return models.PlayerColorRed, nil
+	case 1:
+		return models.PlayerColorYellow, nil
+	default:
+		return "", fmt.Errorf("%w: %d red and %d yellow discs", ErrInvalidPosition, red, yellow)
+	}
+}
@@ -0,0 +1,139 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connect4-multiplayer/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPositionFromMoves(t *testing.T) {
+	board, player, err := PositionFromMoves([]int{3, 3, 2})
+	require.NoError(t, err)
+	assert.Equal(t, models.PlayerColorYellow, player)
+	assert.Equal(t, models.PlayerColorRed, board.Grid[0][3])
+	assert.Equal(t, models.PlayerColorYellow, board.Grid[1][3])
+	assert.Equal(t, models.PlayerColorRed, board.Grid[0][2])
+
+	_, _, err = PositionFromMoves([]int{7})
+	assert.ErrorIs(t, err, ErrInvalidPosition)
+
+	_, _, err = PositionFromMoves([]int{0, 0, 0, 0, 0, 0, 0})
+	assert.ErrorIs(t, err, ErrInvalidPosition, "a column holds six discs")
+
+	_, _, err = PositionFromMoves([]int{0, 1, 0, 1, 0, 1, 0, 1})
+	assert.ErrorIs(t, err, ErrInvalidPosition, "red won with the seventh move")
+}
+
+func TestValidatePosition(t *testing.T) {
+	board, want, err := PositionFromMoves([]int{3, 3, 4})
+	require.NoError(t, err)
+	board.Height = [7]int{}
+
+	player, err := ValidatePosition(&board)
+	require.NoError(t, err)
+	assert.Equal(t, want, player)
+	assert.Equal(t, [7]int{0, 0, 0, 2, 1, 0, 0}, board.Height, "heights are recomputed from the grid")
+
+	floating := models.NewBoard()
+	floating.Grid[1][0] = models.PlayerColorRed
+	_, err = ValidatePosition(&floating)
+	assert.ErrorIs(t, err, ErrInvalidPosition)
+
+	unbalanced := models.NewBoard()
+	unbalanced.Grid[0][0] = models.PlayerColorYellow
+	_, err = ValidatePosition(&unbalanced)
+	assert.ErrorIs(t, err, ErrInvalidPosition, "red moves first")
+
+	unknown := models.NewBoard()
+	unknown.Grid[0][0] = "green"
+	_, err = ValidatePosition(&unknown)
+	assert.ErrorIs(t, err, ErrInvalidPosition)
+}
+
+func TestPositionAnalyzer_SolvedPosition(t *testing.T) {
+	board, player := boardFromMoves(t, "44444412121233")
+
+	analysis, err := NewPositionAnalyzer(DefaultAnalysisConfig()).Analyze(context.Background(), &board, player)
+	require.NoError(t, err)
+
+	scores, err := NewSolver().Analyze(context.Background(), &board, player)
+	require.NoError(t, err)
+
+	assert.True(t, analysis.Solved)
+	assert.Equal(t, player, analysis.Player)
+	assert.Equal(t, bestColumn(scores), analysis.BestMove)
+	assert.Positive(t, analysis.Depth)
+	assert.Positive(t, analysis.Nodes)
+	require.Len(t, analysis.Columns, boardWidth)
+
+	for col, column := range analysis.Columns {
+		assert.Equal(t, col, column.Column)
+		assert.Equal(t, scores[col].Playable, column.Playable)
+		if !column.Playable {
+			assert.Empty(t, column.PrincipalVariation)
+			continue
+		}
+		assert.Equal(t, scores[col].Outcome, column.Outcome)
+		assert.Equal(t, scores[col].Plies, column.Plies)
+
+		// The principal variation is a legal line starting with the column
+		require.NotEmpty(t, column.PrincipalVariation)
+		assert.Equal(t, col, column.PrincipalVariation[0])
+		line := copyBoard(&board)
+		mover := player
+		for _, move := range column.PrincipalVariation {
+			require.NoError(t, line.MakeMove(move, mover))
+			mover = getOpponent(mover)
+		}
+	}
+}
+
+func TestPositionAnalyzer_ForcedWinWithoutSolve(t *testing.T) {
+	// Red threatens to complete the bottom row on either side
+	board, player := boardFromMoves(t, "3344")
+	config := DefaultAnalysisConfig()
+	config.Timeout = 300 * time.Millisecond
+	config.MaxDepth = 5
+
+	analysis, err := NewPositionAnalyzer(config).Analyze(context.Background(), &board, player)
+	require.NoError(t, err)
+
+	assert.False(t, analysis.Solved, "the opening cannot be solved in the time limit")
+	assert.Equal(t, 5, analysis.Depth)
+	for _, col := range []int{1, 4} {
+		assert.Equal(t, OutcomeWin, analysis.Columns[col].Outcome, "column %d", col)
+		assert.Equal(t, 3, analysis.Columns[col].Plies, "column %d", col)
+	}
+	assert.Empty(t, analysis.Columns[6].Outcome, "quiet moves have no proven outcome")
+}
+
+func TestPositionAnalyzer_Errors(t *testing.T) {
+	analyzer := NewPositionAnalyzer(AnalysisConfig{Timeout: time.Second, MaxDepth: 4, MaxConcurrent: 1})
+
+	won, player := boardFromMoves(t, "1212121")
+	_, err := analyzer.Analyze(context.Background(), &won, player)
+	assert.ErrorIs(t, err, ErrPositionDecided)
+
+	// The only slot is taken
+	analyzer.(*positionAnalyzer).slots <- struct{}{}
+	board := models.NewBoard()
+	_, err = analyzer.Analyze(context.Background(), &board, models.PlayerColorRed)
+	assert.ErrorIs(t, err, ErrAnalyzerBusy)
+}
+
+func TestMinimaxOutcome(t *testing.T) {
+	outcome, plies := minimaxOutcome(scoreWin+4, 5)
+	assert.Equal(t, OutcomeWin, outcome)
+	assert.Equal(t, 1, plies)
+
+	outcome, plies = minimaxOutcome(scoreLose-2, 6)
+	assert.Equal(t, OutcomeLoss, outcome)
+	assert.Equal(t, 4, plies)
+
+	outcome, _ = minimaxOutcome(250, 6)
+	assert.Empty(t, outcome)
+}
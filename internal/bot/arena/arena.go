@@ -0,0 +1,237 @@
+// Package arena plays bots against each other to measure their relative
+// strength.
+package arena
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sync"
+	"time"
+
+	"connect4-multiplayer/internal/bot"
+	"connect4-multiplayer/pkg/models"
+)
+
+// Contestant is one side of an arena match
+type Contestant struct {
+	Name string
+	// NewBot creates the bot. Bots are not safe for concurrent use, so each
+	// worker gets its own instance.
+	NewBot func() bot.BotAI
+	// Depth, when positive, searches every move to this depth so games are
+	// reproducible; otherwise moves use the match's MoveTimeout
+	Depth int
+}
+
+// Config controls an arena match
+type Config struct {
+	// Games is the maximum number of games to play
+	Games int
+	// Workers is the number of games played in parallel
+	Workers int
+	// OpeningPlies random moves start every game pair so deterministic bots
+	// do not replay one game; each opening is played once with each color
+	OpeningPlies int
+	// MoveTimeout limits moves of contestants without a fixed depth
+	MoveTimeout time.Duration
+	// Seed makes the openings reproducible
+	Seed int64
+	// SPRT, if set, stops the match as soon as the test reaches a decision
+	SPRT *SPRTConfig
+	// Progress, if set, is called after every game with the running result
+	Progress func(Result)
+}
+
+// DefaultConfig returns a configuration for a quick strength comparison
+func DefaultConfig() Config {
+	return Config{
+		Games:        1000,
+		Workers:      runtime.NumCPU(),
+		OpeningPlies: 2,
+		MoveTimeout:  100 * time.Millisecond,
+		Seed:         1,
+	}
+}
+
+// Result is the outcome of a match from contestant A's point of view
+type Result struct {
+	Games    int           `json:"games"`
+	Wins     int           `json:"wins"`
+	Draws    int           `json:"draws"`
+	Losses   int           `json:"losses"`
+	Forfeits int           `json:"forfeits"` // games lost by an invalid move, by either side
+	Elo      EloEstimate   `json:"elo"`
+	SPRT     *SPRTResult   `json:"sprt,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Score returns A's average points per game
+func (r Result) Score() float64 {
+	if r.Games == 0 {
+		return 0
+	}
+	return (float64(r.Wins) + float64(r.Draws)/2) / float64(r.Games)
+}
+
+// gameOutcome is the result of one game for contestant A
+type gameOutcome int
+
+const (
+	outcomeLoss gameOutcome = iota
+	outcomeDraw
+	outcomeWin
+)
+
+// Run plays a match between a and b and returns A's result. It stops early
+// when ctx is cancelled or the SPRT reaches a decision.
+func Run(ctx context.Context, a, b Contestant, config Config) (Result, error) {
+	if a.NewBot == nil || b.NewBot == nil {
+		return Result{}, fmt.Errorf("both contestants need a bot")
+	}
+	if config.Games <= 0 {
+		return Result{}, fmt.Errorf("games must be positive")
+	}
+	if config.Workers <= 0 {
+		config.Workers = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	start := time.Now()
+	jobs := make(chan int)
+
+	var (
+		mu     sync.Mutex
+		result Result
+		wg     sync.WaitGroup
+	)
+
+	for w := 0; w < config.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			botA, botB := a.NewBot(), b.NewBot()
+			for game := range jobs {
+				outcome, forfeit := playGame(ctx, a, b, botA, botB, game, config)
+				if ctx.Err() != nil {
+					return
+				}
+
+				mu.Lock()
+				result.record(outcome, forfeit)
+				result.Elo = EstimateElo(result.Wins, result.Draws, result.Losses)
+				if config.SPRT != nil {
+					sprt := EvaluateSPRT(*config.SPRT, result.Wins, result.Draws, result.Losses)
+					result.SPRT = &sprt
+					if sprt.Decision != SPRTContinue {
+						cancel()
+					}
+				}
+				result.Duration = time.Since(start)
+				if config.Progress != nil {
+					config.Progress(result)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for game := 0; game < config.Games; game++ {
+		select {
+		case jobs <- game:
+			continue
+		case <-ctx.Done():
+		}
+		break
+	}
+	close(jobs)
+	wg.Wait()
+
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+func (r *Result) record(outcome gameOutcome, forfeit bool) {
+	r.Games++
+	switch outcome {
+	case outcomeWin:
+		r.Wins++
+	case outcomeDraw:
+		r.Draws++
+	default:
+		r.Losses++
+	}
+	if forfeit {
+		r.Forfeits++
+	}
+}
+
+// playGame plays game number game. Games come in pairs that share a random
+// opening, with A playing red in even games and yellow in odd ones.
+func playGame(ctx context.Context, a, b Contestant, botA, botB bot.BotAI, game int, config Config) (gameOutcome, bool) {
+	board := models.NewBoard()
+	color := models.PlayerColorRed
+
+	rng := rand.New(rand.NewSource(config.Seed + int64(game/2)))
+	for i := 0; i < config.OpeningPlies; i++ {
+		col := rng.Intn(7)
+		for !board.IsValidMove(col) {
+			col = (col + 1) % 7
+		}
+		next := board
+		_ = next.MakeMove(col, color)
+		if next.CheckWin() != nil {
+			break // keep openings undecided
+		}
+		board = next
+		color = opponent(color)
+	}
+
+	colorA := models.PlayerColorRed
+	if game%2 == 1 {
+		colorA = models.PlayerColorYellow
+	}
+
+	for {
+		contestant, ai := b, botB
+		if color == colorA {
+			contestant, ai = a, botA
+		}
+
+		var move int
+		if contestant.Depth > 0 {
+			move = ai.GetBestMove(&board, color, contestant.Depth)
+		} else {
+			move, _ = ai.GetBestMoveWithTimeout(ctx, &board, color, config.MoveTimeout)
+		}
+
+		if err := board.MakeMove(move, color); err != nil {
+			// An invalid move forfeits the game
+			if color == colorA {
+				return outcomeLoss, true
+			}
+			return outcomeWin, true
+		}
+
+		if winner := board.CheckWin(); winner != nil {
+			if *winner == colorA {
+				return outcomeWin, false
+			}
+			return outcomeLoss, false
+		}
+		if board.IsFull() {
+			return outcomeDraw, false
+		}
+		color = opponent(color)
+	}
+}
+
+func opponent(color models.PlayerColor) models.PlayerColor {
+	if color == models.PlayerColorRed {
+		return models.PlayerColorYellow
+	}
+	return models.PlayerColorRed
+}
@@ -0,0 +1,87 @@
+package arena
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDeeperSearchIsStronger guards against evaluation or search changes
+// that make the bot weaker than a much shallower search
+func TestDeeperSearchIsStronger(t *testing.T) {
+	deep, err := ParseContestant("minimax:depth=4")
+	require.NoError(t, err)
+	shallow, err := ParseContestant("minimax:depth=1")
+	require.NoError(t, err)
+
+	config := DefaultConfig()
+	config.Games = 20
+	config.Workers = 2
+
+	result, err := Run(context.Background(), deep, shallow, config)
+	require.NoError(t, err)
+
+	assert.Equal(t, 20, result.Games)
+	assert.Equal(t, result.Games, result.Wins+result.Draws+result.Losses)
+	assert.Zero(t, result.Forfeits)
+	assert.Greater(t, result.Score(), 0.75)
+	assert.Greater(t, result.Elo.Diff, 0.0)
+}
+
+func TestRun_IsReproducible(t *testing.T) {
+	a, err := ParseContestant("minimax:depth=2")
+	require.NoError(t, err)
+	b, err := ParseContestant("minimax:depth=2,three=50,center=10")
+	require.NoError(t, err)
+
+	config := DefaultConfig()
+	config.Games = 10
+	config.Workers = 3
+
+	first, err := Run(context.Background(), a, b, config)
+	require.NoError(t, err)
+	second, err := Run(context.Background(), a, b, config)
+	require.NoError(t, err)
+
+	assert.Equal(t, [3]int{first.Wins, first.Draws, first.Losses}, [3]int{second.Wins, second.Draws, second.Losses})
+}
+
+func TestRun_StopsWhenSPRTDecides(t *testing.T) {
+	deep, err := ParseContestant("minimax:depth=3")
+	require.NoError(t, err)
+	shallow, err := ParseContestant("minimax:depth=1")
+	require.NoError(t, err)
+
+	config := DefaultConfig()
+	config.Games = 1000
+	config.Workers = 1
+	config.SPRT = &SPRTConfig{Elo0: 0, Elo1: 100, Alpha: 0.1, Beta: 0.1}
+
+	result, err := Run(context.Background(), deep, shallow, config)
+	require.NoError(t, err)
+	require.NotNil(t, result.SPRT)
+	assert.Equal(t, SPRTAcceptH1, result.SPRT.Decision)
+	assert.Less(t, result.Games, 1000)
+}
+
+func TestParseContestant(t *testing.T) {
+	contestant, err := ParseContestant("minimax:depth=3,three=120")
+	require.NoError(t, err)
+	assert.Equal(t, 3, contestant.Depth)
+	assert.NotNil(t, contestant.NewBot())
+
+	solver, err := ParseContestant("solver")
+	require.NoError(t, err)
+	assert.Zero(t, solver.Depth)
+
+	mcts, err := ParseContestant("mcts:playouts=500,ms=200,heuristic=0")
+	require.NoError(t, err)
+	assert.NotNil(t, mcts.NewBot())
+
+	for _, spec := range []string{"alphazero", "minimax:depth", "minimax:depth=x", "minimax:speed=3", "solver:depth=2", "mcts:depth=3"} {
+		_, err := ParseContestant(spec)
+		assert.Error(t, err, spec)
+	}
+}
@@ -0,0 +1,91 @@
+package arena
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"connect4-multiplayer/internal/bot"
+)
+
+// ParseContestant builds a contestant from a spec such as "solver",
+// "minimax:depth=5,three=120,two=8,center=4" or "mcts:playouts=5000".
+// Minimax options are the fixed search depth (0 searches against the move
+// timeout) and the evaluation weights; unset weights keep their defaults.
+// MCTS options are the playouts and time budget in ms per move (0 for no
+// limit) and heuristic=0 for uniformly random rollouts.
+func ParseContestant(spec string) (Contestant, error) {
+	kind, options, _ := strings.Cut(strings.TrimSpace(spec), ":")
+
+	values := make(map[string]int)
+	if options != "" {
+		for _, option := range strings.Split(options, ",") {
+			key, value, ok := strings.Cut(option, "=")
+			if !ok {
+				return Contestant{}, fmt.Errorf("invalid option %q in %q, expected key=value", option, spec)
+			}
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return Contestant{}, fmt.Errorf("invalid value for %s in %q: %w", key, spec, err)
+			}
+			values[strings.TrimSpace(key)] = n
+		}
+	}
+
+	switch kind {
+	case "minimax":
+		weights := bot.DefaultEvaluationWeights()
+		depth := 0
+		for key, value := range values {
+			switch key {
+			case "depth":
+				depth = value
+			case "three":
+				weights.ThreeInRow = value
+			case "two":
+				weights.TwoInRow = value
+			case "center":
+				weights.CenterBonus = value
+			default:
+				return Contestant{}, fmt.Errorf("unknown minimax option %q", key)
+			}
+		}
+		return Contestant{
+			Name:   spec,
+			NewBot: func() bot.BotAI { return bot.NewMinimaxBotWithWeights(depth, weights) },
+			Depth:  depth,
+		}, nil
+
+	case "mcts":
+		config := bot.DefaultMCTSConfig()
+		for key, value := range values {
+			switch key {
+			case "playouts":
+				config.Playouts = value
+			case "ms":
+				config.TimeBudget = time.Duration(value) * time.Millisecond
+			case "heuristic":
+				config.HeuristicRollouts = value != 0
+			default:
+				return Contestant{}, fmt.Errorf("unknown mcts option %q", key)
+			}
+		}
+		return Contestant{
+			Name:   spec,
+			NewBot: func() bot.BotAI { return bot.NewMCTSBot(config) },
+		}, nil
+
+	case "solver":
+		if len(values) > 0 {
+			return Contestant{}, fmt.Errorf("solver takes no options")
+		}
+		return Contestant{
+			Name:   spec,
+			NewBot: func() bot.BotAI { return bot.NewSolver() },
+		}, nil
+
+	default:
+		return Contestant{}, fmt.Errorf("unknown bot %q, expected minimax, mcts or solver", kind)
+	}
+}
@@ -0,0 +1,115 @@
+package arena
+
+import (
+	"math"
+)
+
+// z95 is the two-sided 95% quantile of the normal distribution
+const z95 = 1.959964
+
+// EloEstimate is the Elo difference implied by a match score with a 95%
+// confidence interval
+type EloEstimate struct {
+	Diff float64 `json:"diff"`
+	Low  float64 `json:"low"`
+	High float64 `json:"high"`
+}
+
+// SPRTConfig describes a sequential probability ratio test of H0: the Elo
+// difference is Elo0 against H1: it is Elo1, with false positive rate Alpha
+// and false negative rate Beta
+type SPRTConfig struct {
+	Elo0  float64 `json:"elo0"`
+	Elo1  float64 `json:"elo1"`
+	Alpha float64 `json:"alpha"`
+	Beta  float64 `json:"beta"`
+}
+
+// DefaultSPRTConfig tests whether a change gains at least 10 Elo
+func DefaultSPRTConfig() SPRTConfig {
+	return SPRTConfig{Elo0: 0, Elo1: 10, Alpha: 0.05, Beta: 0.05}
+}
+
+// SPRTDecision is the state of a sequential test
+type SPRTDecision string
+
+const (
+	SPRTContinue SPRTDecision = "continue" // not enough games to decide
+	SPRTAcceptH0 SPRTDecision = "H0"       // no better than Elo0
+	SPRTAcceptH1 SPRTDecision = "H1"       // at least as good as Elo1
+)
+
+// SPRTResult is the log-likelihood ratio of a match and its bounds
+type SPRTResult struct {
+	LLR      float64      `json:"llr"`
+	Lower    float64      `json:"lower"`
+	Upper    float64      `json:"upper"`
+	Decision SPRTDecision `json:"decision"`
+}
+
+// eloToScore converts an Elo difference into an expected score
+func eloToScore(elo float64) float64 {
+	return 1 / (1 + math.Pow(10, -elo/400))
+}
+
+// scoreToElo converts a score fraction into an Elo difference
+func scoreToElo(score float64) float64 {
+	score = math.Min(math.Max(score, 1e-6), 1-1e-6)
+	return -400 * math.Log10(1/score-1)
+}
+
+// scoreStats returns the mean score per game and its variance
+func scoreStats(wins, draws, losses int) (float64, float64) {
+	n := float64(wins + draws + losses)
+	if n == 0 {
+		return 0.5, 0
+	}
+	w, d, l := float64(wins)/n, float64(draws)/n, float64(losses)/n
+	mean := w + d/2
+	variance := w*math.Pow(1-mean, 2) + d*math.Pow(0.5-mean, 2) + l*math.Pow(mean, 2)
+	return mean, variance
+}
+
+// EstimateElo returns the Elo difference of a win/draw/loss record with a
+// 95% confidence interval, using the normal approximation of the mean score
+func EstimateElo(wins, draws, losses int) EloEstimate {
+	n := float64(wins + draws + losses)
+	mean, variance := scoreStats(wins, draws, losses)
+	if n == 0 {
+		return EloEstimate{}
+	}
+
+	margin := z95 * math.Sqrt(variance/n)
+	return EloEstimate{
+		Diff: scoreToElo(mean),
+		Low:  scoreToElo(mean - margin),
+		High: scoreToElo(mean + margin),
+	}
+}
+
+// EvaluateSPRT computes the generalized SPRT log-likelihood ratio of a
+// record, approximating the per-game score as normally distributed
+func EvaluateSPRT(config SPRTConfig, wins, draws, losses int) SPRTResult {
+	result := SPRTResult{
+		Lower:    math.Log(config.Beta / (1 - config.Alpha)),
+		Upper:    math.Log((1 - config.Beta) / config.Alpha),
+		Decision: SPRTContinue,
+	}
+
+	n := float64(wins + draws + losses)
+	mean, variance := scoreStats(wins, draws, losses)
+	if n == 0 || variance == 0 {
+		return result
+	}
+
+	s0, s1 := eloToScore(config.Elo0), eloToScore(config.Elo1)
+	result.LLR = n * (s1 - s0) * (2*mean - s0 - s1) / (2 * variance)
+
+	switch {
+	case result.LLR >= result.Upper:
+		result.Decision = SPRTAcceptH1
+	case result.LLR <= result.Lower:
+		result.Decision = SPRTAcceptH0
+	}
+	return result
+}
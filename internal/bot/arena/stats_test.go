@@ -0,0 +1,38 @@
+package arena
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateElo(t *testing.T) {
+	even := EstimateElo(40, 20, 40)
+	assert.InDelta(t, 0, even.Diff, 1e-9)
+	assert.Less(t, even.Low, 0.0)
+	assert.Greater(t, even.High, 0.0)
+
+	// 75% score is about +191 Elo
+	strong := EstimateElo(70, 10, 20)
+	assert.InDelta(t, 190.8, strong.Diff, 0.5)
+	assert.Less(t, strong.Low, strong.Diff)
+	assert.Greater(t, strong.High, strong.Diff)
+
+	// More games narrow the interval
+	more := EstimateElo(700, 100, 200)
+	assert.Less(t, more.High-more.Low, strong.High-strong.Low)
+
+	assert.Equal(t, EloEstimate{}, EstimateElo(0, 0, 0))
+}
+
+func TestEvaluateSPRT(t *testing.T) {
+	config := DefaultSPRTConfig()
+
+	undecided := EvaluateSPRT(config, 5, 2, 4)
+	assert.Equal(t, SPRTContinue, undecided.Decision)
+	assert.Less(t, undecided.Lower, 0.0)
+	assert.Greater(t, undecided.Upper, 0.0)
+
+	assert.Equal(t, SPRTAcceptH1, EvaluateSPRT(config, 600, 100, 300).Decision)
+	assert.Equal(t, SPRTAcceptH0, EvaluateSPRT(config, 300, 100, 600).Decision)
+}
@@ -0,0 +1,179 @@
+package bot
+
+import (
+	"math/bits"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+const (
+	boardWidth  = 7
+	boardHeight = 6
+	boardCells  = boardWidth * boardHeight
+)
+
+// bitboard is a compact Connect 4 position seen from the player to move.
+// Each column uses boardHeight+1 bits (the extra bit is a sentinel so shifts
+// never spill into the next column), with bit 0 of a column at the bottom.
+type bitboard struct {
+	current uint64 // stones of the player to move
+	mask    uint64 // all stones on the board
+	moves   int    // number of stones played
+}
+
+var (
+	bottomMask = bottomRow()
+	boardMask  = bottomMask * ((1 << boardHeight) - 1)
+)
+
+func bottomRow() uint64 {
+	var mask uint64
+	for col := 0; col < boardWidth; col++ {
+		mask |= 1 << (col * (boardHeight + 1))
+	}
+	return mask
+}
+
+func topMaskCol(col int) uint64 {
+	return 1 << (boardHeight - 1 + col*(boardHeight+1))
+}
+
+func bottomMaskCol(col int) uint64 {
+	return 1 << (col * (boardHeight + 1))
+}
+
+func columnMask(col int) uint64 {
+	return ((1 << boardHeight) - 1) << (col * (boardHeight + 1))
+}
+
+// bitboardFromBoard converts a board into a bitboard where player is to move
+func bitboardFromBoard(board *models.Board, player models.PlayerColor) bitboard {
+	var p bitboard
+	for col := 0; col < boardWidth; col++ {
+		for row := 0; row < board.Height[col] && row < boardHeight; row++ {
+			bit := uint64(1) << (col*(boardHeight+1) + row)
+			p.mask |= bit
+			if board.Grid[row][col] == player {
+				p.current |= bit
+			}
+			p.moves++
+		}
+	}
+	return p
+}
+
+// canPlay reports whether col is not full
+func (p *bitboard) canPlay(col int) bool {
+	return p.mask&topMaskCol(col) == 0
+}
+
+// play plays a move given as a single-bit mask of the cell to fill
+func (p *bitboard) play(move uint64) {
+	p.current ^= p.mask
+	p.mask |= move
+	p.moves++
+}
+
+// playCol plays a stone in col, which must be playable
+func (p *bitboard) playCol(col int) {
+	p.play((p.mask + bottomMaskCol(col)) & columnMask(col))
+}
+
+// isWinningMove reports whether playing col wins immediately
+func (p *bitboard) isWinningMove(col int) bool {
+	return p.winningPositions()&p.possible()&columnMask(col) != 0
+}
+
+// canWinNext reports whether the player to move can win immediately
+func (p *bitboard) canWinNext() bool {
+	return p.winningPositions()&p.possible() != 0
+}
+
+// key uniquely identifies the position for the player to move
+func (p *bitboard) key() uint64 {
+	return p.current + p.mask
+}
+
+// mirrorKey is the key of the position reflected around the center column
+func (p *bitboard) mirrorKey() uint64 {
+	return mirrorColumns(p.key())
+}
+
+// possible returns a mask of the cells that can be played next
+func (p *bitboard) possible() uint64 {
+	return (p.mask + bottomMask) & boardMask
+}
+
+// possibleNonLosingMoves returns the playable cells that do not hand the
+// opponent an immediate win. It returns 0 when every move loses.
+func (p *bitboard) possibleNonLosingMoves() uint64 {
+	possible := p.possible()
+	opponentWin := p.opponentWinningPositions()
+	forced := possible & opponentWin
+	if forced != 0 {
+		if forced&(forced-1) != 0 {
+			return 0 // two threats at once cannot both be blocked
+		}
+		possible = forced
+	}
+	return possible &^ (opponentWin >> 1) // never play right below an opponent threat
+}
+
+// moveScore counts the winning cells the player would have after move,
+// used to search promising moves first
+func (p *bitboard) moveScore(move uint64) int {
+	return bits.OnesCount64(computeWinningPositions(p.current|move, p.mask))
+}
+
+func (p *bitboard) winningPositions() uint64 {
+	return computeWinningPositions(p.current, p.mask)
+}
+
+func (p *bitboard) opponentWinningPositions() uint64 {
+	return computeWinningPositions(p.current^p.mask, p.mask)
+}
+
+// computeWinningPositions returns the empty cells that would complete a four
+// for the stones in position
+func computeWinningPositions(position, mask uint64) uint64 {
+	const h = boardHeight
+
+	// vertical
+	r := (position << 1) & (position << 2) & (position << 3)
+
+	// horizontal
+	p := (position << (h + 1)) & (position << (2 * (h + 1)))
+	r |= p & (position << (3 * (h + 1)))
+	r |= p & (position >> (h + 1))
+	p = (position >> (h + 1)) & (position >> (2 * (h + 1)))
+	r |= p & (position << (h + 1))
+	r |= p & (position >> (3 * (h + 1)))
+
+	// diagonal 1
+	p = (position << h) & (position << (2 * h))
+	r |= p & (position << (3 * h))
+	r |= p & (position >> h)
+	p = (position >> h) & (position >> (2 * h))
+	r |= p & (position << h)
+	r |= p & (position >> (3 * h))
+
+	// diagonal 2
+	p = (position << (h + 2)) & (position << (2 * (h + 2)))
+	r |= p & (position << (3 * (h + 2)))
+	r |= p & (position >> (h + 2))
+	p = (position >> (h + 2)) & (position >> (2 * (h + 2)))
+	r |= p & (position << (h + 2))
+	r |= p & (position >> (3 * (h + 2)))
+
+	return r & (boardMask ^ mask)
+}
+
+// mirrorColumns reflects a bitboard value around the center column
+func mirrorColumns(v uint64) uint64 {
+	var mirrored uint64
+	for col := 0; col < boardWidth; col++ {
+		column := (v >> (col * (boardHeight + 1))) & ((1 << (boardHeight + 1)) - 1)
+		mirrored |= column << ((boardWidth - 1 - col) * (boardHeight + 1))
+	}
+	return mirrored
+}
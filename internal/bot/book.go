@@ -0,0 +1,291 @@
+package bot
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"sort"
+	"sync"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+const (
+	// OpeningBookVersion is the version of the binary format written by
+	// WriteTo. Readers reject files with any other version.
+	OpeningBookVersion = 1
+
+	openingBookMagic      = "C4OB"
+	openingBookHeaderSize = 14
+	openingBookEntrySize  = 9
+	noBookMove            = -1
+)
+
+var (
+	// ErrInvalidOpeningBook is returned for files that are not opening books
+	// or are corrupted
+	ErrInvalidOpeningBook = errors.New("invalid opening book")
+	// ErrUnsupportedBookVersion is returned for books written in another format version
+	ErrUnsupportedBookVersion = errors.New("unsupported opening book version")
+)
+
+// OpeningBook maps early positions to their exact solver scores and best
+// moves so the solver can answer instantly where a full search would take
+// minutes. Positions and their mirror images share one entry.
+type OpeningBook struct {
+	entries map[uint64]bookEntry
+	maxPly  int
+}
+
+type bookEntry struct {
+	score int8
+	move  int8 // best column, or noBookMove when unknown
+}
+
+// NewOpeningBook creates an empty opening book
+func NewOpeningBook() *OpeningBook {
+	return &OpeningBook{entries: make(map[uint64]bookEntry)}
+}
+
+// DefaultOpeningBook returns the built-in book: the empty board and every
+// first move, which are the positions too deep to solve during a bot turn
+func DefaultOpeningBook() *OpeningBook {
+	book := NewOpeningBook()
+
+	var p bitboard
+	book.add(&p, 1, 3) // the first player wins with their last stone
+
+	// Scores of the position after each first move, for the second player
+	for col, score := range []int{2, 1, 0, -1, 0, 1, 2} {
+		child := p
+		child.playCol(col)
+		book.add(&child, score, noBookMove)
+	}
+
+	return book
+}
+
+var (
+	openingBookMu sync.RWMutex
+	openingBook   = DefaultOpeningBook()
+)
+
+// UseOpeningBook makes solvers created by NewSolver use book on top of the
+// built-in entries. It is meant to be called once at startup.
+func UseOpeningBook(book *OpeningBook) {
+	merged := DefaultOpeningBook()
+	merged.merge(book)
+
+	openingBookMu.Lock()
+	openingBook = merged
+	openingBookMu.Unlock()
+}
+
+func currentOpeningBook() *OpeningBook {
+	openingBookMu.RLock()
+	defer openingBookMu.RUnlock()
+	return openingBook
+}
+
+// Lookup returns the book score of the position for player to move
+func (b *OpeningBook) Lookup(board *models.Board, player models.PlayerColor) (int, bool) {
+	p := bitboardFromBoard(board, player)
+	return b.lookup(&p)
+}
+
+// BestMove returns the book's best column for player to move
+func (b *OpeningBook) BestMove(board *models.Board, player models.PlayerColor) (int, bool) {
+	p := bitboardFromBoard(board, player)
+	return b.lookupMove(&p)
+}
+
+// Len returns the number of positions in the book
+func (b *OpeningBook) Len() int {
+	if b == nil {
+		return 0
+	}
+	return len(b.entries)
+}
+
+// MaxPly returns the number of stones in the deepest positions of the book
+func (b *OpeningBook) MaxPly() int {
+	if b == nil {
+		return -1
+	}
+	return b.maxPly
+}
+
+func (b *OpeningBook) add(p *bitboard, score, move int) {
+	key, mirrored := canonicalKey(p)
+	if mirrored && move != noBookMove {
+		move = boardWidth - 1 - move
+	}
+	b.entries[key] = bookEntry{score: int8(score), move: int8(move)}
+	if p.moves > b.maxPly {
+		b.maxPly = p.moves
+	}
+}
+
+func (b *OpeningBook) lookup(p *bitboard) (int, bool) {
+	if b == nil {
+		return 0, false
+	}
+	key, _ := canonicalKey(p)
+	entry, ok := b.entries[key]
+	return int(entry.score), ok
+}
+
+func (b *OpeningBook) lookupMove(p *bitboard) (int, bool) {
+	if b == nil {
+		return 0, false
+	}
+	key, mirrored := canonicalKey(p)
+	entry, ok := b.entries[key]
+	if !ok || entry.move == noBookMove {
+		return 0, false
+	}
+	if mirrored {
+		return boardWidth - 1 - int(entry.move), true
+	}
+	return int(entry.move), true
+}
+
+// merge copies every entry of other into b, keeping known best moves
+func (b *OpeningBook) merge(other *OpeningBook) {
+	if other == nil {
+		return
+	}
+	for key, entry := range other.entries {
+		if existing, ok := b.entries[key]; ok && entry.move == noBookMove {
+			entry.move = existing.move
+		}
+		b.entries[key] = entry
+	}
+	if other.maxPly > b.maxPly {
+		b.maxPly = other.maxPly
+	}
+}
+
+// canonicalKey picks the smaller of a position's key and its mirror's key and
+// reports whether the mirror was picked
+func canonicalKey(p *bitboard) (uint64, bool) {
+	key, mirror := p.key(), p.mirrorKey()
+	if mirror < key {
+		return mirror, true
+	}
+	return key, false
+}
+
+// WriteTo writes the book in the versioned binary format: a header with the
+// magic, version, board size, depth and entry count, the entries sorted by
+// key (7-byte key, score, best move) and a CRC-32 of everything before it.
+func (b *OpeningBook) WriteTo(w io.Writer) (int64, error) {
+	keys := make([]uint64, 0, len(b.entries))
+	for key := range b.entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	checksum := crc32.NewIEEE()
+	out := bufio.NewWriter(io.MultiWriter(w, checksum))
+
+	header := make([]byte, openingBookHeaderSize)
+	copy(header, openingBookMagic)
+	binary.LittleEndian.PutUint16(header[4:], OpeningBookVersion)
+	header[6] = boardWidth
+	header[7] = boardHeight
+	header[8] = byte(b.maxPly)
+	binary.LittleEndian.PutUint32(header[10:], uint32(len(keys)))
+	if _, err := out.Write(header); err != nil {
+		return 0, fmt.Errorf("failed to write opening book header: %w", err)
+	}
+
+	record := make([]byte, openingBookEntrySize)
+	for _, key := range keys {
+		entry := b.entries[key]
+		var keyBytes [8]byte
+		binary.LittleEndian.PutUint64(keyBytes[:], key)
+		copy(record, keyBytes[:7])
+		record[7] = byte(entry.score)
+		record[8] = byte(entry.move)
+		if _, err := out.Write(record); err != nil {
+			return 0, fmt.Errorf("failed to write opening book entry: %w", err)
+		}
+	}
+	if err := out.Flush(); err != nil {
+		return 0, fmt.Errorf("failed to write opening book: %w", err)
+	}
+
+	var trailer [4]byte
+	binary.LittleEndian.PutUint32(trailer[:], checksum.Sum32())
+	if _, err := w.Write(trailer[:]); err != nil {
+		return 0, fmt.Errorf("failed to write opening book checksum: %w", err)
+	}
+
+	return int64(openingBookHeaderSize + len(keys)*openingBookEntrySize + len(trailer)), nil
+}
+
+// ReadOpeningBook reads a book written by WriteTo
+func ReadOpeningBook(r io.Reader) (*OpeningBook, error) {
+	checksum := crc32.NewIEEE()
+	in := io.TeeReader(bufio.NewReader(r), checksum)
+
+	header := make([]byte, openingBookHeaderSize)
+	if _, err := io.ReadFull(in, header); err != nil {
+		return nil, fmt.Errorf("%w: failed to read header: %v", ErrInvalidOpeningBook, err)
+	}
+	if string(header[:4]) != openingBookMagic {
+		return nil, fmt.Errorf("%w: bad magic", ErrInvalidOpeningBook)
+	}
+	if version := binary.LittleEndian.Uint16(header[4:]); version != OpeningBookVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedBookVersion, version)
+	}
+	if header[6] != boardWidth || header[7] != boardHeight {
+		return nil, fmt.Errorf("%w: board is %dx%d", ErrInvalidOpeningBook, header[6], header[7])
+	}
+
+	count := binary.LittleEndian.Uint32(header[10:])
+	book := &OpeningBook{
+		entries: make(map[uint64]bookEntry, count),
+		maxPly:  int(header[8]),
+	}
+
+	record := make([]byte, openingBookEntrySize)
+	for i := uint32(0); i < count; i++ {
+		if _, err := io.ReadFull(in, record); err != nil {
+			return nil, fmt.Errorf("%w: failed to read entry %d: %v", ErrInvalidOpeningBook, i, err)
+		}
+		var keyBytes [8]byte
+		copy(keyBytes[:7], record[:7])
+		book.entries[binary.LittleEndian.Uint64(keyBytes[:])] = bookEntry{
+			score: int8(record[7]),
+			move:  int8(record[8]),
+		}
+	}
+
+	expected := checksum.Sum32()
+	var trailer [4]byte
+	if _, err := io.ReadFull(in, trailer[:]); err != nil {
+		return nil, fmt.Errorf("%w: failed to read checksum: %v", ErrInvalidOpeningBook, err)
+	}
+	if binary.LittleEndian.Uint32(trailer[:]) != expected {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidOpeningBook)
+	}
+
+	return book, nil
+}
+
+// LoadOpeningBook reads a book file written by WriteTo
+func LoadOpeningBook(path string) (*OpeningBook, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open opening book: %w", err)
+	}
+	defer file.Close()
+
+	return ReadOpeningBook(file)
+}
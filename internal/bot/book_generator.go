@@ -0,0 +1,155 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// BookGeneratorConfig controls opening book generation
+type BookGeneratorConfig struct {
+	// MaxPly is the number of stones in the deepest positions to include
+	MaxPly int
+	// Workers is the number of positions solved in parallel
+	Workers int
+	// Progress, if set, is called after each position is solved
+	Progress func(ply, done, total int)
+}
+
+// DefaultBookGeneratorConfig returns a configuration that builds a shallow
+// book using every CPU
+func DefaultBookGeneratorConfig() BookGeneratorConfig {
+	return BookGeneratorConfig{
+		MaxPly:  4,
+		Workers: runtime.NumCPU(),
+	}
+}
+
+// GenerateOpeningBook solves every position with at most config.MaxPly
+// stones, counting mirror images once. The deepest ply is solved first so
+// that every shallower position is answered from the entries already found.
+func GenerateOpeningBook(ctx context.Context, config BookGeneratorConfig) (*OpeningBook, error) {
+	if config.MaxPly < 0 || config.MaxPly >= boardCells {
+		return nil, fmt.Errorf("max ply must be between 0 and %d", boardCells-1)
+	}
+	if config.Workers <= 0 {
+		config.Workers = 1
+	}
+
+	plies := enumeratePositions(config.MaxPly)
+	book := NewOpeningBook()
+
+	for ply := config.MaxPly; ply >= 0; ply-- {
+		positions := plies[ply]
+		results, err := solvePositions(ctx, book, positions, config.Workers, func(done int) {
+			if config.Progress != nil {
+				config.Progress(ply, done, len(positions))
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// Entries are added between plies so workers only ever read the book
+		for i := range positions {
+			book.add(&positions[i], results[i].score, results[i].move)
+		}
+	}
+
+	return book, nil
+}
+
+// enumeratePositions lists the positions reachable with each number of
+// stones up to maxPly, skipping finished games and mirrored duplicates
+func enumeratePositions(maxPly int) [][]bitboard {
+	plies := make([][]bitboard, maxPly+1)
+	plies[0] = []bitboard{{}}
+
+	for ply := 1; ply <= maxPly; ply++ {
+		seen := make(map[uint64]bool)
+		for _, parent := range plies[ply-1] {
+			for col := 0; col < boardWidth; col++ {
+				if !parent.canPlay(col) || parent.isWinningMove(col) {
+					continue
+				}
+				child := parent
+				child.playCol(col)
+				key, _ := canonicalKey(&child)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				plies[ply] = append(plies[ply], child)
+			}
+		}
+	}
+
+	return plies
+}
+
+type bookResult struct {
+	score int
+	move  int
+}
+
+// solvePositions analyzes positions on a pool of workers, each with its own
+// solver, and returns the score and best move of each position in order
+func solvePositions(ctx context.Context, book *OpeningBook, positions []bitboard, workers int, progress func(done int)) ([]bookResult, error) {
+	results := make([]bookResult, len(positions))
+	jobs := make(chan int)
+
+	var (
+		mu       sync.Mutex
+		done     int
+		firstErr error
+		wg       sync.WaitGroup
+	)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s := NewSolverWithBook(book).(*solver)
+			for i := range jobs {
+				s.start(ctx)
+				scores := s.analyze(&positions[i])
+				stopped := s.stopped
+				s.finish()
+
+				mu.Lock()
+				if stopped {
+					if firstErr == nil {
+						firstErr = ctx.Err()
+					}
+				} else {
+					move := bestColumn(scores)
+					results[i] = bookResult{score: scores[move].Score, move: move}
+					done++
+					progress(done)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := range positions {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr == nil && ctx.Err() != nil {
+		firstErr = ctx.Err()
+	}
+	if firstErr != nil {
+		return nil, fmt.Errorf("opening book generation interrupted: %w", firstErr)
+	}
+	return results, nil
+}
@@ -0,0 +1,129 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnumeratePositions_DeduplicatesMirrors(t *testing.T) {
+	plies := enumeratePositions(2)
+
+	require.Len(t, plies, 3)
+	assert.Len(t, plies[0], 1)
+	assert.Len(t, plies[1], 4, "columns 1-3 mirror columns 5-7")
+	assert.Len(t, plies[2], 25, "49 two-move positions, only both-in-the-center is symmetric")
+}
+
+func TestSolvePositions_ScoresAndBestMoves(t *testing.T) {
+	board, player := boardFromMoves(t, "444444121212")
+	p := bitboardFromBoard(&board, player)
+
+	results, err := solvePositions(context.Background(), NewOpeningBook(), []bitboard{p}, 2, func(int) {})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, 0, results[0].move)
+	assert.Equal(t, 15, results[0].score)
+}
+
+func TestOpeningBook_RoundTrip(t *testing.T) {
+	book := DefaultOpeningBook()
+	board, player := boardFromMoves(t, "444444121212")
+	p := bitboardFromBoard(&board, player)
+	book.add(&p, 15, 0)
+
+	var buf bytes.Buffer
+	size, err := book.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), size)
+
+	loaded, err := ReadOpeningBook(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, book.Len(), loaded.Len())
+	assert.Equal(t, 12, loaded.MaxPly())
+
+	score, ok := loaded.Lookup(&board, player)
+	require.True(t, ok)
+	assert.Equal(t, 15, score)
+	move, ok := loaded.BestMove(&board, player)
+	require.True(t, ok)
+	assert.Equal(t, 0, move)
+}
+
+func TestOpeningBook_BestMoveIsMirrored(t *testing.T) {
+	book := NewOpeningBook()
+	left, player := boardFromMoves(t, "1")
+	l := bitboardFromBoard(&left, player)
+	book.add(&l, 2, 1)
+
+	right, _ := boardFromMoves(t, "7")
+	move, ok := book.BestMove(&right, player)
+	require.True(t, ok)
+	assert.Equal(t, 5, move)
+}
+
+func TestReadOpeningBook_RejectsBadFiles(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := DefaultOpeningBook().WriteTo(&buf)
+	require.NoError(t, err)
+	valid := buf.Bytes()
+
+	corrupted := append([]byte(nil), valid...)
+	corrupted[openingBookHeaderSize+7] ^= 0xFF
+	_, err = ReadOpeningBook(bytes.NewReader(corrupted))
+	assert.ErrorIs(t, err, ErrInvalidOpeningBook)
+
+	newer := append([]byte(nil), valid...)
+	newer[4] = OpeningBookVersion + 1
+	_, err = ReadOpeningBook(bytes.NewReader(newer))
+	assert.ErrorIs(t, err, ErrUnsupportedBookVersion)
+
+	_, err = ReadOpeningBook(bytes.NewReader([]byte("not a book")))
+	assert.ErrorIs(t, err, ErrInvalidOpeningBook)
+
+	_, err = ReadOpeningBook(bytes.NewReader(valid[:len(valid)-2]))
+	assert.ErrorIs(t, err, ErrInvalidOpeningBook)
+}
+
+func TestSolver_ReportsBookStats(t *testing.T) {
+	book := NewOpeningBook()
+	board, player := boardFromMoves(t, "444444121212")
+	p := bitboardFromBoard(&board, player)
+	book.add(&p, 15, 0)
+
+	s := NewSolverWithBook(book)
+	// Red wins at once, so the book is not needed
+	move, err := s.GetBestMoveWithTimeout(context.Background(), &board, player, DefaultBotTimeout)
+	require.NoError(t, err)
+	assert.Equal(t, 0, move)
+
+	// Yellow to move after a quiet red move: within the book's depth but not in it
+	board, player = boardFromMoves(t, "44444412121")
+	_, err = s.GetBestMoveWithTimeout(context.Background(), &board, player, DefaultBotTimeout)
+	require.NoError(t, err)
+	stats := s.(SearchStatsProvider).LastSearchStats()
+	assert.Greater(t, stats.BookMisses, 0)
+	assert.Greater(t, stats.Nodes, uint64(0))
+}
+
+func TestUseOpeningBook(t *testing.T) {
+	defer UseOpeningBook(nil)
+
+	book := NewOpeningBook()
+	board, player := boardFromMoves(t, "4444441212")
+	p := bitboardFromBoard(&board, player)
+	book.add(&p, 3, 2)
+	UseOpeningBook(book)
+
+	s := NewSolver()
+	move, err := s.GetBestMoveWithTimeout(context.Background(), &board, player, DefaultBotTimeout)
+	require.NoError(t, err)
+	assert.Equal(t, 2, move)
+	assert.Equal(t, SearchStats{BookHits: 1, Depth: boardCells - 10, PrincipalVariation: []int{2}}, s.(SearchStatsProvider).LastSearchStats())
+
+	// Built-in entries are kept
+	assert.Equal(t, DefaultOpeningBook().Len()+1, currentOpeningBook().Len())
+}
@@ -2,6 +2,8 @@ package bot
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"connect4-multiplayer/pkg/models"
@@ -14,8 +16,64 @@ const (
 	DifficultyEasy   Difficulty = 1
 	DifficultyMedium Difficulty = 2
 	DifficultyHard   Difficulty = 3
+	// DifficultyImpossible plays perfectly using the solver
+	DifficultyImpossible Difficulty = 4
+	// DifficultyAdaptive adjusts its strength to the human it plays
+	DifficultyAdaptive Difficulty = 5
+	// DifficultyEngine marks bots played by an external engine, whose
+	// strength is not known. Players cannot choose it as a difficulty.
+	DifficultyEngine Difficulty = 6
 )
 
+// DefaultDifficulty is used when a player does not choose a difficulty
+const DefaultDifficulty = DifficultyMedium
+
+// String returns the lowercase name of the difficulty as used in the API
+func (d Difficulty) String() string {
+	switch d {
+	case DifficultyEasy:
+		return "easy"
+	case DifficultyMedium:
+		return "medium"
+	case DifficultyHard:
+		return "hard"
+	case DifficultyImpossible:
+		return "impossible"
+	case DifficultyAdaptive:
+		return "adaptive"
+	case DifficultyEngine:
+		return "engine"
+	default:
+		return fmt.Sprintf("difficulty(%d)", int(d))
+	}
+}
+
+// IsValid reports whether d is a difficulty players can choose
+func (d Difficulty) IsValid() bool {
+	return d >= DifficultyEasy && d <= DifficultyAdaptive
+}
+
+// ParseDifficulty parses a difficulty name such as "easy" or "Hard".
+// An empty name selects DefaultDifficulty.
+func ParseDifficulty(name string) (Difficulty, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "":
+		return DefaultDifficulty, nil
+	case "easy":
+		return DifficultyEasy, nil
+	case "medium":
+		return DifficultyMedium, nil
+	case "hard":
+		return DifficultyHard, nil
+	case "impossible":
+		return DifficultyImpossible, nil
+	case "adaptive":
+		return DifficultyAdaptive, nil
+	default:
+		return 0, fmt.Errorf("unknown bot difficulty %q", name)
+	}
+}
+
 // SearchDepth returns the search depth for a given difficulty
 func (d Difficulty) SearchDepth() int {
 	switch d {
@@ -25,6 +83,8 @@ func (d Difficulty) SearchDepth() int {
 		return 4
 	case DifficultyHard:
 		return 7
+	case DifficultyImpossible:
+		return boardCells // searched to the end of the game
 	default:
 		return 4
 	}
@@ -37,7 +97,7 @@ func (d Difficulty) HumanDelay() time.Duration {
 		return 500 * time.Millisecond
 	case DifficultyMedium:
 		return 300 * time.Millisecond
-	case DifficultyHard:
+	case DifficultyHard, DifficultyImpossible, DifficultyEngine:
 		return 100 * time.Millisecond
 	default:
 		return 300 * time.Millisecond
@@ -65,3 +125,43 @@ type BotAI interface {
 	// FindBlockingMove returns a move that blocks opponent's win, -1 otherwise
 	FindBlockingMove(board *models.Board, player models.PlayerColor) int
 }
+
+// MoveScorer is implemented by bots that can score every column, which lets
+// move selection choose among good moves instead of always the best one
+type MoveScorer interface {
+	// ScoreMoves scores each column for player within the time limit
+	ScoreMoves(ctx context.Context, board *models.Board, player models.PlayerColor, timeout time.Duration) ([]ColumnScore, error)
+}
+
+// SearchStats describes the work done by a bot's last move search
+type SearchStats struct {
+	Nodes      uint64 `json:"nodes"`      // positions searched
+	BookHits   int    `json:"bookHits"`   // positions answered by the opening book
+	BookMisses int    `json:"bookMisses"` // positions within the book's depth that it did not cover
+	// Depth is the deepest search that completed, in plies
+	Depth    int    `json:"depth"`
+	TTProbes uint64 `json:"ttProbes"` // transposition table lookups
+	TTHits   uint64 `json:"ttHits"`   // lookups that found the position
+	// Duration is the time the search took
+	Duration time.Duration `json:"duration"`
+	// PrincipalVariation is the line the search expects, starting with its move
+	PrincipalVariation []int `json:"principalVariation,omitempty"`
+	// Cutoff is set when the deadline or cancellation stopped the search
+	// before it reached its full depth
+	Cutoff bool `json:"cutoff"`
+}
+
+// TTHitRate is the share of transposition table lookups that hit, or 0 if
+// there were none
+func (s SearchStats) TTHitRate() float64 {
+	if s.TTProbes == 0 {
+		return 0
+	}
+	return float64(s.TTHits) / float64(s.TTProbes)
+}
+
+// SearchStatsProvider is implemented by bots that report statistics for
+// their last search
+type SearchStatsProvider interface {
+	LastSearchStats() SearchStats
+}
@@ -0,0 +1,747 @@
+package bot
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+// External engines are programs that speak a line-based text protocol,
+// modelled on UCI, over stdin and stdout. Columns are numbered 0 to 6 from
+// the left, as everywhere else in the API. See docs/engine-protocol.md.
+//
+// Server to engine:
+//
+//	c4ei                           start the handshake
+//	setoption name <id> value <x>  set an engine option from the config
+//	isready                        wait until the engine can search
+//	newgame                        the next position starts a new game
+//	position startpos [moves <col> ...]
+//	position board <rows> <r|y>    six rows top to bottom of '.', 'r' and 'y'
+//	                               separated by '/', then the side to move
+//	go movetime <ms> [depth <n>]   search and answer with bestmove
+//	stop                           answer with bestmove now
+//	quit                           exit
+//
+// Engine to server:
+//
+//	id name <name> / id author <author>
+//	c4eiok                         handshake done
+//	readyok                        answer to isready
+//	info [depth <n>] [score <s>] [nodes <n>] [time <ms>] [pv <col> ...]
+//	bestmove <col>
+
+var (
+	// ErrEngineNotFound is returned for engine names that are not registered
+	ErrEngineNotFound = errors.New("engine not found")
+	// ErrEngineUnavailable is returned while an engine that keeps crashing
+	// waits to be restarted
+	ErrEngineUnavailable = errors.New("engine unavailable")
+	// ErrEngineCrashed is returned when the engine process exits or stops
+	// reading its input
+	ErrEngineCrashed = errors.New("engine crashed")
+	// ErrEngineTimeout is returned when the engine does not answer in time
+	ErrEngineTimeout = errors.New("engine did not answer in time")
+	// ErrEngineBusy is returned when every engine process stays busy for
+	// the whole of a move's time limit
+	ErrEngineBusy = errors.New("engine busy")
+	// ErrEngineProtocol is returned for answers that break the protocol,
+	// including illegal moves
+	ErrEngineProtocol = errors.New("engine protocol error")
+)
+
+const (
+	// engineMoveMargin is kept from each move's time limit for the round
+	// trip to the engine
+	engineMoveMargin = 50 * time.Millisecond
+	// engineStopGrace is how long an engine has to answer stop before it is
+	// treated as hung and killed
+	engineStopGrace = 200 * time.Millisecond
+	// minFallbackTime is the least search time the fallback bot gets
+	minFallbackTime = 100 * time.Millisecond
+	// engineRestartWindow is the period over which MaxRestarts is counted
+	engineRestartWindow = time.Minute
+)
+
+// engineNamePattern keeps engine names short enough for bot usernames
+var engineNamePattern = regexp.MustCompile(`^[a-z0-9-]{1,12}$`)
+
+// EngineConfig describes how to run an external engine
+type EngineConfig struct {
+	// Name identifies the engine; lowercase letters, digits and dashes, up
+	// to 12 characters
+	Name string
+	Path string   // executable, looked up in PATH if it has no slash
+	Args []string // command line arguments
+	Dir  string   // working directory, empty for the server's
+	Env  []string // extra KEY=value environment variables
+	// Options are sent with setoption after every start
+	Options map[string]string
+	// MoveTime caps the time the engine gets per move; 0 leaves it to the
+	// caller's time limit
+	MoveTime time.Duration
+	// StartupTimeout bounds the handshake
+	StartupTimeout time.Duration
+	// MaxRestarts is how many times each engine process may be restarted
+	// within a minute before it is left down for the rest of that minute
+	MaxRestarts int
+	// Processes is how many engine processes may search at once, one per
+	// game thinking; 0 means 1. Games beyond that wait for a free process,
+	// and play the fallback bot's move if none frees up in time.
+	Processes int
+	Logger    *slog.Logger
+}
+
+// EngineInfo is what an engine reported about itself in the handshake
+type EngineInfo struct {
+	Name   string `json:"name"`
+	Author string `json:"author,omitempty"`
+}
+
+// ExternalEngine is a BotAI backed by a pool of engine subprocesses. Each
+// process runs one search at a time; while the engine is down or busy, moves
+// come from a minimax bot so games never stall.
+type ExternalEngine interface {
+	BotAI
+	SearchStatsProvider
+	// Name returns the configured engine name
+	Name() string
+	// Info returns the engine's handshake details once it has started
+	Info() EngineInfo
+	// Close stops the engine process
+	Close() error
+}
+
+// externalEngine implements ExternalEngine
+type externalEngine struct {
+	config   EngineConfig
+	fallback BotAI
+	logger   *slog.Logger
+	idle     chan *engineSlot // slots free to search; a search holds its slot
+
+	mu     sync.Mutex
+	info   EngineInfo
+	stats  SearchStats
+	closed bool
+}
+
+// engineSlot runs one of the engine's processes, starting it on demand
+type engineSlot struct {
+	proc     *engineProcess
+	restarts []time.Time
+}
+
+// engineProcess is one run of the engine executable
+type engineProcess struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	lines chan string   // stdout lines; closed when stdout closes
+	done  chan struct{} // closed when the process has exited
+}
+
+// NewExternalEngine creates a bot driven by an external engine. The process
+// starts on the first move.
+func NewExternalEngine(config EngineConfig) (ExternalEngine, error) {
+	if !engineNamePattern.MatchString(config.Name) {
+		return nil, fmt.Errorf("invalid engine name %q: use up to 12 lowercase letters, digits or dashes", config.Name)
+	}
+	if _, err := exec.LookPath(config.Path); err != nil {
+		return nil, fmt.Errorf("engine %s: %w", config.Name, err)
+	}
+	if config.StartupTimeout <= 0 {
+		config.StartupTimeout = 5 * time.Second
+	}
+	if config.MaxRestarts <= 0 {
+		config.MaxRestarts = 3
+	}
+	if config.Processes <= 0 {
+		config.Processes = 1
+	}
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+
+	idle := make(chan *engineSlot, config.Processes)
+	for i := 0; i < config.Processes; i++ {
+		idle <- &engineSlot{}
+	}
+
+	return &externalEngine{
+		config:   config,
+		fallback: NewMinimaxBotWithDepth(DifficultyMedium.SearchDepth()),
+		logger:   config.Logger.With("component", "engine", "engine", config.Name),
+		idle:     idle,
+	}, nil
+}
+
+// Name returns the configured engine name
+func (e *externalEngine) Name() string {
+	return e.config.Name
+}
+
+// Info returns the engine's handshake details
+func (e *externalEngine) Info() EngineInfo {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.info
+}
+
+// GetBestMove asks the engine for a move limited to depth plies and the
+// configured move time
+func (e *externalEngine) GetBestMove(board *models.Board, player models.PlayerColor, depth int) int {
+	limit := e.config.MoveTime
+	if limit <= 0 {
+		limit = DefaultBotTimeout
+	}
+	move, _ := e.bestMove(context.Background(), board, player, depth, limit)
+	return move
+}
+
+// GetBestMoveWithTimeout asks the engine for a move within the time limit.
+// If the engine fails, the fallback bot moves instead.
+func (e *externalEngine) GetBestMoveWithTimeout(ctx context.Context, board *models.Board, player models.PlayerColor, timeout time.Duration) (int, error) {
+	return e.bestMove(ctx, board, player, 0, timeout)
+}
+
+func (e *externalEngine) bestMove(ctx context.Context, board *models.Board, player models.PlayerColor, depth int, timeout time.Duration) (int, error) {
+	start := time.Now()
+	move, err := e.search(ctx, board, player, depth, timeout)
+	if err == nil {
+		return move, nil
+	}
+
+	e.logger.Warn("engine failed, playing fallback move", "error", err)
+	remaining := timeout - time.Since(start)
+	if remaining < minFallbackTime {
+		// A hung engine used the whole budget; a shallow search still
+		// beats the first open column
+		remaining = minFallbackTime
+	}
+	return e.fallback.GetBestMoveWithTimeout(ctx, board, player, remaining)
+}
+
+// EvaluatePosition returns the fallback bot's heuristic; engines only
+// report scores for their own searches
+func (e *externalEngine) EvaluatePosition(board *models.Board, player models.PlayerColor) int {
+	return e.fallback.EvaluatePosition(board, player)
+}
+
+// FindWinningMove finds a move that wins the game immediately
+func (e *externalEngine) FindWinningMove(board *models.Board, player models.PlayerColor) int {
+	return e.fallback.FindWinningMove(board, player)
+}
+
+// FindBlockingMove finds a move that blocks the opponent's winning move
+func (e *externalEngine) FindBlockingMove(board *models.Board, player models.PlayerColor) int {
+	return e.fallback.FindBlockingMove(board, player)
+}
+
+// LastSearchStats returns the nodes, depth and principal variation of the
+// last info line of the engine's latest search
+func (e *externalEngine) LastSearchStats() SearchStats {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.stats
+}
+
+// Close stops the engine processes; later moves come from the fallback bot.
+// Processes still searching stop when their search ends.
+func (e *externalEngine) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.closed = true
+	var slots []*engineSlot
+	for drained := false; !drained; {
+		select {
+		case slot := <-e.idle:
+			slot.stop(true)
+			slots = append(slots, slot)
+		default:
+			drained = true
+		}
+	}
+	for _, slot := range slots {
+		e.idle <- slot
+	}
+	return nil
+}
+
+// acquire takes a free slot, waiting at most timeout for one
+func (e *externalEngine) acquire(ctx context.Context, timeout time.Duration) (*engineSlot, error) {
+	select {
+	case slot := <-e.idle:
+		return slot, nil
+	default:
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case slot := <-e.idle:
+		return slot, nil
+	case <-timer.C:
+		return nil, ErrEngineBusy
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// release frees a slot after a search, stopping its process if the engine
+// was closed meanwhile
+func (e *externalEngine) release(slot *engineSlot) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		slot.stop(true)
+	}
+	e.idle <- slot
+}
+
+// search runs one engine search on a free process, starting it if needed.
+// Time spent waiting for a process counts towards the timeout. Searches
+// that do not answer in time are stopped, and engines that ignore stop
+// are killed.
+func (e *externalEngine) search(ctx context.Context, board *models.Board, player models.PlayerColor, depth int, timeout time.Duration) (int, error) {
+	start := time.Now()
+	var stats SearchStats
+	defer func() {
+		stats.Duration = time.Since(start)
+		e.mu.Lock()
+		e.stats = stats
+		e.mu.Unlock()
+	}()
+
+	slot, err := e.acquire(ctx, timeout)
+	if err != nil {
+		return -1, err
+	}
+	defer e.release(slot)
+
+	if err := e.ensureRunning(slot); err != nil {
+		return -1, err
+	}
+	proc := slot.proc
+
+	limit := timeout - time.Since(start)
+	if e.config.MoveTime > 0 && e.config.MoveTime < limit {
+		limit = e.config.MoveTime
+	}
+	moveTime := max(int((limit-engineMoveMargin)/time.Millisecond), 1)
+
+	// A search cut short earlier may have left a late answer behind
+	proc.drain()
+
+	command := fmt.Sprintf("go movetime %d", moveTime)
+	if depth > 0 {
+		command += fmt.Sprintf(" depth %d", depth)
+	}
+	if err := proc.send("position "+encodeBoard(board, player), command); err != nil {
+		slot.stop(false)
+		return -1, err
+	}
+
+	timer := time.NewTimer(limit)
+	defer timer.Stop()
+	cancelled := ctx.Done()
+	stopped := false
+	for {
+		select {
+		case line, ok := <-proc.lines:
+			if !ok {
+				slot.stop(false)
+				return -1, ErrEngineCrashed
+			}
+			move, done, err := readSearchLine(line, board, &stats)
+			if err != nil {
+				slot.stop(false)
+				return -1, err
+			}
+			if done {
+				return move, nil
+			}
+
+		case <-timer.C:
+			if stopped {
+				// Ignored stop; the engine is hung
+				slot.stop(false)
+				return -1, ErrEngineTimeout
+			}
+			stopped = true
+			stats.Cutoff = true
+			if err := proc.send("stop"); err != nil {
+				slot.stop(false)
+				return -1, err
+			}
+			timer.Reset(engineStopGrace)
+
+		case <-cancelled:
+			cancelled = nil
+			if !stopped {
+				stopped = true
+				stats.Cutoff = true
+				if err := proc.send("stop"); err != nil {
+					slot.stop(false)
+					return -1, err
+				}
+				timer.Reset(engineStopGrace)
+			}
+		}
+	}
+}
+
+// readSearchLine handles one line of engine output during a search, noting
+// progress in stats, and reports the move once bestmove arrives
+func readSearchLine(line string, board *models.Board, stats *SearchStats) (int, bool, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return -1, false, nil
+	}
+
+	switch fields[0] {
+	case "info":
+		if nodes, ok := infoValue(fields, "nodes"); ok {
+			stats.Nodes = uint64(nodes)
+		}
+		if depth, ok := infoValue(fields, "depth"); ok {
+			stats.Depth = int(depth)
+		}
+		if pv := infoPV(fields); pv != nil {
+			stats.PrincipalVariation = pv
+		}
+		return -1, false, nil
+	case "bestmove":
+		if len(fields) < 2 {
+			return -1, false, fmt.Errorf("%w: bestmove without a column", ErrEngineProtocol)
+		}
+		move, err := strconv.Atoi(fields[1])
+		if err != nil || !board.IsValidMove(move) {
+			return -1, false, fmt.Errorf("%w: illegal move %q", ErrEngineProtocol, fields[1])
+		}
+		return move, true, nil
+	default:
+		// Unknown lines are ignored so engines can add their own output
+		return -1, false, nil
+	}
+}
+
+// infoValue reads the number following key in an info line
+func infoValue(fields []string, key string) (int64, bool) {
+	for i := 1; i+1 < len(fields); i++ {
+		if fields[i] == key {
+			value, err := strconv.ParseInt(fields[i+1], 10, 64)
+			return value, err == nil
+		}
+	}
+	return 0, false
+}
+
+// infoPV returns the columns after pv in an info line, or nil if there are
+// none. The line runs to the end of the info line or the first non-column.
+func infoPV(fields []string) []int {
+	var pv []int
+	for i := 1; i < len(fields); i++ {
+		if fields[i] != "pv" {
+			continue
+		}
+		for _, field := range fields[i+1:] {
+			col, err := strconv.Atoi(field)
+			if err != nil || col < 0 || col >= boardWidth {
+				break
+			}
+			pv = append(pv, col)
+		}
+		break
+	}
+	return pv
+}
+
+// ensureRunning starts the slot's process unless it is running, counting
+// restarts so a process that keeps crashing is left down for a while
+func (e *externalEngine) ensureRunning(slot *engineSlot) error {
+	e.mu.Lock()
+	closed := e.closed
+	e.mu.Unlock()
+	if closed {
+		return ErrEngineUnavailable
+	}
+	if slot.proc != nil {
+		select {
+		case <-slot.proc.done:
+			slot.proc = nil
+		default:
+			return nil
+		}
+	}
+
+	now := time.Now()
+	recent := slot.restarts[:0]
+	for _, at := range slot.restarts {
+		if now.Sub(at) < engineRestartWindow {
+			recent = append(recent, at)
+		}
+	}
+	slot.restarts = recent
+	if len(slot.restarts) > e.config.MaxRestarts {
+		return ErrEngineUnavailable
+	}
+	slot.restarts = append(slot.restarts, now)
+
+	proc, info, err := startEngine(e.config)
+	if err != nil {
+		return err
+	}
+	slot.proc = proc
+	e.mu.Lock()
+	e.info = info
+	e.mu.Unlock()
+	e.logger.Info("engine started", "name", info.Name, "author", info.Author)
+	return nil
+}
+
+// stop ends the slot's process so the next search restarts it. Processes
+// that failed are killed; healthy ones are asked to quit.
+func (s *engineSlot) stop(graceful bool) {
+	if s.proc == nil {
+		return
+	}
+	if graceful {
+		s.proc.quit()
+	} else {
+		s.proc.kill()
+	}
+	s.proc = nil
+}
+
+// startEngine runs the executable and completes the handshake
+func startEngine(config EngineConfig) (*engineProcess, EngineInfo, error) {
+	cmd := exec.Command(config.Path, config.Args...)
+	cmd.Dir = config.Dir
+	cmd.Env = append(os.Environ(), config.Env...)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, EngineInfo{}, fmt.Errorf("engine %s: %w", config.Name, err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, EngineInfo{}, fmt.Errorf("engine %s: %w", config.Name, err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, EngineInfo{}, fmt.Errorf("engine %s: %w", config.Name, err)
+	}
+
+	proc := &engineProcess{
+		cmd:   cmd,
+		stdin: stdin,
+		lines: make(chan string, 64),
+		done:  make(chan struct{}),
+	}
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			proc.lines <- scanner.Text()
+		}
+		// Output has ended, so the process is exiting
+		_ = cmd.Wait()
+		close(proc.done)
+		close(proc.lines)
+	}()
+
+	info, err := proc.handshake(config)
+	if err != nil {
+		proc.kill()
+		return nil, EngineInfo{}, fmt.Errorf("engine %s: %w", config.Name, err)
+	}
+	return proc, info, nil
+}
+
+// handshake identifies the engine, sets its options and waits until it is
+// ready, all within the startup timeout
+func (p *engineProcess) handshake(config EngineConfig) (EngineInfo, error) {
+	deadline := time.NewTimer(config.StartupTimeout)
+	defer deadline.Stop()
+
+	info := EngineInfo{Name: config.Name}
+	if err := p.send("c4ei"); err != nil {
+		return info, err
+	}
+	err := p.await("c4eiok", deadline.C, func(line string) {
+		if name, ok := strings.CutPrefix(line, "id name "); ok {
+			info.Name = strings.TrimSpace(name)
+		} else if author, ok := strings.CutPrefix(line, "id author "); ok {
+			info.Author = strings.TrimSpace(author)
+		}
+	})
+	if err != nil {
+		return info, err
+	}
+
+	names := make([]string, 0, len(config.Options))
+	for name := range config.Options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := p.send(fmt.Sprintf("setoption name %s value %s", name, config.Options[name])); err != nil {
+			return info, err
+		}
+	}
+
+	if err := p.send("newgame", "isready"); err != nil {
+		return info, err
+	}
+	return info, p.await("readyok", deadline.C, nil)
+}
+
+// await reads lines until want, passing the others to handle
+func (p *engineProcess) await(want string, deadline <-chan time.Time, handle func(string)) error {
+	for {
+		select {
+		case line, ok := <-p.lines:
+			if !ok {
+				return ErrEngineCrashed
+			}
+			line = strings.TrimSpace(line)
+			if line == want {
+				return nil
+			}
+			if handle != nil {
+				handle(line)
+			}
+		case <-deadline:
+			return fmt.Errorf("%w: no %s", ErrEngineTimeout, want)
+		}
+	}
+}
+
+// send writes commands to the engine, one per line
+func (p *engineProcess) send(commands ...string) error {
+	for _, command := range commands {
+		if _, err := io.WriteString(p.stdin, command+"\n"); err != nil {
+			return fmt.Errorf("%w: %v", ErrEngineCrashed, err)
+		}
+	}
+	return nil
+}
+
+// drain discards output left over from an earlier search
+func (p *engineProcess) drain() {
+	for {
+		select {
+		case _, ok := <-p.lines:
+			if !ok {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+// quit asks the engine to exit and kills it if it does not
+func (p *engineProcess) quit() {
+	_ = p.send("quit")
+	_ = p.stdin.Close()
+	select {
+	case <-p.done:
+	case <-time.After(engineStopGrace):
+		p.kill()
+	}
+}
+
+// kill ends the process and waits for it to exit, discarding any output
+// the reader is still trying to deliver
+func (p *engineProcess) kill() {
+	_ = p.cmd.Process.Kill()
+	_ = p.stdin.Close()
+	for range p.lines {
+	}
+}
+
+// encodeBoard writes a position command's board argument: the rows from
+// top to bottom separated by slashes, then the side to move
+func encodeBoard(board *models.Board, player models.PlayerColor) string {
+	var sb strings.Builder
+	sb.WriteString("board ")
+	for row := boardHeight - 1; row >= 0; row-- {
+		for col := 0; col < boardWidth; col++ {
+			switch board.Grid[row][col] {
+			case models.PlayerColorRed:
+				sb.WriteByte('r')
+			case models.PlayerColorYellow:
+				sb.WriteByte('y')
+			default:
+				sb.WriteByte('.')
+			}
+		}
+		if row > 0 {
+			sb.WriteByte('/')
+		}
+	}
+	if player == models.PlayerColorRed {
+		sb.WriteString(" r")
+	} else {
+		sb.WriteString(" y")
+	}
+	return sb.String()
+}
+
+var (
+	enginesMu sync.RWMutex
+	engines   = make(map[string]ExternalEngine)
+)
+
+// RegisterEngine makes an engine available to bot games under its name. It
+// is meant to be called at startup.
+func RegisterEngine(engine ExternalEngine) error {
+	enginesMu.Lock()
+	defer enginesMu.Unlock()
+	if _, exists := engines[engine.Name()]; exists {
+		return fmt.Errorf("engine %s is already registered", engine.Name())
+	}
+	engines[engine.Name()] = engine
+	return nil
+}
+
+// LookupEngine returns the registered engine with the given name
+func LookupEngine(name string) (ExternalEngine, bool) {
+	enginesMu.RLock()
+	defer enginesMu.RUnlock()
+	engine, ok := engines[name]
+	return engine, ok
+}
+
+// EngineNames returns the names of the registered engines in order
+func EngineNames() []string {
+	enginesMu.RLock()
+	defer enginesMu.RUnlock()
+	names := make([]string, 0, len(engines))
+	for name := range engines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CloseEngines stops and unregisters every engine
+func CloseEngines() {
+	enginesMu.Lock()
+	defer enginesMu.Unlock()
+	for name, engine := range engines {
+		_ = engine.Close()
+		delete(engines, name)
+	}
+}
@@ -0,0 +1,259 @@
+package bot
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+// engineHelperEnv selects how the test binary behaves when it runs as an
+// engine: "left" plays the leftmost open column, "slow" does so after
+// engineHelperThinkTime, "hang" never answers go, "crash" exits on go,
+// "illegal" answers with an off-board column, and "crash-once:<file>"
+// crashes unless file exists, creating it first
+const engineHelperEnv = "C4_TEST_ENGINE"
+
+const engineHelperThinkTime = 300 * time.Millisecond
+
+func TestEngineHelperProcess(t *testing.T) {
+	mode := os.Getenv(engineHelperEnv)
+	if mode == "" {
+		return
+	}
+	runTestEngine(mode)
+	os.Exit(0)
+}
+
+// runTestEngine speaks the engine protocol on stdin and stdout
+func runTestEngine(mode string) {
+	if file, ok := strings.CutPrefix(mode, "crash-once:"); ok {
+		mode = "left"
+		if _, err := os.Stat(file); err != nil {
+			_ = os.WriteFile(file, nil, 0o600)
+			mode = "crash"
+		}
+	}
+
+	var grid string
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "c4ei":
+			fmt.Println("id name Leftmost")
+			fmt.Println("id author Tests")
+			fmt.Println("c4eiok")
+		case "isready":
+			fmt.Println("readyok")
+		case "position":
+			grid = fields[2]
+		case "go":
+			switch mode {
+			case "hang":
+				continue
+			case "crash":
+				os.Exit(3)
+			case "illegal":
+				fmt.Println("bestmove 9")
+				continue
+			case "slow":
+				time.Sleep(engineHelperThinkTime)
+			}
+			top := strings.Split(grid, "/")[0]
+			fmt.Println("info depth 1 score 0 nodes 42 pv", strings.IndexByte(top, '.'))
+			fmt.Println("bestmove", strings.IndexByte(top, '.'))
+		case "quit":
+			return
+		}
+	}
+}
+
+func newTestEngine(t *testing.T, mode string) ExternalEngine {
+	t.Helper()
+	return newTestEnginePool(t, mode, 1)
+}
+
+func newTestEnginePool(t *testing.T, mode string, processes int) ExternalEngine {
+	t.Helper()
+	engine, err := NewExternalEngine(EngineConfig{
+		Name:        "leftmost",
+		Path:        os.Args[0],
+		Args:        []string{"-test.run=^TestEngineHelperProcess$"},
+		Env:         []string{engineHelperEnv + "=" + mode},
+		Options:     map[string]string{"Hash": "16"},
+		MaxRestarts: 2,
+		Processes:   processes,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = engine.Close() })
+	return engine
+}
+
+func TestExternalEngine_PlaysEngineMoves(t *testing.T) {
+	engine := newTestEngine(t, "left")
+	board, player := boardFromMoves(t, "1111114")
+
+	move, err := engine.GetBestMoveWithTimeout(context.Background(), &board, player, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 1, move, "column 0 is full, so the leftmost open column is 1")
+	assert.Equal(t, EngineInfo{Name: "Leftmost", Author: "Tests"}, engine.Info())
+	stats := engine.LastSearchStats()
+	assert.Equal(t, uint64(42), stats.Nodes)
+	assert.Equal(t, 1, stats.Depth)
+	assert.Equal(t, []int{1}, stats.PrincipalVariation)
+	assert.False(t, stats.Cutoff)
+
+	board, player = boardFromMoves(t, "")
+	assert.Equal(t, 0, engine.GetBestMove(&board, player, 4), "the process is reused")
+}
+
+func TestExternalEngine_FallsBackWhenEngineFails(t *testing.T) {
+	for _, mode := range []string{"hang", "crash", "illegal"} {
+		t.Run(mode, func(t *testing.T) {
+			engine := newTestEngine(t, mode)
+			board, player := boardFromMoves(t, "")
+
+			start := time.Now()
+			move, err := engine.GetBestMoveWithTimeout(context.Background(), &board, player, 300*time.Millisecond)
+			require.NoError(t, err)
+			assert.Equal(t, 3, move, "the minimax fallback plays the center")
+			assert.Less(t, time.Since(start), 2*time.Second)
+		})
+	}
+}
+
+func TestExternalEngine_RestartsAfterCrash(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "crashed")
+	engine := newTestEngine(t, "crash-once:"+marker)
+	board, player := boardFromMoves(t, "")
+
+	move, err := engine.GetBestMoveWithTimeout(context.Background(), &board, player, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 3, move, "the fallback covers the crashed move")
+
+	move, err = engine.GetBestMoveWithTimeout(context.Background(), &board, player, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 0, move, "the restarted engine plays again")
+}
+
+func TestExternalEngine_StopsRestartingCrashLoops(t *testing.T) {
+	engine := newTestEngine(t, "crash").(*externalEngine)
+	board, player := boardFromMoves(t, "")
+
+	for i := 0; i < 5; i++ {
+		_, err := engine.GetBestMoveWithTimeout(context.Background(), &board, player, 200*time.Millisecond)
+		require.NoError(t, err)
+	}
+	slot := <-engine.idle
+	assert.Len(t, slot.restarts, engine.config.MaxRestarts+1)
+	engine.idle <- slot
+
+	_, err := engine.search(context.Background(), &board, player, 0, 200*time.Millisecond)
+	assert.ErrorIs(t, err, ErrEngineUnavailable)
+}
+
+func TestExternalEngine_SearchesConcurrentlyWithAPool(t *testing.T) {
+	engine := newTestEnginePool(t, "slow", 2)
+	board, player := boardFromMoves(t, "")
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	moves := make([]int, 2)
+	for i := range moves {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			moves[i], _ = engine.GetBestMoveWithTimeout(context.Background(), &board, player, time.Second)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, []int{0, 0}, moves, "both games got the engine's move")
+	assert.Less(t, time.Since(start), 2*engineHelperThinkTime, "neither game waited for the other")
+}
+
+func TestNewExternalEngine_ValidatesConfig(t *testing.T) {
+	_, err := NewExternalEngine(EngineConfig{Name: "Has Spaces", Path: os.Args[0]})
+	assert.Error(t, err)
+
+	_, err = NewExternalEngine(EngineConfig{Name: "missing", Path: "/nonexistent/engine"})
+	assert.Error(t, err)
+}
+
+func TestEncodeBoard(t *testing.T) {
+	board, player := boardFromMoves(t, "44")
+	assert.Equal(t, "board ......./......./......./......./...y.../...r... r", encodeBoard(&board, player))
+
+	empty := models.NewBoard()
+	assert.True(t, strings.HasSuffix(encodeBoard(&empty, models.PlayerColorYellow), " y"))
+}
+
+func TestEngineRegistry(t *testing.T) {
+	engine := newTestEngine(t, "left")
+	require.NoError(t, RegisterEngine(engine))
+	t.Cleanup(CloseEngines)
+
+	assert.Error(t, RegisterEngine(engine), "names are unique")
+	found, ok := LookupEngine("leftmost")
+	assert.True(t, ok)
+	assert.Equal(t, engine, found)
+	assert.Equal(t, []string{"leftmost"}, EngineNames())
+
+	CloseEngines()
+	_, ok = LookupEngine("leftmost")
+	assert.False(t, ok)
+}
+
+func TestCreateEngineBot(t *testing.T) {
+	service := NewBotPlayerServiceWithSeed(1)
+	_, err := service.CreateEngineBot("leftmost")
+	assert.ErrorIs(t, err, ErrEngineNotFound)
+
+	require.NoError(t, RegisterEngine(newTestEngine(t, "left")))
+	t.Cleanup(CloseEngines)
+
+	player, err := service.CreateEngineBot("leftmost")
+	require.NoError(t, err)
+	assert.Equal(t, "Bot_Ext_leftmost_1", player.Username)
+	assert.Equal(t, "leftmost", player.Engine)
+	assert.Equal(t, DifficultyEngine, player.Difficulty)
+	assert.True(t, service.IsBot(player.Username))
+
+	other, err := service.CreateEngineBot("leftmost")
+	require.NoError(t, err)
+	assert.NotEqual(t, player.Username, other.Username, "each game's bot has its own username")
+
+	name, ok := EngineFromUsername(player.Username)
+	assert.True(t, ok)
+	assert.Equal(t, "leftmost", name)
+	name, ok = EngineFromUsername("Bot_Ext_leftmost")
+	assert.True(t, ok, "games from before bots were numbered")
+	assert.Equal(t, "leftmost", name)
+	_, ok = EngineFromUsername("Bot_Hard_3")
+	assert.False(t, ok)
+
+	// The engine's move stands even when it misses a win
+	board, color := boardFromMoves(t, "454545")
+	move, err := service.GetBotMove(context.Background(), player, &board, color)
+	require.NoError(t, err)
+	assert.Equal(t, 0, move)
+
+	// Engine searches are not counted as any difficulty's
+	metrics := service.SearchMetrics()
+	assert.Contains(t, metrics, "engine")
+	assert.NotContains(t, metrics, "hard")
+}
@@ -0,0 +1,156 @@
+package bot
+
+import (
+	"fmt"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+// MoveReason is the main reason a bot played a move
+type MoveReason string
+
+const (
+	// ReasonWin completes four in a row
+	ReasonWin MoveReason = "win"
+	// ReasonBlock stops the opponent completing four on their next move
+	ReasonBlock MoveReason = "block"
+	// ReasonDoubleThreat leaves two wins that cannot both be blocked
+	ReasonDoubleThreat MoveReason = "double_threat"
+	// ReasonZugzwang makes a threat on a row the opponent will eventually
+	// be forced to play under
+	ReasonZugzwang MoveReason = "zugzwang"
+	// ReasonCenterControl takes the center column, which is part of the
+	// most fours
+	ReasonCenterControl MoveReason = "center_control"
+	// ReasonBestScore is the move the search rated highest
+	ReasonBestScore MoveReason = "best_score"
+)
+
+// Cell is a square of the board. Row 0 is the bottom row.
+type Cell struct {
+	Column int `json:"column"`
+	Row    int `json:"row"`
+}
+
+// MoveExplanation says why a bot played a move, for training mode
+type MoveExplanation struct {
+	Reason MoveReason `json:"reason"`
+	Column int        `json:"column"`
+	// Threats are the cells behind the reason: the opponent's winning cell
+	// for a block, the two winning cells of a double threat and the new
+	// threat for zugzwang
+	Threats []Cell `json:"threats,omitempty"`
+	// Outcome is the proven result of the move, when the search found one
+	Outcome Outcome `json:"outcome,omitempty"`
+	// Text explains the move in words, counting columns from 1
+	Text string `json:"text"`
+}
+
+// ExplainMove explains why player dropped a disc in column on board, the
+// position before the move. Immediate wins and blocks come first, then
+// threats the move creates, then center control; anything else is put down
+// to the search. scores are the search's column scores and may be nil.
+func ExplainMove(board *models.Board, player models.PlayerColor, column int, scores []ColumnScore) MoveExplanation {
+	explanation := MoveExplanation{Reason: ReasonBestScore, Column: column}
+	if column >= 0 && column < len(scores) && scores[column].Column == column {
+		explanation.Outcome = scores[column].Outcome
+	}
+	if !board.IsValidMove(column) {
+		explanation.Text = fmt.Sprintf("Column %d", column+1)
+		return explanation
+	}
+
+	opponent := getOpponent(player)
+	row := board.Height[column]
+	after := *board
+	_ = after.MakeMove(column, player)
+
+	switch {
+	case connectsFour(&after, row, column, player):
+		explanation.Reason = ReasonWin
+		explanation.Text = fmt.Sprintf("Column %d completes four in a row", column+1)
+
+	case isThreat(board, Cell{Column: column, Row: row}, opponent):
+		explanation.Reason = ReasonBlock
+		explanation.Threats = []Cell{{Column: column, Row: row}}
+		explanation.Text = fmt.Sprintf("Column %d blocks the opponent from completing four there", column+1)
+
+	default:
+		if threats := doubleThreat(&after, player); threats != nil {
+			explanation.Reason = ReasonDoubleThreat
+			explanation.Threats = threats
+			explanation.Text = fmt.Sprintf("Column %d makes two threats, in columns %d and %d, and only one can be blocked",
+				column+1, threats[0].Column+1, threats[1].Column+1)
+		} else if threat, ok := newParityThreat(board, &after, player); ok {
+			explanation.Reason = ReasonZugzwang
+			explanation.Threats = []Cell{threat}
+			explanation.Text = fmt.Sprintf("Column %d makes a threat in column %d on row %d, which the opponent will eventually have to play under",
+				column+1, threat.Column+1, threat.Row+1)
+		} else if column == len(board.Height)/2 {
+			explanation.Reason = ReasonCenterControl
+			explanation.Text = fmt.Sprintf("Column %d takes the center, which is part of the most fours", column+1)
+		} else {
+			explanation.Text = fmt.Sprintf("Column %d was the strongest move the search found", column+1)
+		}
+	}
+
+	switch explanation.Outcome {
+	case OutcomeWin:
+		explanation.Text += fmt.Sprintf("; it wins with best play in %d moves", scores[column].Plies)
+	case OutcomeLoss:
+		explanation.Text += "; every move loses with best play"
+	}
+	return explanation
+}
+
+// isThreat reports whether cell is empty and player would complete four by
+// filling it
+func isThreat(board *models.Board, cell Cell, player models.PlayerColor) bool {
+	// connectsFour only looks at the cell's neighbours
+	return board.Grid[cell.Row][cell.Column] == "" && connectsFour(board, cell.Row, cell.Column, player)
+}
+
+// doubleThreat returns two cells where player threatens to win next move
+// that the opponent cannot both cover: two playable cells, or a playable
+// cell with another directly above it. It returns nil if there are none.
+func doubleThreat(board *models.Board, player models.PlayerColor) []Cell {
+	var playable []Cell
+	for _, column := range validColumns(board) {
+		cell := Cell{Column: column, Row: board.Height[column]}
+		if !isThreat(board, cell, player) {
+			continue
+		}
+		above := Cell{Column: column, Row: cell.Row + 1}
+		if above.Row < len(board.Grid) && isThreat(board, above, player) {
+			return []Cell{cell, above}
+		}
+		playable = append(playable, cell)
+	}
+	if len(playable) >= 2 {
+		return playable[:2]
+	}
+	return nil
+}
+
+// newParityThreat returns a threat player has on after but not on before,
+// above the next playable cell and on a row that suits player: red, who moves
+// first, wants threats on odd rows counting from 1 and yellow on even rows.
+// Such threats win when the rest of the board fills up.
+func newParityThreat(before, after *models.Board, player models.PlayerColor) (Cell, bool) {
+	parity := 0
+	if player == models.PlayerColorYellow {
+		parity = 1
+	}
+	for column := range after.Height {
+		for row := after.Height[column] + 1; row < len(after.Grid); row++ {
+			cell := Cell{Column: column, Row: row}
+			if row%2 != parity || !isThreat(after, cell, player) {
+				continue
+			}
+			if !isThreat(before, cell, player) {
+				return cell, true
+			}
+		}
+	}
+	return Cell{}, false
+}
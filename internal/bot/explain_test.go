@@ -0,0 +1,102 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+// boardFromColumns stacks discs bottom up, one string of 'R' and 'Y' per column
+func boardFromColumns(t *testing.T, columns ...string) models.Board {
+	t.Helper()
+	board := models.NewBoard()
+	for col, discs := range columns {
+		for _, disc := range discs {
+			color := models.PlayerColorRed
+			if disc == 'Y' {
+				color = models.PlayerColorYellow
+			}
+			require.NoError(t, board.MakeMove(col, color))
+		}
+	}
+	return board
+}
+
+func TestExplainMove(t *testing.T) {
+	red, yellow := models.PlayerColorRed, models.PlayerColorYellow
+
+	t.Run("Win", func(t *testing.T) {
+		board := boardFromColumns(t, "R", "R", "R", "", "", "Y", "YY")
+		explanation := ExplainMove(&board, red, 3, nil)
+		assert.Equal(t, ReasonWin, explanation.Reason)
+		assert.Equal(t, 3, explanation.Column)
+	})
+
+	t.Run("Block", func(t *testing.T) {
+		board := boardFromColumns(t, "R", "R", "R", "", "", "Y", "YY")
+		explanation := ExplainMove(&board, yellow, 3, nil)
+		assert.Equal(t, ReasonBlock, explanation.Reason)
+		assert.Equal(t, []Cell{{Column: 3, Row: 0}}, explanation.Threats)
+		assert.Contains(t, explanation.Text, "Column 4")
+	})
+
+	t.Run("DoubleThreat", func(t *testing.T) {
+		board := boardFromColumns(t, "", "", "RY", "RY")
+		explanation := ExplainMove(&board, red, 1, nil)
+		assert.Equal(t, ReasonDoubleThreat, explanation.Reason)
+		assert.Equal(t, []Cell{{Column: 0, Row: 0}, {Column: 4, Row: 0}}, explanation.Threats)
+	})
+
+	t.Run("Zugzwang", func(t *testing.T) {
+		// Red completes three on the third row, which suits the first player
+		board := boardFromColumns(t, "YYR", "RYR", "YR")
+		explanation := ExplainMove(&board, red, 2, nil)
+		assert.Equal(t, ReasonZugzwang, explanation.Reason)
+		assert.Equal(t, []Cell{{Column: 3, Row: 2}}, explanation.Threats)
+
+		// The same threat for yellow is on the wrong row
+		board = boardFromColumns(t, "RRY", "YRY", "RY")
+		explanation = ExplainMove(&board, yellow, 2, nil)
+		assert.Equal(t, ReasonBestScore, explanation.Reason)
+	})
+
+	t.Run("CenterControl", func(t *testing.T) {
+		board := models.NewBoard()
+		explanation := ExplainMove(&board, red, 3, nil)
+		assert.Equal(t, ReasonCenterControl, explanation.Reason)
+	})
+
+	t.Run("BestScore", func(t *testing.T) {
+		board := models.NewBoard()
+		scores := make([]ColumnScore, 7)
+		for col := range scores {
+			scores[col] = ColumnScore{Column: col, Playable: true}
+		}
+		scores[0].Outcome, scores[0].Plies = OutcomeWin, 9
+
+		explanation := ExplainMove(&board, red, 0, scores)
+		assert.Equal(t, ReasonBestScore, explanation.Reason)
+		assert.Equal(t, OutcomeWin, explanation.Outcome)
+		assert.Contains(t, explanation.Text, "in 9 moves")
+	})
+}
+
+func TestGetBotMove_RecordsScores(t *testing.T) {
+	service := NewBotPlayerServiceWithSeed(1)
+	player := service.CreateBot(DifficultyEasy)
+	board := models.NewBoard()
+
+	_, err := service.GetBotMove(context.Background(), player, &board, models.PlayerColorRed)
+	require.NoError(t, err)
+	assert.Len(t, player.Scores, 7)
+
+	// Forced moves are not searched
+	board = boardFromColumns(t, "R", "R", "R")
+	_, err = service.GetBotMove(context.Background(), player, &board, models.PlayerColorRed)
+	require.NoError(t, err)
+	assert.Nil(t, player.Scores)
+}
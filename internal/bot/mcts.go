@@ -0,0 +1,389 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+// Algorithm is the search a built-in bot plays with
+type Algorithm string
+
+const (
+	// AlgorithmMinimax searches with alpha-beta and the heuristic evaluation
+	AlgorithmMinimax Algorithm = "minimax"
+	// AlgorithmMCTS searches with Monte Carlo tree search
+	AlgorithmMCTS Algorithm = "mcts"
+)
+
+// ParseAlgorithm parses an algorithm name. An empty name selects
+// AlgorithmMinimax.
+func ParseAlgorithm(name string) (Algorithm, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", string(AlgorithmMinimax):
+		return AlgorithmMinimax, nil
+	case string(AlgorithmMCTS):
+		return AlgorithmMCTS, nil
+	default:
+		return "", fmt.Errorf("unknown bot algorithm %q", name)
+	}
+}
+
+// mctsCheckInterval is how many playouts run between deadline checks
+const mctsCheckInterval = 64
+
+// MCTSConfig configures a Monte Carlo tree search bot. The search stops at
+// whichever limit comes first: Playouts, TimeBudget or the caller's timeout.
+type MCTSConfig struct {
+	// Playouts per move; 0 searches until the time runs out
+	Playouts int `json:"playouts"`
+	// TimeBudget per move; 0 uses the caller's timeout, or DefaultBotTimeout
+	// when there is none
+	TimeBudget time.Duration `json:"timeBudget"`
+	// Exploration is the UCT exploration constant; 0 uses √2
+	Exploration float64 `json:"exploration"`
+	// HeuristicRollouts plays immediate wins and blocks during playouts and
+	// otherwise prefers moves that EvaluatePosition rates highly, instead of
+	// playing uniformly at random
+	HeuristicRollouts bool `json:"heuristicRollouts"`
+	// Seed makes the search reproducible; 0 seeds from the clock
+	Seed int64 `json:"seed"`
+}
+
+// DefaultMCTSConfig returns a time-limited search with heuristic rollouts
+func DefaultMCTSConfig() MCTSConfig {
+	return MCTSConfig{
+		Exploration:       math.Sqrt2,
+		HeuristicRollouts: true,
+	}
+}
+
+// MCTSConfig returns the search limits of an MCTS bot at a difficulty
+func (d Difficulty) MCTSConfig() MCTSConfig {
+	config := DefaultMCTSConfig()
+	switch d {
+	case DifficultyEasy:
+		config.Playouts = 200
+		config.HeuristicRollouts = false
+	case DifficultyMedium:
+		config.Playouts = 2000
+		config.HeuristicRollouts = false
+	case DifficultyHard, DifficultyImpossible:
+		// Search until the move timeout
+	default:
+		return DifficultyMedium.MCTSConfig()
+	}
+	return config
+}
+
+// mctsHeuristicGreed is the chance a heuristic rollout plays the best-rated
+// move rather than a random one, which keeps playouts varied
+const mctsHeuristicGreed = 0.5
+
+// mctsBot implements BotAI with UCT Monte Carlo tree search. It needs no
+// evaluation function, so unlike minimax it does not depend on handcrafted
+// weights; EvaluatePosition is only used to guide rollouts.
+type mctsBot struct {
+	config    MCTSConfig
+	heuristic BotAI // tactics and rollout guidance
+	rng       *rand.Rand
+	stats     SearchStats
+}
+
+// mctsNode is a position in the search tree, reached by player dropping a
+// disc in column
+type mctsNode struct {
+	parent   *mctsNode
+	children []*mctsNode
+	untried  []int
+	column   int
+	player   models.PlayerColor
+	visits   int
+	wins     float64 // for player; draws count half
+	terminal bool
+	winner   *models.PlayerColor
+}
+
+// NewMCTSBot creates a Monte Carlo tree search bot
+func NewMCTSBot(config MCTSConfig) BotAI {
+	if config.Playouts < 0 {
+		config.Playouts = 0
+	}
+	if config.Exploration <= 0 {
+		config.Exploration = math.Sqrt2
+	}
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &mctsBot{
+		config:    config,
+		heuristic: NewMinimaxBotWithDepth(1),
+		rng:       rand.New(rand.NewSource(seed)),
+	}
+}
+
+// GetBestMove returns the most visited move; depth is ignored
+func (b *mctsBot) GetBestMove(board *models.Board, player models.PlayerColor, depth int) int {
+	move, _ := b.GetBestMoveWithTimeout(context.Background(), board, player, DefaultBotTimeout)
+	return move
+}
+
+// GetBestMoveWithTimeout plays immediate wins and blocks, and otherwise
+// searches until a limit is reached and returns the most visited move
+func (b *mctsBot) GetBestMoveWithTimeout(ctx context.Context, board *models.Board, player models.PlayerColor, timeout time.Duration) (int, error) {
+	b.stats = SearchStats{}
+
+	if move := b.FindWinningMove(board, player); move != -1 {
+		return move, nil
+	}
+	if move := b.FindBlockingMove(board, player); move != -1 {
+		return move, nil
+	}
+
+	moves := validColumns(board)
+	switch len(moves) {
+	case 0:
+		return -1, fmt.Errorf("no valid moves available")
+	case 1:
+		return moves[0], nil
+	}
+
+	if b.config.TimeBudget > 0 && (timeout <= 0 || b.config.TimeBudget < timeout) {
+		timeout = b.config.TimeBudget
+	}
+	if timeout <= 0 {
+		timeout = DefaultBotTimeout
+	}
+
+	start := time.Now()
+	root := b.search(ctx, board, player, start.Add(timeout))
+	b.stats.PrincipalVariation = root.principalVariation()
+	b.stats.Depth = len(b.stats.PrincipalVariation)
+	b.stats.Duration = time.Since(start)
+	return b.stats.PrincipalVariation[0], ctx.Err()
+}
+
+// search grows a tree from board until a limit is reached. At least one
+// playout is run for every move so the root always has children.
+func (b *mctsBot) search(ctx context.Context, board *models.Board, player models.PlayerColor, deadline time.Time) *mctsNode {
+	root := &mctsNode{column: -1, player: getOpponent(player), untried: validColumns(board)}
+	minPlayouts := len(root.untried)
+
+	for playouts := 0; ; playouts++ {
+		if playouts >= minPlayouts {
+			if b.config.Playouts > 0 && playouts >= b.config.Playouts {
+				break
+			}
+			if playouts%mctsCheckInterval == 0 && (ctx.Err() != nil || time.Now().After(deadline)) {
+				// Running out of time only cuts searches meant to stop
+				// at a playout count
+				b.stats.Cutoff = ctx.Err() != nil || b.config.Playouts > 0
+				break
+			}
+		}
+
+		state := *board
+		node := b.selectAndExpand(root, &state)
+		winner := node.winner
+		if !node.terminal {
+			winner = b.rollout(&state, getOpponent(node.player))
+		}
+		node.backpropagate(winner)
+		b.stats.Nodes++
+	}
+	return root
+}
+
+// selectAndExpand descends by UCT to a node with untried moves, adds one of
+// them to the tree and returns it, playing the path's moves on state
+func (b *mctsBot) selectAndExpand(node *mctsNode, state *models.Board) *mctsNode {
+	for !node.terminal && len(node.untried) == 0 {
+		node = node.bestChild(b.config.Exploration)
+		_ = state.MakeMove(node.column, node.player)
+	}
+	if node.terminal {
+		return node
+	}
+
+	i := b.rng.Intn(len(node.untried))
+	column := node.untried[i]
+	node.untried[i] = node.untried[len(node.untried)-1]
+	node.untried = node.untried[:len(node.untried)-1]
+
+	player := getOpponent(node.player)
+	row := state.Height[column]
+	_ = state.MakeMove(column, player)
+
+	child := &mctsNode{parent: node, column: column, player: player}
+	if connectsFour(state, row, column, player) {
+		child.terminal = true
+		child.winner = &player
+	} else if state.IsFull() {
+		child.terminal = true
+	} else {
+		child.untried = validColumns(state)
+	}
+	node.children = append(node.children, child)
+	return child
+}
+
+// principalVariation follows the most visited children from n. The root
+// always has children, so its line is never empty.
+func (n *mctsNode) principalVariation() []int {
+	var line []int
+	for len(n.children) > 0 {
+		best := n.children[0]
+		for _, child := range n.children[1:] {
+			if child.visits > best.visits {
+				best = child
+			}
+		}
+		line = append(line, best.column)
+		n = best
+	}
+	return line
+}
+
+// bestChild returns the child with the highest UCT value
+func (n *mctsNode) bestChild(exploration float64) *mctsNode {
+	logVisits := math.Log(float64(n.visits))
+	var best *mctsNode
+	bestValue := math.Inf(-1)
+	for _, child := range n.children {
+		value := child.wins/float64(child.visits) + exploration*math.Sqrt(logVisits/float64(child.visits))
+		if value > bestValue {
+			best, bestValue = child, value
+		}
+	}
+	return best
+}
+
+// backpropagate records a playout's result from n up to the root
+func (n *mctsNode) backpropagate(winner *models.PlayerColor) {
+	for node := n; node != nil; node = node.parent {
+		node.visits++
+		switch {
+		case winner == nil:
+			node.wins += 0.5
+		case *winner == node.player:
+			node.wins++
+		}
+	}
+}
+
+// rollout plays state to the end starting with player and returns the
+// winner, or nil for a draw
+func (b *mctsBot) rollout(state *models.Board, player models.PlayerColor) *models.PlayerColor {
+	for {
+		moves := validColumns(state)
+		if len(moves) == 0 {
+			return nil
+		}
+
+		column := b.rolloutMove(state, player, moves)
+		row := state.Height[column]
+		_ = state.MakeMove(column, player)
+		if connectsFour(state, row, column, player) {
+			return &player
+		}
+		player = getOpponent(player)
+	}
+}
+
+// rolloutMove picks a playout move, at random or guided by the heuristic
+func (b *mctsBot) rolloutMove(state *models.Board, player models.PlayerColor, moves []int) int {
+	if !b.config.HeuristicRollouts {
+		return moves[b.rng.Intn(len(moves))]
+	}
+
+	if move := winningColumn(state, moves, player); move != -1 {
+		return move
+	}
+	if move := winningColumn(state, moves, getOpponent(player)); move != -1 {
+		return move
+	}
+	if b.rng.Float64() >= mctsHeuristicGreed {
+		return moves[b.rng.Intn(len(moves))]
+	}
+
+	best, bestScore := moves[0], math.MinInt
+	for _, column := range moves {
+		next := *state
+		_ = next.MakeMove(column, player)
+		if score := b.heuristic.EvaluatePosition(&next, player); score > bestScore {
+			best, bestScore = column, score
+		}
+	}
+	return best
+}
+
+// EvaluatePosition returns the heuristic evaluation used to guide rollouts
+func (b *mctsBot) EvaluatePosition(board *models.Board, player models.PlayerColor) int {
+	return b.heuristic.EvaluatePosition(board, player)
+}
+
+// FindWinningMove returns a winning move if one exists, -1 otherwise
+func (b *mctsBot) FindWinningMove(board *models.Board, player models.PlayerColor) int {
+	return b.heuristic.FindWinningMove(board, player)
+}
+
+// FindBlockingMove returns a move that blocks opponent's win, -1 otherwise
+func (b *mctsBot) FindBlockingMove(board *models.Board, player models.PlayerColor) int {
+	return b.heuristic.FindBlockingMove(board, player)
+}
+
+// LastSearchStats reports the playouts of the last search as nodes and the
+// length of its most visited line as depth
+func (b *mctsBot) LastSearchStats() SearchStats {
+	return b.stats
+}
+
+// validColumns returns the playable columns of board
+func validColumns(board *models.Board) []int {
+	moves := make([]int, 0, len(board.Height))
+	for col := range board.Height {
+		if board.IsValidMove(col) {
+			moves = append(moves, col)
+		}
+	}
+	return moves
+}
+
+// winningColumn returns the first of moves that wins for player, or -1
+func winningColumn(board *models.Board, moves []int, player models.PlayerColor) int {
+	for _, column := range moves {
+		row := board.Height[column]
+		next := *board
+		_ = next.MakeMove(column, player)
+		if connectsFour(&next, row, column, player) {
+			return column
+		}
+	}
+	return -1
+}
+
+// connectsFour reports whether the disc player has at row, column is part of
+// four in a row. It only looks through that cell, which is much cheaper than
+// Board.CheckWin during playouts.
+func connectsFour(board *models.Board, row, column int, player models.PlayerColor) bool {
+	for _, dir := range [4][2]int{{0, 1}, {1, 0}, {1, 1}, {1, -1}} {
+		count := 1
+		for _, sign := range [2]int{1, -1} {
+			r, c := row+sign*dir[0], column+sign*dir[1]
+			for r >= 0 && r < len(board.Grid) && c >= 0 && c < len(board.Grid[r]) && board.Grid[r][c] == player {
+				count++
+				r, c = r+sign*dir[0], c+sign*dir[1]
+			}
+		}
+		if count >= 4 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package bot
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"connect4-multiplayer/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAlgorithm(t *testing.T) {
+	for name, want := range map[string]Algorithm{"": AlgorithmMinimax, "minimax": AlgorithmMinimax, " MCTS ": AlgorithmMCTS} {
+		algorithm, err := ParseAlgorithm(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, want, algorithm, name)
+	}
+
+	_, err := ParseAlgorithm("alphazero")
+	assert.Error(t, err)
+}
+
+func TestMCTS_TakesWinAndBlocks(t *testing.T) {
+	bot := NewMCTSBot(MCTSConfig{Playouts: 100, Seed: 1})
+
+	board := models.NewBoard()
+	for _, col := range []int{0, 1, 2} {
+		require.NoError(t, board.MakeMove(col, models.PlayerColorRed))
+	}
+	move, err := bot.GetBestMoveWithTimeout(context.Background(), &board, models.PlayerColorRed, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 3, move, "should take the win")
+
+	move, err = bot.GetBestMoveWithTimeout(context.Background(), &board, models.PlayerColorYellow, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 3, move, "should block the win")
+}
+
+func TestMCTS_FindsDoubleThreat(t *testing.T) {
+	// Red to move on R R in the bottom centre: playing 1 or 4 makes an open
+	// three that cannot be stopped
+	board := models.NewBoard()
+	for _, move := range []struct {
+		col   int
+		color models.PlayerColor
+	}{{2, models.PlayerColorRed}, {2, models.PlayerColorYellow}, {3, models.PlayerColorRed}, {3, models.PlayerColorYellow}} {
+		require.NoError(t, board.MakeMove(move.col, move.color))
+	}
+
+	for _, heuristic := range []bool{false, true} {
+		bot := NewMCTSBot(MCTSConfig{Playouts: 4000, HeuristicRollouts: heuristic, Seed: 7})
+		move, err := bot.GetBestMoveWithTimeout(context.Background(), &board, models.PlayerColorRed, 10*time.Second)
+		require.NoError(t, err)
+		assert.Contains(t, []int{1, 4}, move, "heuristic rollouts: %v", heuristic)
+	}
+}
+
+func TestMCTS_RespectsLimits(t *testing.T) {
+	board := models.NewBoard()
+
+	bot := NewMCTSBot(MCTSConfig{Playouts: 300, Seed: 1})
+	move, err := bot.GetBestMoveWithTimeout(context.Background(), &board, models.PlayerColorRed, 10*time.Second)
+	require.NoError(t, err)
+	assert.True(t, board.IsValidMove(move))
+	stats := bot.(SearchStatsProvider).LastSearchStats()
+	assert.Equal(t, uint64(300), stats.Nodes)
+	require.NotEmpty(t, stats.PrincipalVariation)
+	assert.Equal(t, move, stats.PrincipalVariation[0])
+	assert.Equal(t, len(stats.PrincipalVariation), stats.Depth)
+	assert.False(t, stats.Cutoff, "a playout limit is not a deadline")
+
+	bot = NewMCTSBot(MCTSConfig{TimeBudget: 50 * time.Millisecond, Seed: 1})
+	start := time.Now()
+	_, err = bot.GetBestMoveWithTimeout(context.Background(), &board, models.PlayerColorRed, 10*time.Second)
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	move, err = NewMCTSBot(MCTSConfig{Seed: 1}).GetBestMoveWithTimeout(ctx, &board, models.PlayerColorRed, 10*time.Second)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.True(t, board.IsValidMove(move), "a cancelled search still returns a playable move")
+}
+
+func TestMCTS_IsReproducibleWithSeed(t *testing.T) {
+	board := models.NewBoard()
+	require.NoError(t, board.MakeMove(3, models.PlayerColorRed))
+
+	var moves []int
+	for i := 0; i < 2; i++ {
+		bot := NewMCTSBot(MCTSConfig{Playouts: 500, Seed: 42})
+		moves = append(moves, bot.GetBestMove(&board, models.PlayerColorYellow, 0))
+	}
+	assert.Equal(t, moves[0], moves[1])
+}
+
+func TestConnectsFourMatchesCheckWin(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for game := 0; game < 200; game++ {
+		board := models.NewBoard()
+		player := models.PlayerColorRed
+		for {
+			moves := validColumns(&board)
+			if len(moves) == 0 {
+				break
+			}
+			col := moves[rng.Intn(len(moves))]
+			row := board.Height[col]
+			require.NoError(t, board.MakeMove(col, player))
+
+			won := connectsFour(&board, row, col, player)
+			assert.Equal(t, board.CheckWin() != nil, won)
+			if won {
+				break
+			}
+			player = getOpponent(player)
+		}
+	}
+}
+
+func TestCreateBotWithAlgorithm(t *testing.T) {
+	service := NewBotPlayerServiceWithSeed(1)
+
+	player := service.CreateBotWithAlgorithm(DifficultyHard, AlgorithmMCTS)
+	assert.Equal(t, AlgorithmMCTS, player.Algorithm)
+	assert.IsType(t, &mctsBot{}, player.AI)
+	assert.Equal(t, AlgorithmMCTS, AlgorithmFromUsername(player.Username))
+	assert.True(t, IsBotUsername(player.Username))
+	assert.NotEqual(t, player.Username, service.CreateBotWithAlgorithm(DifficultyHard, AlgorithmMCTS).Username,
+		"each game's bot has its own username")
+
+	player = service.CreateBot(DifficultyHard)
+	assert.Equal(t, AlgorithmMinimax, player.Algorithm)
+	assert.Equal(t, AlgorithmMinimax, AlgorithmFromUsername(player.Username))
+
+	assert.Equal(t, AlgorithmMCTS, AlgorithmFromUsername(AlgorithmUsername(AlgorithmMCTS)))
+
+	board := models.NewBoard()
+	move, err := service.GetBotMove(context.Background(), service.CreateBotWithAlgorithm(DifficultyEasy, AlgorithmMCTS), &board, models.PlayerColorRed)
+	require.NoError(t, err)
+	assert.True(t, board.IsValidMove(move))
+}
@@ -10,22 +10,96 @@ import (
 
 const (
 	// Scoring constants for position evaluation
-	scoreWin         = 100000
-	scoreLose        = -100000
-	scoreThreeInRow  = 100
-	scoreTwoInRow    = 10
-	scoreCenterBonus = 3
+	scoreWin          = 100000
+	scoreLose         = -100000
+	scoreThreeInRow   = 100
+	scoreTwoInRow     = 10
+	scoreCenterBonus  = 3
+	scoreParityThreat = 50
 )
 
+// EvaluationWeights are the points the heuristic evaluation gives to board
+// features. A completed four always scores as a win.
+type EvaluationWeights struct {
+	ThreeInRow  int `json:"threeInRow"`  // three discs and an empty cell in a window of four
+	TwoInRow    int `json:"twoInRow"`    // two discs and two empty cells in a window of four
+	CenterBonus int `json:"centerBonus"` // each disc in the center column, half for its neighbours
+	// ParityThreat is each threat above the playable cell on a row that
+	// suits its owner, which tends to win once the board fills up
+	ParityThreat int `json:"parityThreat"`
+}
+
+// BuiltinEvaluationWeights returns the hand-picked weights the bots play
+// with unless a tuned weights file is installed with UseEvaluationWeights
+func BuiltinEvaluationWeights() EvaluationWeights {
+	return EvaluationWeights{
+		ThreeInRow:   scoreThreeInRow,
+		TwoInRow:     scoreTwoInRow,
+		CenterBonus:  scoreCenterBonus,
+		ParityThreat: scoreParityThreat,
+	}
+}
+
+// maxSearchDepth is the deepest iterative deepening goes when no limit is set
+const maxSearchDepth = 7
+
+// minimaxDeadlineInterval is how many nodes are searched between deadline checks
+const minimaxDeadlineInterval = 1 << 10
+
+// minimaxOrder searches center columns first for better pruning
+var minimaxOrder = [boardWidth]int{3, 2, 4, 1, 5, 0, 6}
+
 // minimaxBot implements the BotAI interface using minimax with alpha-beta pruning
 type minimaxBot struct {
-	transpositionTable map[string]int // Cache for evaluated positions
+	table     *transpositionTable // Cache of search results, nil disables it
+	tableSize int                 // Entries allocated for the table on first use
+	maxDepth  int                 // Iterative deepening stops at this depth
+	weights   EvaluationWeights   // Heuristic evaluation weights
+	nodes     uint64              // Positions searched by the current search
+	ttProbes  uint64              // Table lookups by the current search
+	ttHits    uint64              // Table lookups that found the position
+	stopped   bool                // The deadline passed during the current search
+	stats     SearchStats         // Statistics of the last completed search
+
+	// Set by the caller of startSearch before finishSearch records them
+	started time.Time // When the current search started
+	reached int       // Deepest completed depth of the current search
+	cutoff  bool      // The current search stopped short of its full depth
+	pv      []int     // Expected line of the current search
+
+	ctx            context.Context // Cancels the current search, nil if it cannot be cancelled
+	sequential     bool            // Never borrow helpers, for background work
+	helpers        int             // Extra goroutines the current search may use
+	releaseHelpers func()          // Returns the helpers to the shared pool
 }
 
 // NewMinimaxBot creates a new minimax bot instance
 func NewMinimaxBot() BotAI {
+	return NewMinimaxBotWithDepth(maxSearchDepth)
+}
+
+// NewMinimaxBotWithDepth creates a minimax bot that never searches deeper
+// than maxDepth plies, which is how difficulty levels weaken the bot
+func NewMinimaxBotWithDepth(maxDepth int) BotAI {
+	return NewMinimaxBotWithWeights(maxDepth, DefaultEvaluationWeights())
+}
+
+// NewMinimaxBotWithWeights creates a minimax bot that evaluates positions
+// with the given weights, for comparing evaluation tweaks
+func NewMinimaxBotWithWeights(maxDepth int, weights EvaluationWeights) BotAI {
+	return newMinimaxBot(maxDepth, weights, defaultMinimaxTableSize)
+}
+
+// newMinimaxBot creates a minimax bot with a transposition table of
+// tableSize entries, a power of two, or none if tableSize is 0
+func newMinimaxBot(maxDepth int, weights EvaluationWeights, tableSize int) *minimaxBot {
+	if maxDepth <= 0 || maxDepth > maxSearchDepth {
+		maxDepth = maxSearchDepth
+	}
 	return &minimaxBot{
-		transpositionTable: make(map[string]int),
+		tableSize: tableSize,
+		maxDepth:  maxDepth,
+		weights:   weights,
 	}
 }
 
@@ -34,6 +108,7 @@ func (b *minimaxBot) GetBestMove(board *models.Board, player models.PlayerColor,
 	// First, check for immediate winning move
 	winMove := b.FindWinningMove(board, player)
 	if winMove != -1 {
+		b.stats = SearchStats{}
 		return winMove
 	}
 
@@ -41,56 +116,34 @@ func (b *minimaxBot) GetBestMove(board *models.Board, player models.PlayerColor,
 	opponent := getOpponent(player)
 	blockMove := b.FindWinningMove(board, opponent)
 	if blockMove != -1 {
+		b.stats = SearchStats{}
 		return blockMove
 	}
 
 	// Use minimax with alpha-beta pruning for strategic move
-	bestMove := 3 // Default to center column
-	bestScore := math.MinInt32
-
-	// Order moves: center columns first for better pruning
-	moveOrder := []int{3, 2, 4, 1, 5, 0, 6}
-
-	for _, col := range moveOrder {
-		if !board.IsValidMove(col) {
-			continue
-		}
-
-		// Make move on a copy of the board
-		boardCopy := copyBoard(board)
-		if err := boardCopy.MakeMove(col, player); err != nil {
-			continue // Skip invalid moves
-		}
-
-		// Evaluate using minimax
-		score := b.minimax(boardCopy, depth-1, math.MinInt32, math.MaxInt32, false, player)
-
-		if score > bestScore {
-			bestScore = score
-			bestMove = col
-		}
-	}
-
-	return bestMove
+	b.startSearch(nil, depth)
+	defer b.finishSearch()
+	move := b.getBestMoveWithDeadline(board, player, depth, time.Time{})
+	b.reached, b.pv = depth, b.principalVariation(board, player, move, depth)
+	return move
 }
 
 // GetBestMoveWithTimeout returns the best move within the time limit using iterative deepening
 func (b *minimaxBot) GetBestMoveWithTimeout(ctx context.Context, board *models.Board, player models.PlayerColor, timeout time.Duration) (int, error) {
-	// Clear transposition table for new search
-	b.transpositionTable = make(map[string]int)
-
 	deadline := time.Now().Add(timeout)
 	bestMove := 3 // Default to center
 
 	// First check for immediate winning/blocking moves (these are fast)
 	winMove := b.FindWinningMove(board, player)
 	if winMove != -1 {
+		b.stats = SearchStats{}
 		return winMove, nil
 	}
 
 	opponent := getOpponent(player)
 	blockMove := b.FindWinningMove(board, opponent)
 	if blockMove != -1 {
+		b.stats = SearchStats{}
 		return blockMove, nil
 	}
 
@@ -102,13 +155,19 @@ func (b *minimaxBot) GetBestMoveWithTimeout(ctx context.Context, board *models.B
 		}
 	}
 
-	// Iterative deepening: start shallow, go deeper if time permits
-	for depth := 1; depth <= 7; depth++ {
+	b.startSearch(ctx, b.maxDepth)
+	defer b.finishSearch()
+
+	// Iterative deepening: start shallow, go deeper if time permits. Each
+	// depth searches the previous depth's best moves first.
+	for depth := 1; depth <= b.maxDepth; depth++ {
 		select {
 		case <-ctx.Done():
+			b.cutoff = true
 			return bestMove, ctx.Err()
 		default:
 			if time.Now().After(deadline) {
+				b.cutoff = true
 				return bestMove, nil
 			}
 
@@ -117,9 +176,13 @@ func (b *minimaxBot) GetBestMoveWithTimeout(ctx context.Context, board *models.B
 			if board.IsValidMove(move) {
 				bestMove = move
 			}
+			if !b.stopped {
+				b.reached, b.pv = depth, b.principalVariation(board, player, bestMove, depth)
+			}
 
 			// Check time after each depth
 			if time.Now().After(deadline) {
+				b.cutoff = b.reached < b.maxDepth
 				return bestMove, nil
 			}
 		}
@@ -128,123 +191,165 @@ func (b *minimaxBot) GetBestMoveWithTimeout(ctx context.Context, board *models.B
 	return bestMove, nil
 }
 
-// getBestMoveWithDeadline performs minimax search with a deadline check
-func (b *minimaxBot) getBestMoveWithDeadline(board *models.Board, player models.PlayerColor, depth int, deadline time.Time) int {
-	bestMove := 3 // Default to center column
-	bestScore := math.MinInt32
+// ScoreMoves scores every column with iterative deepening and returns the
+// scores of the deepest search that finished before the time limit
+func (b *minimaxBot) ScoreMoves(ctx context.Context, board *models.Board, player models.PlayerColor, timeout time.Duration) ([]ColumnScore, error) {
+	deadline := time.Now().Add(timeout)
 
-	// Order moves: center columns first for better pruning
-	moveOrder := []int{3, 2, 4, 1, 5, 0, 6}
+	b.startSearch(ctx, b.maxDepth)
+	defer b.finishSearch()
 
-	for _, col := range moveOrder {
-		if time.Now().After(deadline) {
-			break
-		}
+	scores, reached := b.scoreMovesIteratively(board, player, deadline)
+	b.reached, b.cutoff = reached, reached < b.maxDepth
+	if best := bestColumn(scores); best != -1 {
+		b.pv = b.principalVariation(board, player, best, reached)
+	}
+	return scores, ctx.Err()
+}
 
-		if !board.IsValidMove(col) {
-			continue
-		}
+// scoreMovesIteratively scores every column at increasing depths until the
+// deadline or cancellation, and returns the scores of the deepest search
+// that completed along with its depth
+func (b *minimaxBot) scoreMovesIteratively(board *models.Board, player models.PlayerColor, deadline time.Time) ([]ColumnScore, int) {
+	// A one-ply search is only seven evaluations, so there is always a result
+	scores, _ := b.scoreMovesWithDeadline(board, player, 1, time.Time{})
+	reached := 1
 
-		// Make move on a copy of the board
-		boardCopy := copyBoard(board)
-		if err := boardCopy.MakeMove(col, player); err != nil {
-			continue // Skip invalid moves
+	for depth := 2; depth <= b.maxDepth; depth++ {
+		if b.ctx != nil && b.ctx.Err() != nil {
+			break
 		}
-
-		// Evaluate using minimax
-		score := b.minimaxWithDeadline(boardCopy, depth-1, math.MinInt32, math.MaxInt32, false, player, deadline)
-
-		if score > bestScore {
-			bestScore = score
-			bestMove = col
+		current, complete := b.scoreMovesWithDeadline(board, player, depth, deadline)
+		if !complete {
+			break
 		}
+		scores, reached = current, depth
 	}
 
-	return bestMove
+	return scores, reached
 }
 
-// minimaxWithDeadline implements minimax with deadline checking
-func (b *minimaxBot) minimaxWithDeadline(board *models.Board, depth int, alpha, beta int, isMaximizing bool, botPlayer models.PlayerColor, deadline time.Time) int {
-	// Check deadline
-	if time.Now().After(deadline) {
-		return b.EvaluatePosition(board, botPlayer)
+// LastSearchStats reports the work done by the last search
+func (b *minimaxBot) LastSearchStats() SearchStats {
+	return b.stats
+}
+
+// startSearch prepares the transposition table and counters for a search
+// up to depth plies and borrows helper goroutines if it is deep enough to
+// split. A nil ctx cannot be cancelled.
+func (b *minimaxBot) startSearch(ctx context.Context, depth int) {
+	if b.table == nil && b.tableSize > 0 {
+		b.table = newTranspositionTable(b.tableSize)
+	}
+	if b.table != nil {
+		b.table.newSearch()
 	}
+	b.nodes, b.ttProbes, b.ttHits = 0, 0, 0
+	b.started, b.reached, b.cutoff, b.pv = time.Now(), 0, false, nil
+	b.ctx = ctx
 
-	// Check for terminal states
-	winner := board.CheckWin()
-	if winner != nil {
-		if *winner == botPlayer {
-			return scoreWin + depth // Prefer faster wins
-		}
-		return scoreLose - depth // Prefer slower losses
+	b.helpers, b.releaseHelpers = 0, func() {}
+	if depth >= parallelMinDepth && !b.sequential {
+		// Splitting the root never keeps more than one goroutine per move busy
+		b.helpers, b.releaseHelpers = acquireHelpers(boardWidth - 1)
 	}
+}
 
-	if board.IsFull() {
-		return 0 // Draw
+func (b *minimaxBot) finishSearch() {
+	b.releaseHelpers()
+	b.helpers = 0
+	b.ctx = nil
+	b.stats = SearchStats{
+		Nodes:              b.nodes,
+		Depth:              b.reached,
+		TTProbes:           b.ttProbes,
+		TTHits:             b.ttHits,
+		Duration:           time.Since(b.started),
+		PrincipalVariation: b.pv,
+		Cutoff:             b.cutoff,
 	}
+}
 
-	if depth == 0 {
-		return b.EvaluatePosition(board, botPlayer)
+// helper creates a bot that searches alongside b, sharing its table
+func (b *minimaxBot) helper() *minimaxBot {
+	return &minimaxBot{
+		table:    b.table,
+		maxDepth: b.maxDepth,
+		weights:  b.weights,
+		ctx:      b.ctx,
 	}
+}
 
-	moveOrder := []int{3, 2, 4, 1, 5, 0, 6}
+// scoreMovesWithDeadline scores each column with a full-window search so
+// that every score is exact at the given depth. It reports false if the
+// deadline cut the search short.
+func (b *minimaxBot) scoreMovesWithDeadline(board *models.Board, player models.PlayerColor, depth int, deadline time.Time) ([]ColumnScore, bool) {
+	b.stopped = false
+	values, searched := b.searchRootMoves(board, player, depth, minimaxOrder, deadline, true)
 
-	if isMaximizing {
-		maxScore := math.MinInt32
-		for _, col := range moveOrder {
-			if time.Now().After(deadline) {
-				break
-			}
+	scores := make([]ColumnScore, 7)
+	for col := 0; col < 7; col++ {
+		scores[col] = ColumnScore{Column: col, Playable: searched[col], Score: values[col]}
+	}
 
-			if !board.IsValidMove(col) {
-				continue
-			}
+	for col := 0; col < 7; col++ {
+		if board.IsValidMove(col) && !searched[col] {
+			return scores, false
+		}
+	}
+	return scores, !b.stopped && (deadline.IsZero() || !time.Now().After(deadline))
+}
 
-			boardCopy := copyBoard(board)
-			if err := boardCopy.MakeMove(col, botPlayer); err != nil {
-				continue // Skip invalid moves
-			}
+// getBestMoveWithDeadline performs minimax search with a deadline check. A
+// zero deadline searches without a time limit.
+func (b *minimaxBot) getBestMoveWithDeadline(board *models.Board, player models.PlayerColor, depth int, deadline time.Time) int {
+	b.stopped = false
+	hash := zobristHash(board, player)
 
-			score := b.minimaxWithDeadline(boardCopy, depth-1, alpha, beta, false, botPlayer, deadline)
-			maxScore = max(maxScore, score)
-			alpha = max(alpha, score)
+	ttMove := -1
+	if b.table != nil {
+		if entry, ok := b.table.probe(hash); ok {
+			ttMove = int(entry.move)
+		}
+	}
 
-			if beta <= alpha {
-				break // Beta cutoff
-			}
+	// Ties go to the move searched first
+	order := moveOrder(ttMove)
+	scores, searched := b.searchRootMoves(board, player, depth, order, deadline, false)
+
+	bestMove := 3 // Default to center column
+	bestScore := math.MinInt32
+	for _, col := range order {
+		if searched[col] && scores[col] > bestScore {
+			bestScore = scores[col]
+			bestMove = col
 		}
-		return maxScore
-	} else {
-		minScore := math.MaxInt32
-		opponent := getOpponent(botPlayer)
-		for _, col := range moveOrder {
-			if time.Now().After(deadline) {
-				break
-			}
+	}
 
-			if !board.IsValidMove(col) {
-				continue
-			}
+	if b.table != nil && !b.stopped {
+		b.table.store(hash, depth, bestScore, boundExact, bestMove)
+	}
 
-			boardCopy := copyBoard(board)
-			if err := boardCopy.MakeMove(col, opponent); err != nil {
-				continue // Skip invalid moves
-			}
+	return bestMove
+}
 
-			score := b.minimaxWithDeadline(boardCopy, depth-1, alpha, beta, true, botPlayer, deadline)
-			minScore = min(minScore, score)
-			beta = min(beta, score)
+// search implements minimax with alpha-beta pruning from botPlayer's point
+// of view. hash is the Zobrist hash of board; results are cached in the
+// transposition table and its best move is searched first. Once the
+// deadline passes, positions are evaluated statically and nothing more is
+// cached.
+func (b *minimaxBot) search(board *models.Board, hash uint64, depth int, alpha, beta int, isMaximizing bool, botPlayer models.PlayerColor, deadline time.Time) int {
+	b.nodes++
 
-			if beta <= alpha {
-				break // Alpha cutoff
-			}
-		}
-		return minScore
+	// Check deadline and cancellation
+	if !b.stopped && b.nodes%minimaxDeadlineInterval == 0 &&
+		((!deadline.IsZero() && time.Now().After(deadline)) || (b.ctx != nil && b.ctx.Err() != nil)) {
+		b.stopped = true
+	}
+	if b.stopped {
+		return b.EvaluatePosition(board, botPlayer)
 	}
-}
 
-// minimax implements the minimax algorithm with alpha-beta pruning
-func (b *minimaxBot) minimax(board *models.Board, depth int, alpha, beta int, isMaximizing bool, botPlayer models.PlayerColor) int {
 	// Check for terminal states
 	winner := board.CheckWin()
 	if winner != nil {
@@ -258,56 +363,108 @@ func (b *minimaxBot) minimax(board *models.Board, depth int, alpha, beta int, is
 		return 0 // Draw
 	}
 
+	ttMove := -1
+	if b.table != nil {
+		b.ttProbes++
+		if entry, ok := b.table.probe(hash); ok {
+			b.ttHits++
+			ttMove = int(entry.move)
+			if int(entry.depth) >= depth {
+				score := int(entry.score)
+				switch entry.bound {
+				case boundExact:
+					return score
+				case boundLower:
+					alpha = max(alpha, score)
+				case boundUpper:
+					beta = min(beta, score)
+				}
+				if beta <= alpha {
+					return score
+				}
+			}
+		}
+	}
+
 	if depth == 0 {
-		return b.EvaluatePosition(board, botPlayer)
+		score := b.EvaluatePosition(board, botPlayer)
+		if b.table != nil {
+			b.table.store(hash, 0, score, boundExact, -1)
+		}
+		return score
 	}
 
-	moveOrder := []int{3, 2, 4, 1, 5, 0, 6}
+	alphaOrig, betaOrig := alpha, beta
+	mover := botPlayer
+	bestScore := math.MinInt32
+	if !isMaximizing {
+		mover = getOpponent(botPlayer)
+		bestScore = math.MaxInt32
+	}
+	bestMove := -1
 
-	if isMaximizing {
-		maxScore := math.MinInt32
-		for _, col := range moveOrder {
-			if !board.IsValidMove(col) {
-				continue
-			}
+	for _, col := range moveOrder(ttMove) {
+		if b.stopped {
+			break
+		}
 
-			boardCopy := copyBoard(board)
-			if err := boardCopy.MakeMove(col, botPlayer); err != nil {
-				continue // Skip invalid moves
-			}
+		if !board.IsValidMove(col) {
+			continue
+		}
 
-			score := b.minimax(boardCopy, depth-1, alpha, beta, false, botPlayer)
-			maxScore = max(maxScore, score)
-			alpha = max(alpha, score)
+		row := board.Height[col]
+		boardCopy := copyBoard(board)
+		if err := boardCopy.MakeMove(col, mover); err != nil {
+			continue // Skip invalid moves
+		}
+
+		score := b.search(boardCopy, hash^zobristKey(row, col, mover), depth-1, alpha, beta, !isMaximizing, botPlayer, deadline)
 
-			if beta <= alpha {
-				break // Beta cutoff
+		if isMaximizing {
+			if score > bestScore {
+				bestScore, bestMove = score, col
 			}
-		}
-		return maxScore
-	} else {
-		minScore := math.MaxInt32
-		opponent := getOpponent(botPlayer)
-		for _, col := range moveOrder {
-			if !board.IsValidMove(col) {
-				continue
+			alpha = max(alpha, score)
+		} else {
+			if score < bestScore {
+				bestScore, bestMove = score, col
 			}
+			beta = min(beta, score)
+		}
 
-			boardCopy := copyBoard(board)
-			if err := boardCopy.MakeMove(col, opponent); err != nil {
-				continue // Skip invalid moves
-			}
+		if beta <= alpha {
+			break // Cutoff
+		}
+	}
 
-			score := b.minimax(boardCopy, depth-1, alpha, beta, true, botPlayer)
-			minScore = min(minScore, score)
-			beta = min(beta, score)
+	if b.table != nil && !b.stopped {
+		bound := boundExact
+		if bestScore <= alphaOrig {
+			bound = boundUpper
+		} else if bestScore >= betaOrig {
+			bound = boundLower
+		}
+		b.table.store(hash, depth, bestScore, bound, bestMove)
+	}
 
-			if beta <= alpha {
-				break // Alpha cutoff
-			}
+	return bestScore
+}
+
+// moveOrder returns the columns in search order, first followed by the
+// rest from the center out. A negative first keeps the center-out order.
+func moveOrder(first int) [boardWidth]int {
+	if first < 0 || first >= boardWidth {
+		return minimaxOrder
+	}
+	order := [boardWidth]int{first}
+	i := 1
+	for _, col := range minimaxOrder {
+		if col != first {
+			order[i] = col
+			i++
 		}
-		return minScore
 	}
+	return order
 }
 
 // EvaluatePosition evaluates the board position for the given player
@@ -321,6 +478,11 @@ func (b *minimaxBot) EvaluatePosition(board *models.Board, player models.PlayerC
 	// Center column bonus
 	score += b.evaluateCenterControl(board, player)
 
+	// Odd/even threats that decide the game when the board fills up
+	if b.weights.ParityThreat != 0 {
+		score += b.weights.ParityThreat * parityThreats(board, player)
+	}
+
 	return score
 }
 
@@ -410,10 +572,10 @@ func (b *minimaxBot) evaluateWindow(window []models.PlayerColor, player, opponen
 		return scoreWin
 	}
 	if playerCount == 3 && emptyCount == 1 {
-		return scoreThreeInRow
+		return b.weights.ThreeInRow
 	}
 	if playerCount == 2 && emptyCount == 2 {
-		return scoreTwoInRow
+		return b.weights.TwoInRow
 	}
 
 	// Penalize opponent threats
@@ -421,10 +583,10 @@ func (b *minimaxBot) evaluateWindow(window []models.PlayerColor, player, opponen
 		return scoreLose
 	}
 	if opponentCount == 3 && emptyCount == 1 {
-		return -scoreThreeInRow
+		return -b.weights.ThreeInRow
 	}
 	if opponentCount == 2 && emptyCount == 2 {
-		return -scoreTwoInRow
+		return -b.weights.TwoInRow
 	}
 
 	return 0
@@ -437,17 +599,17 @@ func (b *minimaxBot) evaluateCenterControl(board *models.Board, player models.Pl
 
 	for row := 0; row < 6; row++ {
 		if board.Grid[row][centerCol] == player {
-			score += scoreCenterBonus
+			score += b.weights.CenterBonus
 		}
 	}
 
 	// Also give smaller bonus for adjacent center columns
 	for row := 0; row < 6; row++ {
 		if board.Grid[row][2] == player {
-			score += scoreCenterBonus / 2
+			score += b.weights.CenterBonus / 2
 		}
 		if board.Grid[row][4] == player {
-			score += scoreCenterBonus / 2
+			score += b.weights.CenterBonus / 2
 		}
 	}
 
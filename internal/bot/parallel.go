@@ -0,0 +1,169 @@
+package bot
+
+import (
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+// parallelMinDepth is the shallowest search worth splitting across
+// goroutines; shallower ones finish in well under a millisecond
+const parallelMinDepth = 4
+
+// searchHelpers is the pool of helper goroutine slots shared by all minimax
+// searches. Each search keeps its own goroutine and borrows whatever free
+// slots it can, so concurrent bot games never use more than the configured
+// workers plus one goroutine per search.
+var (
+	searchHelpersMu sync.RWMutex
+	searchHelpers   = newHelperPool(DefaultSearchWorkers())
+)
+
+// DefaultSearchWorkers leaves half the CPUs for serving players
+func DefaultSearchWorkers() int {
+	return max(1, runtime.NumCPU()/2)
+}
+
+// SetSearchWorkers sets how many goroutines a minimax search may use,
+// including its own; 1 disables parallel search and values below 1 use
+// DefaultSearchWorkers. It is meant to be called once at startup.
+func SetSearchWorkers(workers int) {
+	if workers < 1 {
+		workers = DefaultSearchWorkers()
+	}
+	pool := newHelperPool(workers)
+
+	searchHelpersMu.Lock()
+	searchHelpers = pool
+	searchHelpersMu.Unlock()
+}
+
+// helperPool hands out helper goroutine slots
+type helperPool chan struct{}
+
+func newHelperPool(workers int) helperPool {
+	return make(helperPool, workers-1)
+}
+
+// acquireHelpers borrows up to want free slots without waiting and returns
+// how many it got and a function that gives them back
+func acquireHelpers(want int) (int, func()) {
+	searchHelpersMu.RLock()
+	pool := searchHelpers
+	searchHelpersMu.RUnlock()
+
+	n := 0
+	for ; n < want; n++ {
+		select {
+		case pool <- struct{}{}:
+			continue
+		default:
+		}
+		break
+	}
+
+	return n, func() {
+		for i := 0; i < n; i++ {
+			<-pool
+		}
+	}
+}
+
+// searchRootMoves searches the playable columns in order and returns their
+// scores by column and which columns were searched before the deadline.
+// Scores are exact when exact is set. Otherwise moves after the first only
+// search for scores above the best found so far minus one, which is enough
+// to find the best move and any move that ties with it, whichever worker
+// finishes first. Deep searches are split across the bot's helpers.
+func (b *minimaxBot) searchRootMoves(board *models.Board, player models.PlayerColor, depth int, order [boardWidth]int, deadline time.Time, exact bool) ([boardWidth]int, [boardWidth]bool) {
+	var (
+		scores   [boardWidth]int
+		searched [boardWidth]bool
+	)
+
+	hash := zobristHash(board, player)
+	var moves []int
+	for _, col := range order {
+		if board.IsValidMove(col) {
+			moves = append(moves, col)
+		}
+	}
+
+	// best is shared by the workers and only grows
+	var best atomic.Int64
+	best.Store(math.MinInt32)
+	searchMove := func(w *minimaxBot, col int) {
+		alpha := math.MinInt32
+		if !exact {
+			alpha = int(best.Load()) - 1
+		}
+
+		row := board.Height[col]
+		boardCopy := copyBoard(board)
+		if err := boardCopy.MakeMove(col, player); err != nil {
+			return // Skip invalid moves
+		}
+		score := w.search(boardCopy, hash^zobristKey(row, col, player), depth-1, alpha, math.MaxInt32, false, player, deadline)
+
+		scores[col], searched[col] = score, true
+		for current := best.Load(); int64(score) > current && !best.CompareAndSwap(current, int64(score)); current = best.Load() {
+		}
+	}
+
+	expired := func(w *minimaxBot) bool {
+		return w.stopped || (!deadline.IsZero() && time.Now().After(deadline))
+	}
+
+	if b.helpers == 0 || depth < parallelMinDepth || len(moves) < 2 {
+		for _, col := range moves {
+			if expired(b) {
+				break
+			}
+			searchMove(b, col)
+		}
+		return scores, searched
+	}
+
+	// The first move is usually best, so search it alone to give the other
+	// workers a tight window
+	searchMove(b, moves[0])
+
+	var (
+		next atomic.Int64
+		wg   sync.WaitGroup
+	)
+	next.Store(1)
+	work := func(w *minimaxBot) {
+		for !expired(w) {
+			i := int(next.Add(1) - 1)
+			if i >= len(moves) {
+				return
+			}
+			searchMove(w, moves[i])
+		}
+	}
+
+	helpers := make([]*minimaxBot, b.helpers)
+	for i := range helpers {
+		helpers[i] = b.helper()
+		wg.Add(1)
+		go func(w *minimaxBot) {
+			defer wg.Done()
+			work(w)
+		}(helpers[i])
+	}
+	work(b)
+	wg.Wait()
+
+	for _, w := range helpers {
+		b.nodes += w.nodes
+		b.ttProbes += w.ttProbes
+		b.ttHits += w.ttHits
+		b.stopped = b.stopped || w.stopped
+	}
+	return scores, searched
+}
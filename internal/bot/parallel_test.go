@@ -0,0 +1,105 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withSearchWorkers sets the worker count for one test
+func withSearchWorkers(t *testing.T, workers int) {
+	SetSearchWorkers(workers)
+	t.Cleanup(func() { SetSearchWorkers(0) })
+}
+
+func TestAcquireHelpers_SharesBudget(t *testing.T) {
+	withSearchWorkers(t, 4)
+
+	first, releaseFirst := acquireHelpers(2)
+	second, releaseSecond := acquireHelpers(6)
+	third, releaseThird := acquireHelpers(6)
+	assert.Equal(t, 2, first)
+	assert.Equal(t, 1, second, "only workers-1 helpers exist in total")
+	assert.Equal(t, 0, third)
+
+	releaseFirst()
+	releaseSecond()
+	releaseThird()
+	all, releaseAll := acquireHelpers(6)
+	defer releaseAll()
+	assert.Equal(t, 3, all, "released helpers are available again")
+}
+
+func TestSetSearchWorkers_OneIsSequential(t *testing.T) {
+	withSearchWorkers(t, 1)
+
+	n, release := acquireHelpers(6)
+	defer release()
+	assert.Zero(t, n)
+}
+
+// TestParallelSearch_MatchesSequential checks that splitting the search
+// picks the same moves and scores as a single goroutine
+func TestParallelSearch_MatchesSequential(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+	for i := 0; i < 15; i++ {
+		board, player := randomPosition(rng, 2+rng.Intn(20))
+
+		withSearchWorkers(t, 1)
+		sequential := NewMinimaxBotWithDepth(6)
+		wantMove := sequential.GetBestMove(&board, player, 6)
+		wantScores, err := sequential.(MoveScorer).ScoreMoves(context.Background(), &board, player, time.Minute)
+		require.NoError(t, err)
+
+		withSearchWorkers(t, 4)
+		parallel := NewMinimaxBotWithDepth(6)
+		assert.Equal(t, wantMove, parallel.GetBestMove(&board, player, 6))
+		gotScores, err := parallel.(MoveScorer).ScoreMoves(context.Background(), &board, player, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, wantScores, gotScores)
+	}
+}
+
+func TestParallelSearch_HonorsDeadlineAndContext(t *testing.T) {
+	withSearchWorkers(t, 4)
+	board, player := boardFromMoves(t, "4453")
+
+	ai := NewMinimaxBot()
+	start := time.Now()
+	move, err := ai.GetBestMoveWithTimeout(context.Background(), &board, player, 20*time.Millisecond)
+	require.NoError(t, err)
+	assert.True(t, board.IsValidMove(move))
+	assert.Less(t, time.Since(start), 200*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	move, _ = ai.GetBestMoveWithTimeout(ctx, &board, player, time.Minute)
+	assert.True(t, board.IsValidMove(move))
+	assert.Less(t, time.Since(start), 200*time.Millisecond)
+
+	// Helpers are returned once the search ends
+	n, release := acquireHelpers(6)
+	defer release()
+	assert.Equal(t, 3, n)
+}
+
+// BenchmarkMinimax_Workers measures a depth-7 move with 1, 2 and 4
+// goroutines; the speedup depends on the CPUs available
+func BenchmarkMinimax_Workers(b *testing.B) {
+	board, player := boardFromMoves(b, "443534")
+	for _, workers := range []int{1, 2, 4} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			SetSearchWorkers(workers)
+			defer SetSearchWorkers(0)
+			for i := 0; i < b.N; i++ {
+				NewMinimaxBot().GetBestMove(&board, player, maxSearchDepth)
+			}
+		})
+	}
+}
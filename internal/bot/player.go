@@ -3,6 +3,10 @@ package bot
 import (
 	"context"
 	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"connect4-multiplayer/pkg/models"
@@ -20,34 +24,76 @@ type BotPlayer struct {
 	ID         string     `json:"id"`
 	Username   string     `json:"username"`
 	Difficulty Difficulty `json:"difficulty"`
-	AI         BotAI      `json:"-"`
+	Style      PlayStyle  `json:"style"`
+	Algorithm  Algorithm  `json:"algorithm,omitempty"`
+	// Strength is the playing strength of an adaptive bot, from 0 to 1
+	Strength float64 `json:"strength,omitempty"`
+	// Engine names the external engine playing for the bot, if any
+	Engine string `json:"engine,omitempty"`
+	AI     BotAI  `json:"-"`
+	// Scores are the column scores behind the bot's last move, when its AI
+	// scores moves and the move was not forced
+	Scores []ColumnScore `json:"-"`
 }
 
 // BotPlayerService manages bot players
 type BotPlayerService interface {
 	// CreateBot creates a new bot player with the specified difficulty
 	CreateBot(difficulty Difficulty) *BotPlayer
+	// CreateBotWithAlgorithm creates a bot that searches with algorithm
+	CreateBotWithAlgorithm(difficulty Difficulty, algorithm Algorithm) *BotPlayer
+	// CreateAdaptiveBot creates a bot playing at strength, from 0 to 1
+	CreateAdaptiveBot(strength float64) *BotPlayer
+	// CreateEngineBot creates a bot played by a registered external engine
+	CreateEngineBot(name string) (*BotPlayer, error)
 	// GetBotMove gets the best move for the bot given the current board state
 	GetBotMove(ctx context.Context, bot *BotPlayer, board *models.Board, color models.PlayerColor) (int, error)
 	// IsBot checks if a username belongs to a bot
 	IsBot(username string) bool
+	// SearchMetrics returns the search statistics of bot moves so far,
+	// keyed by difficulty
+	SearchMetrics() map[string]SearchMetricsSnapshot
 }
 
 // botPlayerService implements BotPlayerService
 type botPlayerService struct {
-	botCounter int
+	botCounter atomic.Int64 // numbers bots, so each game's bot has its own username
+
+	mu  sync.Mutex
+	rng *rand.Rand // seeds the per-move random sources
+
+	metrics *SearchMetrics
 }
 
 // NewBotPlayerService creates a new bot player service
 func NewBotPlayerService() BotPlayerService {
+	return NewBotPlayerServiceWithSeed(time.Now().UnixNano())
+}
+
+// NewBotPlayerServiceWithSeed creates a bot player service whose move
+// choices and think times are reproducible for a given seed
+func NewBotPlayerServiceWithSeed(seed int64) BotPlayerService {
 	return &botPlayerService{
-		botCounter: 0,
+		rng:     rand.New(rand.NewSource(seed)),
+		metrics: NewSearchMetrics(),
 	}
 }
 
 // CreateBot creates a new bot player with the specified difficulty
 func (s *botPlayerService) CreateBot(difficulty Difficulty) *BotPlayer {
-	s.botCounter++
+	return s.CreateBotWithAlgorithm(difficulty, AlgorithmMinimax)
+}
+
+// CreateBotWithAlgorithm creates a bot player that searches with algorithm.
+// Impossible minimax bots use the solver; MCTS bots stay MCTS at every
+// difficulty, with more playouts as it rises. Adaptive minimax bots play at
+// DefaultAdaptiveStrength.
+func (s *botPlayerService) CreateBotWithAlgorithm(difficulty Difficulty, algorithm Algorithm) *BotPlayer {
+	if difficulty == DifficultyAdaptive && algorithm != AlgorithmMCTS {
+		return s.CreateAdaptiveBot(DefaultAdaptiveStrength)
+	}
+
+	id := s.botCounter.Add(1)
 	
 	difficultyName := "Medium"
 	switch difficulty {
@@ -55,17 +101,57 @@ func (s *botPlayerService) CreateBot(difficulty Difficulty) *BotPlayer {
 		difficultyName = "Easy"
 	case DifficultyHard:
 		difficultyName = "Hard"
+	case DifficultyImpossible:
+		difficultyName = "Impossible"
+	}
+
+	var ai BotAI
+	prefix := BotUsernamePrefix
+	switch {
+	case algorithm == AlgorithmMCTS:
+		ai = NewMCTSBot(difficulty.MCTSConfig())
+		prefix = AlgorithmUsername(AlgorithmMCTS) + "_"
+	case difficulty == DifficultyImpossible:
+		ai = NewSolver()
+	default:
+		algorithm = AlgorithmMinimax
+		ai = NewMinimaxBotWithDepth(difficulty.SearchDepth())
 	}
 	
 	return &BotPlayer{
-		ID:         fmt.Sprintf("bot_%d", s.botCounter),
-		Username:   fmt.Sprintf("%s%s_%d", BotUsernamePrefix, difficultyName, s.botCounter),
+		ID:         fmt.Sprintf("bot_%d", id),
+		Username:   fmt.Sprintf("%s%s_%d", prefix, difficultyName, id),
 		Difficulty: difficulty,
-		AI:         NewMinimaxBot(),
+		Style:      difficulty.PlayStyle(),
+		Algorithm:  algorithm,
+		AI:         ai,
 	}
 }
 
-// GetBotMove gets the best move for the bot given the current board state
+// CreateEngineBot creates a bot played by the registered engine with the
+// given name. Engine bots think like hard bots and always play the engine's
+// move.
+func (s *botPlayerService) CreateEngineBot(name string) (*BotPlayer, error) {
+	engine, ok := LookupEngine(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrEngineNotFound, name)
+	}
+
+	id := s.botCounter.Add(1)
+
+	return &BotPlayer{
+		ID:         fmt.Sprintf("bot_%d", id),
+		Username:   fmt.Sprintf("%s_%d", EngineUsername(name), id),
+		Difficulty: DifficultyEngine,
+		Engine:     name,
+		AI:         engine,
+	}, nil
+}
+
+// GetBotMove chooses the bot's move and waits as long as a human at the
+// bot's difficulty would. Immediate wins and blocks are always played;
+// otherwise the move is drawn from the column scores according to the bot's
+// PlayStyle, and the think time grows with the number of close alternatives.
 func (s *botPlayerService) GetBotMove(ctx context.Context, bot *BotPlayer, board *models.Board, color models.PlayerColor) (int, error) {
 	if bot == nil {
 		return -1, fmt.Errorf("bot player is nil")
@@ -74,12 +160,12 @@ func (s *botPlayerService) GetBotMove(ctx context.Context, bot *BotPlayer, board
 	if bot.AI == nil {
 		return -1, fmt.Errorf("bot AI is not initialized")
 	}
+
+	rng := s.moveRand()
+	bot.Scores = nil
 	
-	// Add human-like delay based on difficulty
-	delay := bot.Difficulty.HumanDelay()
-	
-	// Calculate timeout (must complete within 1 second total including delay)
-	timeout := DefaultBotTimeout - delay
+	// Leave room for the base think time within the 1 second budget
+	timeout := DefaultBotTimeout - bot.Difficulty.HumanDelay()
 	if timeout < 100*time.Millisecond {
 		timeout = 100 * time.Millisecond
 	}
@@ -90,16 +176,14 @@ func (s *botPlayerService) GetBotMove(ctx context.Context, bot *BotPlayer, board
 	
 	// Start timing
 	start := time.Now()
-	
-	// Get the best move
-	move, err := bot.AI.GetBestMoveWithTimeout(moveCtx, board, color, timeout)
+
+	move, complexity, err := s.chooseMove(moveCtx, bot, board, color, timeout, rng)
 	if err != nil && err != context.DeadlineExceeded {
 		return -1, fmt.Errorf("failed to get bot move: %w", err)
 	}
 	
-	// Calculate remaining delay time
-	elapsed := time.Since(start)
-	remainingDelay := delay - elapsed
+	// Wait out the rest of the think time
+	remainingDelay := bot.Difficulty.ThinkTime(complexity, rng) - time.Since(start)
 	if remainingDelay > 0 {
 		select {
 		case <-ctx.Done():
@@ -123,6 +207,62 @@ func (s *botPlayerService) GetBotMove(ctx context.Context, bot *BotPlayer, board
 	return move, nil
 }
 
+// chooseMove returns the bot's move and how hard the decision looked
+func (s *botPlayerService) chooseMove(ctx context.Context, bot *BotPlayer, board *models.Board, color models.PlayerColor, timeout time.Duration, rng *rand.Rand) (int, float64, error) {
+	// External engines make every decision themselves
+	if _, ok := bot.AI.(ExternalEngine); ok {
+		move, err := bot.AI.GetBestMoveWithTimeout(ctx, board, color, timeout)
+		s.recordSearch(bot)
+		return move, 0.5, err
+	}
+
+	// Tactics are never left to chance
+	if move := bot.AI.FindWinningMove(board, color); move != -1 {
+		return move, 0, nil
+	}
+	if move := bot.AI.FindBlockingMove(board, color); move != -1 {
+		return move, 0, nil
+	}
+
+	scorer, ok := bot.AI.(MoveScorer)
+	if !ok || bot.Style.deterministic() {
+		// Without column scores, treat the decision as moderately hard
+		move, err := bot.AI.GetBestMoveWithTimeout(ctx, board, color, timeout)
+		s.recordSearch(bot)
+		return move, 0.5, err
+	}
+
+	scores, err := scorer.ScoreMoves(ctx, board, color, timeout)
+	s.recordSearch(bot)
+	bot.Scores = scores
+	if len(scores) == 0 {
+		return -1, 0, err
+	}
+	return chooseMove(scores, bot.Style, rng), moveComplexity(scores, bot.Style), err
+}
+
+// recordSearch adds the bot's last search to the metrics, if its AI reports
+// search statistics
+func (s *botPlayerService) recordSearch(bot *BotPlayer) {
+	if provider, ok := bot.AI.(SearchStatsProvider); ok {
+		s.metrics.Record(bot.Difficulty, provider.LastSearchStats())
+	}
+}
+
+// SearchMetrics returns the search statistics of bot moves so far, keyed by
+// difficulty
+func (s *botPlayerService) SearchMetrics() map[string]SearchMetricsSnapshot {
+	return s.metrics.Snapshot()
+}
+
+// moveRand returns a random source for one move, seeded from the service so
+// a seeded service replays the same choices
+func (s *botPlayerService) moveRand() *rand.Rand {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return rand.New(rand.NewSource(s.rng.Int63()))
+}
+
 // IsBot checks if a username belongs to a bot
 func (s *botPlayerService) IsBot(username string) bool {
 	if len(username) < len(BotUsernamePrefix) {
@@ -130,3 +270,81 @@ func (s *botPlayerService) IsBot(username string) bool {
 	}
 	return username[:len(BotUsernamePrefix)] == BotUsernamePrefix
 }
+
+// IsBotUsername reports whether a username was assigned to a bot by any of the
+// game flows (bot service, matchmaking fallback or direct bot games)
+func IsBotUsername(username string) bool {
+	return strings.HasPrefix(username, BotUsernamePrefix) || strings.HasPrefix(username, "bot_") || username == "Bot"
+}
+
+// SessionDifficulty returns the difficulty of the bot in a game session. It
+// prefers the difficulty persisted on the session, then the one encoded in the
+// bot's username, and falls back to DefaultDifficulty for older games. Games
+// against an external engine return DifficultyEngine.
+func SessionDifficulty(session *models.GameSession) Difficulty {
+	if session == nil {
+		return DefaultDifficulty
+	}
+	if session.BotDifficulty == DifficultyEngine.String() {
+		return DifficultyEngine
+	}
+	if session.BotDifficulty != "" {
+		if difficulty, err := ParseDifficulty(session.BotDifficulty); err == nil {
+			return difficulty
+		}
+	}
+	for _, player := range []string{session.Player1, session.Player2} {
+		if difficulty, ok := DifficultyFromUsername(player); ok {
+			return difficulty
+		}
+	}
+	return DefaultDifficulty
+}
+
+// DifficultyFromUsername extracts the difficulty encoded in a bot username
+// created by CreateBot. It reports false for any other username.
+func DifficultyFromUsername(username string) (Difficulty, bool) {
+	switch {
+	case strings.HasPrefix(username, BotUsernamePrefix+"Easy_"):
+		return DifficultyEasy, true
+	case strings.HasPrefix(username, BotUsernamePrefix+"Medium_"):
+		return DifficultyMedium, true
+	case strings.HasPrefix(username, BotUsernamePrefix+"Hard_"):
+		return DifficultyHard, true
+	case strings.HasPrefix(username, BotUsernamePrefix+"Impossible_"):
+		return DifficultyImpossible, true
+	case strings.HasPrefix(username, BotUsernamePrefix+"Adaptive_"):
+		return DifficultyAdaptive, true
+	}
+	return 0, false
+}
+
+// EngineUsername returns the username prefix of bots played by the named
+// engine. CreateEngineBot appends a number, so each game's bot has its own
+// username.
+func EngineUsername(name string) string {
+	return BotUsernamePrefix + "Ext_" + name
+}
+
+// EngineFromUsername extracts the engine name from a username created by
+// CreateEngineBot or EngineUsername. It reports false for any other username.
+func EngineFromUsername(username string) (string, bool) {
+	name, ok := strings.CutPrefix(username, BotUsernamePrefix+"Ext_")
+	// Engine names have no underscores, so one starts the bot's number
+	name, _, _ = strings.Cut(name, "_")
+	return name, ok && name != ""
+}
+
+// AlgorithmUsername returns the username prefix of bots that play with
+// algorithm. Minimax bots keep their usual usernames.
+func AlgorithmUsername(algorithm Algorithm) string {
+	return BotUsernamePrefix + strings.ToUpper(string(algorithm))
+}
+
+// AlgorithmFromUsername returns the algorithm a bot username plays with
+func AlgorithmFromUsername(username string) Algorithm {
+	if strings.HasPrefix(username, AlgorithmUsername(AlgorithmMCTS)) {
+		return AlgorithmMCTS
+	}
+	return AlgorithmMinimax
+}
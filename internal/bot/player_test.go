@@ -48,6 +48,20 @@ func TestCreateBot_Hard(t *testing.T) {
 	assert.NotNil(t, bot.AI)
 }
 
+func TestCreateBot_Impossible(t *testing.T) {
+	service := NewBotPlayerService()
+	bot := service.CreateBot(DifficultyImpossible)
+
+	assert.Equal(t, DifficultyImpossible, bot.Difficulty)
+	assert.Contains(t, bot.Username, "Impossible")
+	_, ok := bot.AI.(Solver)
+	assert.True(t, ok, "Impossible bots should use the solver")
+
+	difficulty, ok := DifficultyFromUsername(bot.Username)
+	assert.True(t, ok)
+	assert.Equal(t, DifficultyImpossible, difficulty)
+}
+
 func TestCreateBot_UniqueIDs(t *testing.T) {
 	service := NewBotPlayerService()
 	
@@ -218,3 +232,62 @@ func TestGetBotMove_HandlesFullBoard(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 3, move, "Bot should choose the only available column")
 }
+
+func TestParseDifficulty(t *testing.T) {
+	cases := map[string]Difficulty{
+		"":           DefaultDifficulty,
+		"easy":       DifficultyEasy,
+		"Medium":     DifficultyMedium,
+		" HARD ":     DifficultyHard,
+		"impossible": DifficultyImpossible,
+	}
+	for name, expected := range cases {
+		difficulty, err := ParseDifficulty(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, expected, difficulty, name)
+	}
+
+	_, err := ParseDifficulty("grandmaster")
+	assert.Error(t, err)
+
+	// The engine marker is not a difficulty players can choose
+	_, err = ParseDifficulty(DifficultyEngine.String())
+	assert.Error(t, err)
+	assert.False(t, DifficultyEngine.IsValid())
+
+	for _, difficulty := range []Difficulty{DifficultyEasy, DifficultyMedium, DifficultyHard, DifficultyImpossible} {
+		parsed, err := ParseDifficulty(difficulty.String())
+		require.NoError(t, err)
+		assert.Equal(t, difficulty, parsed)
+	}
+}
+
+func TestSessionDifficulty(t *testing.T) {
+	assert.Equal(t, DefaultDifficulty, SessionDifficulty(nil))
+
+	// Persisted difficulty wins over anything else
+	session := &models.GameSession{Player1: "alice", Player2: "bot_123", BotDifficulty: "easy"}
+	assert.Equal(t, DifficultyEasy, SessionDifficulty(session))
+
+	// Older games fall back to the bot username, then the default
+	session = &models.GameSession{Player1: "Bot_Hard_1", Player2: "alice"}
+	assert.Equal(t, DifficultyHard, SessionDifficulty(session))
+
+	session = &models.GameSession{Player1: "alice", Player2: "bot_123"}
+	assert.Equal(t, DefaultDifficulty, SessionDifficulty(session))
+
+	// Engine games keep their own marker
+	session = &models.GameSession{Player1: "alice", Player2: "Bot_Ext_leftmost", BotDifficulty: "engine"}
+	assert.Equal(t, DifficultyEngine, SessionDifficulty(session))
+}
+
+func TestCreateBot_SearchDepthFollowsDifficulty(t *testing.T) {
+	service := NewBotPlayerService()
+
+	for _, difficulty := range []Difficulty{DifficultyEasy, DifficultyMedium, DifficultyHard} {
+		botPlayer := service.CreateBot(difficulty)
+		ai, ok := botPlayer.AI.(*minimaxBot)
+		require.True(t, ok)
+		assert.Equal(t, difficulty.SearchDepth(), ai.maxDepth)
+	}
+}
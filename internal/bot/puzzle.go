@@ -0,0 +1,147 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+// ErrForcedWinMismatch is returned when the solver and minimax disagree
+// about a forced win, which means one of them is wrong about the position
+var ErrForcedWinMismatch = errors.New("solver and minimax disagree on forced win")
+
+// hiddenWinDepth is the search depth whose choice decides whether a forced
+// win is obvious
+const hiddenWinDepth = 2
+
+// ForcedWin is a position where the player to move wins against any defence
+type ForcedWin struct {
+	// Moves is the number of moves the player needs, counting the winning one
+	Moves int `json:"moves"`
+	// Solutions are the columns that win in Moves moves
+	Solutions []int `json:"solutions"`
+	// Playable is the number of columns the player could choose from
+	Playable int `json:"playable"`
+	// Hidden is set when a shallow search prefers a column that does not win
+	Hidden bool `json:"hidden"`
+}
+
+// IsSolution reports whether playing col keeps the fastest win
+func (w *ForcedWin) IsSolution(col int) bool {
+	for _, solution := range w.Solutions {
+		if solution == col {
+			return true
+		}
+	}
+	return false
+}
+
+// FindForcedWin returns the fastest forced win for player to move within
+// maxMoves moves, or nil if there is none. Minimax searches for the win and
+// the solver confirms it, so both must agree on the length and on every
+// solution. It returns ctx's error if the search does not finish in time.
+func FindForcedWin(ctx context.Context, board *models.Board, player models.PlayerColor, maxMoves int) (*ForcedWin, error) {
+	if board.CheckWin() != nil {
+		return nil, ErrPositionDecided
+	}
+	if board.IsFull() {
+		return nil, ErrBoardFull
+	}
+
+	depth := 2*max(1, maxMoves) - 1
+	searcher := &minimaxBot{
+		tableSize:  defaultMinimaxTableSize,
+		maxDepth:   depth,
+		weights:    DefaultEvaluationWeights(),
+		sequential: true,
+	}
+	searcher.startSearch(ctx, depth)
+	scores, complete := searcher.scoreMovesWithDeadline(board, player, depth, time.Time{})
+	searcher.finishSearch()
+	if !complete {
+		return nil, ctx.Err()
+	}
+
+	found := forcedWinFrom(scores, func(score ColumnScore) (Outcome, int) {
+		return minimaxOutcome(score.Score, depth)
+	})
+	if found == nil {
+		return nil, nil
+	}
+
+	if err := confirmForcedWin(ctx, board, player, found); err != nil {
+		return nil, err
+	}
+
+	searcher.startSearch(ctx, hiddenWinDepth)
+	shallow, _ := searcher.scoreMovesWithDeadline(board, player, hiddenWinDepth, time.Time{})
+	searcher.finishSearch()
+	found.Hidden = !found.IsSolution(bestColumn(shallow))
+
+	return found, nil
+}
+
+// confirmForcedWin checks with the solver that exactly the solutions win
+// within the forced win's length and that none of them wins faster
+func confirmForcedWin(ctx context.Context, board *models.Board, player models.PlayerColor, win *ForcedWin) error {
+	s := NewSolver().(*solver)
+	p := bitboardFromBoard(board, player)
+	plies := 2*win.Moves - 1
+
+	s.start(ctx)
+	defer s.finish()
+	for col := 0; col < boardWidth; col++ {
+		if !p.canPlay(col) {
+			continue
+		}
+
+		solution := win.IsSolution(col)
+		wins := s.winsWithin(&p, col, plies)
+		faster := solution && s.winsWithin(&p, col, plies-2)
+		if s.stopped {
+			return ctx.Err()
+		}
+		if wins != solution || faster {
+			return fmt.Errorf("%w: column %d in a win in %d", ErrForcedWinMismatch, col, win.Moves)
+		}
+	}
+	return nil
+}
+
+// forcedWinFrom collects the fastest winning columns, reading each column's
+// outcome and length in plies with result
+func forcedWinFrom(scores []ColumnScore, result func(ColumnScore) (Outcome, int)) *ForcedWin {
+	fastest := 0
+	playable := 0
+	var solutions []int
+
+	for _, score := range scores {
+		if !score.Playable {
+			continue
+		}
+		playable++
+
+		outcome, plies := result(score)
+		if outcome != OutcomeWin {
+			continue
+		}
+		switch {
+		case fastest == 0 || plies < fastest:
+			fastest, solutions = plies, []int{score.Column}
+		case plies == fastest:
+			solutions = append(solutions, score.Column)
+		}
+	}
+
+	if fastest == 0 {
+		return nil
+	}
+	return &ForcedWin{
+		Moves:     (fastest + 1) / 2,
+		Solutions: solutions,
+		Playable:  playable,
+	}
+}
@@ -0,0 +1,83 @@
+package bot
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindForcedWin(t *testing.T) {
+	ctx := context.Background()
+
+	// Red's open three on the bottom row cannot be stopped
+	board, player := boardFromMoves(t, "2233")
+	win, err := FindForcedWin(ctx, &board, player, 4)
+	require.NoError(t, err)
+	require.NotNil(t, win)
+	assert.Equal(t, 2, win.Moves)
+	assert.Equal(t, []int{3}, win.Solutions)
+	assert.Equal(t, 7, win.Playable)
+	assert.True(t, win.IsSolution(3))
+	assert.False(t, win.IsSolution(0))
+
+	board, player = boardFromMoves(t, "121212")
+	win, err = FindForcedWin(ctx, &board, player, 4)
+	require.NoError(t, err)
+	require.NotNil(t, win)
+	assert.Equal(t, 1, win.Moves)
+	assert.Equal(t, []int{0}, win.Solutions)
+	assert.False(t, win.Hidden, "a shallow search sees a four")
+
+	board, player = boardFromMoves(t, "44")
+	win, err = FindForcedWin(ctx, &board, player, 3)
+	require.NoError(t, err)
+	assert.Nil(t, win, "no short win in the opening")
+
+	board, player = boardFromMoves(t, "1212121")
+	_, err = FindForcedWin(ctx, &board, player, 3)
+	assert.ErrorIs(t, err, ErrPositionDecided)
+}
+
+func TestFindForcedWin_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	time.Sleep(2 * time.Millisecond)
+
+	board, player := boardFromMoves(t, "")
+	_, err := FindForcedWin(ctx, &board, player, 10)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestSolverWinsWithin_MatchesAnalyze(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	ctx := context.Background()
+
+	for i := 0; i < 30; i++ {
+		board, player := randomPosition(rng, 20+rng.Intn(12))
+		if board.IsFull() {
+			continue
+		}
+
+		s := NewSolver().(*solver)
+		scores, err := s.Analyze(ctx, &board, player)
+		require.NoError(t, err)
+
+		p := bitboardFromBoard(&board, player)
+		s.start(ctx)
+		for _, score := range scores {
+			if !score.Playable {
+				continue
+			}
+			for plies := 1; plies <= 9; plies += 2 {
+				want := score.Outcome == OutcomeWin && score.Plies <= plies
+				assert.Equal(t, want, s.winsWithin(&p, score.Column, plies),
+					"position %d column %d within %d plies", i, score.Column, plies)
+			}
+		}
+		s.finish()
+	}
+}
@@ -0,0 +1,94 @@
+package bot
+
+import (
+	"sync"
+	"time"
+)
+
+// SearchMetrics aggregates the statistics of bot searches per difficulty, to
+// show whether the move time budget is enough under load
+type SearchMetrics struct {
+	mu     sync.Mutex
+	totals map[Difficulty]*searchTotals
+}
+
+// searchTotals are the running totals for one difficulty
+type searchTotals struct {
+	searches uint64
+	cutoffs  uint64
+	depth    uint64
+	nodes    uint64
+	ttProbes uint64
+	ttHits   uint64
+	duration time.Duration
+	maxTime  time.Duration
+}
+
+// SearchMetricsSnapshot summarizes the searches at one difficulty
+type SearchMetricsSnapshot struct {
+	Searches uint64 `json:"searches"`
+	// CutoffRate is the share of searches the deadline stopped early
+	CutoffRate float64 `json:"cutoffRate"`
+	AvgDepth   float64 `json:"avgDepth"`
+	AvgNodes   float64 `json:"avgNodes"`
+	TTHitRate  float64 `json:"ttHitRate"`
+	AvgTimeMs  float64 `json:"avgTimeMs"`
+	MaxTimeMs  float64 `json:"maxTimeMs"`
+}
+
+// NewSearchMetrics creates empty search metrics
+func NewSearchMetrics() *SearchMetrics {
+	return &SearchMetrics{totals: make(map[Difficulty]*searchTotals)}
+}
+
+// Record adds the statistics of one search at difficulty
+func (m *SearchMetrics) Record(difficulty Difficulty, stats SearchStats) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	totals, ok := m.totals[difficulty]
+	if !ok {
+		totals = &searchTotals{}
+		m.totals[difficulty] = totals
+	}
+	totals.searches++
+	if stats.Cutoff {
+		totals.cutoffs++
+	}
+	totals.depth += uint64(stats.Depth)
+	totals.nodes += stats.Nodes
+	totals.ttProbes += stats.TTProbes
+	totals.ttHits += stats.TTHits
+	totals.duration += stats.Duration
+	if stats.Duration > totals.maxTime {
+		totals.maxTime = stats.Duration
+	}
+}
+
+// Snapshot returns the metrics keyed by difficulty name
+func (m *SearchMetrics) Snapshot() map[string]SearchMetricsSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]SearchMetricsSnapshot, len(m.totals))
+	for difficulty, totals := range m.totals {
+		searches := float64(totals.searches)
+		entry := SearchMetricsSnapshot{
+			Searches:   totals.searches,
+			CutoffRate: float64(totals.cutoffs) / searches,
+			AvgDepth:   float64(totals.depth) / searches,
+			AvgNodes:   float64(totals.nodes) / searches,
+			AvgTimeMs:  durationMs(totals.duration) / searches,
+			MaxTimeMs:  durationMs(totals.maxTime),
+		}
+		if totals.ttProbes > 0 {
+			entry.TTHitRate = float64(totals.ttHits) / float64(totals.ttProbes)
+		}
+		snapshot[difficulty.String()] = entry
+	}
+	return snapshot
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
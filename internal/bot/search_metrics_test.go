@@ -0,0 +1,98 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connect4-multiplayer/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinimaxSearchStats(t *testing.T) {
+	board := models.NewBoard()
+
+	bot := NewMinimaxBotWithDepth(4)
+	move, err := bot.GetBestMoveWithTimeout(context.Background(), &board, models.PlayerColorRed, 10*time.Second)
+	require.NoError(t, err)
+
+	stats := bot.(SearchStatsProvider).LastSearchStats()
+	assert.Equal(t, 4, stats.Depth)
+	assert.False(t, stats.Cutoff)
+	assert.Positive(t, stats.Nodes)
+	assert.Positive(t, stats.TTProbes)
+	assert.LessOrEqual(t, stats.TTHits, stats.TTProbes)
+	assert.Positive(t, stats.Duration)
+	require.NotEmpty(t, stats.PrincipalVariation)
+	assert.Equal(t, move, stats.PrincipalVariation[0])
+	assert.LessOrEqual(t, len(stats.PrincipalVariation), 4)
+
+	// A cancelled search stops before finishing any depth
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = bot.GetBestMoveWithTimeout(ctx, &board, models.PlayerColorRed, 10*time.Second)
+	assert.ErrorIs(t, err, context.Canceled)
+	stats = bot.(SearchStatsProvider).LastSearchStats()
+	assert.True(t, stats.Cutoff)
+	assert.Zero(t, stats.Depth)
+	assert.Empty(t, stats.PrincipalVariation)
+}
+
+func TestMinimaxSearchStats_TacticalMovesAreNotSearched(t *testing.T) {
+	board := boardFromColumns(t, "R", "R", "R")
+
+	bot := NewMinimaxBotWithDepth(4)
+	move, err := bot.GetBestMoveWithTimeout(context.Background(), &board, models.PlayerColorRed, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 3, move)
+	assert.Equal(t, SearchStats{}, bot.(SearchStatsProvider).LastSearchStats())
+}
+
+func TestSearchMetrics(t *testing.T) {
+	metrics := NewSearchMetrics()
+	assert.Empty(t, metrics.Snapshot())
+
+	metrics.Record(DifficultyEasy, SearchStats{Depth: 2, Nodes: 100, TTProbes: 10, TTHits: 4, Duration: 10 * time.Millisecond})
+	metrics.Record(DifficultyEasy, SearchStats{Depth: 4, Nodes: 300, TTProbes: 30, TTHits: 16, Duration: 30 * time.Millisecond, Cutoff: true})
+	metrics.Record(DifficultyHard, SearchStats{Depth: 9, Nodes: 5000, Duration: 900 * time.Millisecond})
+
+	snapshot := metrics.Snapshot()
+	assert.Equal(t, SearchMetricsSnapshot{
+		Searches:   2,
+		CutoffRate: 0.5,
+		AvgDepth:   3,
+		AvgNodes:   200,
+		TTHitRate:  0.5,
+		AvgTimeMs:  20,
+		MaxTimeMs:  30,
+	}, snapshot["easy"])
+	assert.Equal(t, SearchMetricsSnapshot{
+		Searches:  1,
+		AvgDepth:  9,
+		AvgNodes:  5000,
+		AvgTimeMs: 900,
+		MaxTimeMs: 900,
+	}, snapshot["hard"])
+}
+
+func TestGetBotMove_RecordsSearchMetrics(t *testing.T) {
+	service := NewBotPlayerServiceWithSeed(1)
+	player := service.CreateBot(DifficultyEasy)
+	board := models.NewBoard()
+
+	_, err := service.GetBotMove(context.Background(), player, &board, models.PlayerColorRed)
+	require.NoError(t, err)
+
+	// Forced moves are not searched, so they are not counted
+	board = boardFromColumns(t, "R", "R", "R")
+	_, err = service.GetBotMove(context.Background(), player, &board, models.PlayerColorRed)
+	require.NoError(t, err)
+
+	metrics := service.SearchMetrics()
+	require.Contains(t, metrics, "easy")
+	assert.Equal(t, uint64(1), metrics["easy"].Searches)
+	assert.Positive(t, metrics["easy"].AvgDepth)
+	assert.Positive(t, metrics["easy"].AvgNodes)
+	assert.NotContains(t, metrics, "hard")
+}
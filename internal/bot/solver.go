@@ -0,0 +1,547 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+const (
+	// Solver scores follow the usual convention: a win with the player's k-th
+	// stone scores 22-k, a draw 0 and a loss the negated winner's score
+	solverMinScore = -boardCells/2 + 3
+	solverMaxScore = (boardCells+1)/2 - 3
+
+	// defaultSolverTableSize is the number of transposition table entries
+	defaultSolverTableSize = 1 << 21
+
+	// solverCheckInterval is how many nodes are searched between context checks
+	solverCheckInterval = 1 << 12
+)
+
+var (
+	// ErrPositionDecided is returned when asked to solve a finished game
+	ErrPositionDecided = errors.New("position already has a winner")
+	// ErrBoardFull is returned when asked to solve a full board
+	ErrBoardFull = errors.New("board is full")
+)
+
+// Outcome is the result of a move under perfect play
+type Outcome string
+
+const (
+	OutcomeWin  Outcome = "win"
+	OutcomeDraw Outcome = "draw"
+	OutcomeLoss Outcome = "loss"
+)
+
+// ColumnScore is the value of playing a column. The solver's scores are
+// exact; heuristic bots report their evaluation and leave Outcome and Plies
+// empty.
+type ColumnScore struct {
+	Column   int     `json:"column"`
+	Playable bool    `json:"playable"`
+	Score    int     `json:"score"`
+	Outcome  Outcome `json:"outcome,omitempty"`
+	// Plies is the number of moves, including this one, until the game ends
+	// when both sides play perfectly
+	Plies int `json:"plies,omitempty"`
+}
+
+// Solver is a BotAI that plays perfectly and reports exact results
+type Solver interface {
+	BotAI
+	// Solve returns the exact score of the position for player to move
+	Solve(ctx context.Context, board *models.Board, player models.PlayerColor) (int, error)
+	// Analyze returns the exact outcome of every column for player to move
+	Analyze(ctx context.Context, board *models.Board, player models.PlayerColor) ([]ColumnScore, error)
+}
+
+// solver implements Solver with a bitboard negamax, a transposition table,
+// null-window search and an opening book. It is not safe for concurrent use.
+type solver struct {
+	table    *solverTable
+	book     *OpeningBook
+	fallback BotAI // plays when a solve does not finish in time
+
+	ctx     context.Context
+	stopped bool
+	started time.Time
+	stats   SearchStats
+}
+
+// NewSolver creates a perfect-play bot using the opening book installed with
+// UseOpeningBook, or the built-in one
+func NewSolver() Solver {
+	return NewSolverWithBook(currentOpeningBook())
+}
+
+// NewSolverWithBook creates a perfect-play bot using the given opening book
+func NewSolverWithBook(book *OpeningBook) Solver {
+	return &solver{
+		book:     book,
+		fallback: NewMinimaxBot(),
+	}
+}
+
+// GetBestMove returns the best move under perfect play. Depth is ignored;
+// positions outside the opening book are solved to the end, which can take
+// long early in the game. Use GetBestMoveWithTimeout to bound it.
+func (s *solver) GetBestMove(board *models.Board, player models.PlayerColor, depth int) int {
+	scores, err := s.Analyze(context.Background(), board, player)
+	if err != nil {
+		return s.fallback.GetBestMove(board, player, depth)
+	}
+	return bestColumn(scores)
+}
+
+// GetBestMoveWithTimeout returns the perfect move if the position can be
+// solved in half the time limit, and the minimax move otherwise
+func (s *solver) GetBestMoveWithTimeout(ctx context.Context, board *models.Board, player models.PlayerColor, timeout time.Duration) (int, error) {
+	if winMove := s.FindWinningMove(board, player); winMove != -1 {
+		s.stats = SearchStats{}
+		return winMove, nil
+	}
+
+	// Book moves are instant; otherwise Analyze consults the book for each reply
+	p := bitboardFromBoard(board, player)
+	if move, ok := s.book.lookupMove(&p); ok && board.IsValidMove(move) {
+		s.stats = SearchStats{BookHits: 1, Depth: boardCells - p.moves, PrincipalVariation: []int{move}}
+		return move, nil
+	}
+
+	start := time.Now()
+	solveCtx, cancel := context.WithTimeout(ctx, timeout/2)
+	scores, err := s.Analyze(solveCtx, board, player)
+	cancel()
+	if err == nil {
+		move := bestColumn(scores)
+		s.stats.PrincipalVariation = []int{move}
+		return move, nil
+	}
+
+	remaining := timeout - time.Since(start)
+	if ctx.Err() != nil {
+		remaining = 0
+	}
+	move, err := s.fallback.GetBestMoveWithTimeout(ctx, board, player, remaining)
+	s.recordFallback(start)
+	return move, err
+}
+
+// recordFallback combines the statistics of a solve that ran out of time
+// with those of the fallback search that chose the move instead
+func (s *solver) recordFallback(start time.Time) {
+	var stats SearchStats
+	if provider, ok := s.fallback.(SearchStatsProvider); ok {
+		stats = provider.LastSearchStats()
+	}
+	stats.Nodes += s.stats.Nodes
+	stats.BookHits, stats.BookMisses = s.stats.BookHits, s.stats.BookMisses
+	stats.TTProbes += s.stats.TTProbes
+	stats.TTHits += s.stats.TTHits
+	stats.Duration = time.Since(start)
+	stats.Cutoff = true
+	s.stats = stats
+}
+
+// EvaluatePosition returns the minimax heuristic; use Solve for exact values
+func (s *solver) EvaluatePosition(board *models.Board, player models.PlayerColor) int {
+	return s.fallback.EvaluatePosition(board, player)
+}
+
+// FindWinningMove finds a move that wins the game immediately
+func (s *solver) FindWinningMove(board *models.Board, player models.PlayerColor) int {
+	p := bitboardFromBoard(board, player)
+	for _, col := range columnOrder {
+		if p.canPlay(col) && p.isWinningMove(col) {
+			return col
+		}
+	}
+	return -1
+}
+
+// FindBlockingMove finds a move that blocks the opponent's winning move
+func (s *solver) FindBlockingMove(board *models.Board, player models.PlayerColor) int {
+	return s.FindWinningMove(board, getOpponent(player))
+}
+
+// Solve returns the exact score of the position for player to move
+func (s *solver) Solve(ctx context.Context, board *models.Board, player models.PlayerColor) (int, error) {
+	p, err := s.position(board, player)
+	if err != nil {
+		return 0, err
+	}
+
+	s.start(ctx)
+	defer s.finish()
+	score := s.solve(&p)
+	if s.stopped {
+		return 0, ctx.Err()
+	}
+	s.stats.Depth = boardCells - p.moves
+	return score, nil
+}
+
+// Analyze returns the exact outcome of every column for player to move
+func (s *solver) Analyze(ctx context.Context, board *models.Board, player models.PlayerColor) ([]ColumnScore, error) {
+	p, err := s.position(board, player)
+	if err != nil {
+		return nil, err
+	}
+
+	s.start(ctx)
+	defer s.finish()
+	scores := s.analyze(&p)
+	if s.stopped {
+		return nil, ctx.Err()
+	}
+	s.stats.Depth = boardCells - p.moves
+	return scores, nil
+}
+
+// LastSearchStats returns statistics for the last Solve, Analyze or move search
+func (s *solver) LastSearchStats() SearchStats {
+	return s.stats
+}
+
+// analyze scores every column of p; callers check s.stopped afterwards
+func (s *solver) analyze(p *bitboard) []ColumnScore {
+	scores := make([]ColumnScore, boardWidth)
+	for col := 0; col < boardWidth; col++ {
+		scores[col].Column = col
+		if !p.canPlay(col) {
+			continue
+		}
+
+		var score int
+		if p.isWinningMove(col) {
+			score = (boardCells + 1 - p.moves) / 2
+		} else {
+			child := *p
+			child.playCol(col)
+			score = -s.solve(&child)
+			if s.stopped {
+				return nil
+			}
+		}
+
+		scores[col] = ColumnScore{
+			Column:   col,
+			Playable: true,
+			Score:    score,
+			Outcome:  scoreOutcome(score),
+			Plies:    scorePlies(score, p.moves),
+		}
+	}
+
+	return scores
+}
+
+// position validates the board and converts it to a bitboard
+func (s *solver) position(board *models.Board, player models.PlayerColor) (bitboard, error) {
+	if board == nil {
+		return bitboard{}, fmt.Errorf("board is nil")
+	}
+	if board.CheckWin() != nil {
+		return bitboard{}, ErrPositionDecided
+	}
+	if board.IsFull() {
+		return bitboard{}, ErrBoardFull
+	}
+	return bitboardFromBoard(board, player), nil
+}
+
+func (s *solver) start(ctx context.Context) {
+	s.ctx = ctx
+	s.stopped = false
+	s.started = time.Now()
+	s.stats = SearchStats{}
+	s.table = solverTables.Get().(*solverTable)
+}
+
+func (s *solver) finish() {
+	solverTables.Put(s.table)
+	s.table = nil
+	s.stats.Duration = time.Since(s.started)
+	s.stats.Cutoff = s.stopped
+}
+
+// solve finds the exact score with a series of null-window searches that
+// narrow the [min, max] interval, trying 0 first to settle win/draw/loss
+func (s *solver) solve(p *bitboard) int {
+	if p.canWinNext() {
+		return (boardCells + 1 - p.moves) / 2
+	}
+	if p.moves <= s.book.MaxPly() {
+		if score, ok := s.book.lookup(p); ok {
+			s.stats.BookHits++
+			return score
+		}
+		s.stats.BookMisses++
+	}
+
+	min := -(boardCells - p.moves) / 2
+	max := (boardCells + 1 - p.moves) / 2
+
+	for min < max {
+		med := min + (max-min)/2
+		if med <= 0 && min/2 < med {
+			med = min / 2
+		} else if med >= 0 && max/2 > med {
+			med = max / 2
+		}
+
+		r := s.negamax(p, med, med+1)
+		if s.stopped {
+			return 0
+		}
+		if r <= med {
+			max = r
+		} else {
+			min = r
+		}
+	}
+
+	return min
+}
+
+// winsWithin reports whether playing col wins within plies moves, counting
+// col itself. A single null-window search at the matching score settles it,
+// which is far cheaper than solving the position. Callers check s.stopped
+// afterwards.
+func (s *solver) winsWithin(p *bitboard, col, plies int) bool {
+	if plies < 1 {
+		return false
+	}
+	if p.isWinningMove(col) {
+		return true
+	}
+	if plies < 3 {
+		return false
+	}
+
+	child := *p
+	child.playCol(col)
+	if child.canWinNext() {
+		return false
+	}
+
+	// The score of winning with the player's stone after plies moves
+	target := (boardCells+2)/2 - p.moves/2 - (plies+1)/2
+	return -s.negamax(&child, -target, -target+1) >= target
+}
+
+// negamax returns the exact score when it lies in [alpha, beta], an upper
+// bound when it is at most alpha and a lower bound when it is at least beta.
+// The player to move must not be able to win immediately.
+func (s *solver) negamax(p *bitboard, alpha, beta int) int {
+	s.stats.Nodes++
+	if s.stats.Nodes%solverCheckInterval == 0 && s.ctx.Err() != nil {
+		s.stopped = true
+	}
+	if s.stopped {
+		return 0
+	}
+
+	next := p.possibleNonLosingMoves()
+	if next == 0 {
+		return -(boardCells - p.moves) / 2
+	}
+	if p.moves >= boardCells-2 {
+		return 0
+	}
+
+	// The opponent cannot win next turn, so the score is bounded below
+	min := -(boardCells - 2 - p.moves) / 2
+	if alpha < min {
+		alpha = min
+		if alpha >= beta {
+			return alpha
+		}
+	}
+
+	// We cannot win next turn either
+	max := (boardCells - 1 - p.moves) / 2
+	key := p.key()
+	s.stats.TTProbes++
+	if value := s.table.get(key); value != 0 {
+		s.stats.TTHits++
+		if value > solverMaxScore-solverMinScore+1 {
+			min = int(value) + 2*solverMinScore - solverMaxScore - 2
+			if alpha < min {
+				alpha = min
+				if alpha >= beta {
+					return alpha
+				}
+			}
+		} else {
+			max = int(value) + solverMinScore - 1
+		}
+	}
+	if beta > max {
+		beta = max
+		if alpha >= beta {
+			return beta
+		}
+	}
+
+	var moves moveSorter
+	for i := len(columnOrder) - 1; i >= 0; i-- {
+		if move := next & columnMask(columnOrder[i]); move != 0 {
+			moves.add(move, p.moveScore(move))
+		}
+	}
+
+	for move := moves.next(); move != 0; move = moves.next() {
+		child := *p
+		child.play(move)
+		score := -s.negamax(&child, -beta, -alpha)
+		if s.stopped {
+			return 0
+		}
+		if score >= beta {
+			s.table.put(key, uint8(score+solverMaxScore-2*solverMinScore+2))
+			return score
+		}
+		if score > alpha {
+			alpha = score
+		}
+	}
+
+	s.table.put(key, uint8(alpha-solverMinScore+1))
+	return alpha
+}
+
+// bestColumn returns the highest scoring playable column, preferring the
+// center on ties
+func bestColumn(scores []ColumnScore) int {
+	best := -1
+	for _, col := range columnOrder {
+		if col >= len(scores) || !scores[col].Playable {
+			continue
+		}
+		if best == -1 || scores[col].Score > scores[best].Score {
+			best = col
+		}
+	}
+	return best
+}
+
+func scoreOutcome(score int) Outcome {
+	switch {
+	case score > 0:
+		return OutcomeWin
+	case score < 0:
+		return OutcomeLoss
+	default:
+		return OutcomeDraw
+	}
+}
+
+// scorePlies converts a column score into the number of plies, counting the
+// move itself, until the game ends from a position with moves stones played
+func scorePlies(score, moves int) int {
+	ownStones := moves / 2
+	opponentStones := moves - ownStones
+	switch {
+	case score > 0:
+		return 2*((boardCells+2)/2-score-ownStones) - 1
+	case score < 0:
+		return 2 * ((boardCells+2)/2 + score - opponentStones)
+	default:
+		return boardCells - moves
+	}
+}
+
+// columnOrder searches center columns first for better pruning
+var columnOrder = []int{3, 2, 4, 1, 5, 0, 6}
+
+// moveSorter keeps up to boardWidth moves ordered by score. Moves added
+// later win ties, so callers add them from the least to the most central.
+type moveSorter struct {
+	size    int
+	entries [boardWidth]struct {
+		move  uint64
+		score int
+	}
+}
+
+func (m *moveSorter) add(move uint64, score int) {
+	pos := m.size
+	m.size++
+	for ; pos > 0 && m.entries[pos-1].score > score; pos-- {
+		m.entries[pos] = m.entries[pos-1]
+	}
+	m.entries[pos].move = move
+	m.entries[pos].score = score
+}
+
+// next pops the best remaining move, or 0 when none is left
+func (m *moveSorter) next() uint64 {
+	if m.size == 0 {
+		return 0
+	}
+	m.size--
+	return m.entries[m.size].move
+}
+
+// solverTables recycles transposition tables between searches, since bots are
+// created per move. Entries hold exact bounds for a position regardless of the
+// search that stored them, so a recycled table also speeds up the next one.
+var solverTables = sync.Pool{
+	New: func() interface{} { return newSolverTable(defaultSolverTableSize) },
+}
+
+// solverTable is a fixed-size transposition table. Keys are positions with
+// fewer than 49 significant bits, so with a prime size above 2^17 storing the
+// low 32 bits is enough to tell entries in the same slot apart.
+type solverTable struct {
+	keys   []uint32
+	values []uint8
+}
+
+func newSolverTable(size int) *solverTable {
+	size = nextPrime(size)
+	return &solverTable{
+		keys:   make([]uint32, size),
+		values: make([]uint8, size),
+	}
+}
+
+func (t *solverTable) put(key uint64, value uint8) {
+	i := key % uint64(len(t.keys))
+	t.keys[i] = uint32(key)
+	t.values[i] = value
+}
+
+// get returns the stored value, or 0 when the key is absent
+func (t *solverTable) get(key uint64) uint8 {
+	i := key % uint64(len(t.keys))
+	if t.keys[i] == uint32(key) {
+		return t.values[i]
+	}
+	return 0
+}
+
+func nextPrime(n int) int {
+	if n < 2 {
+		return 2
+	}
+	for ; ; n++ {
+		prime := true
+		for d := 2; d*d <= n; d++ {
+			if n%d == 0 {
+				prime = false
+				break
+			}
+		}
+		if prime {
+			return n
+		}
+	}
+}
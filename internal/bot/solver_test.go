@@ -0,0 +1,181 @@
+package bot
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"connect4-multiplayer/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// boardFromMoves plays a sequence of columns (1-7) starting with red and
+// returns the board and the player to move
+func boardFromMoves(t testing.TB, moves string) (models.Board, models.PlayerColor) {
+	board := models.NewBoard()
+	player := models.PlayerColorRed
+	for _, c := range moves {
+		require.NoError(t, board.MakeMove(int(c-'1'), player))
+		player = getOpponent(player)
+	}
+	return board, player
+}
+
+// referenceScore solves a position by plain negamax without pruning
+func referenceScore(p bitboard) int {
+	if p.moves == boardCells {
+		return 0
+	}
+	for col := 0; col < boardWidth; col++ {
+		if p.canPlay(col) && p.isWinningMove(col) {
+			return (boardCells + 1 - p.moves) / 2
+		}
+	}
+	best := -boardCells
+	for col := 0; col < boardWidth; col++ {
+		if p.canPlay(col) {
+			child := p
+			child.playCol(col)
+			if score := -referenceScore(child); score > best {
+				best = score
+			}
+		}
+	}
+	return best
+}
+
+func TestSolver_MatchesReferenceOnEndgames(t *testing.T) {
+	s := NewSolverWithBook(nil)
+	rng := rand.New(rand.NewSource(42))
+
+	checked := 0
+	for checked < 10 {
+		board := models.NewBoard()
+		player := models.PlayerColorRed
+		for i := 0; i < 33 && board.CheckWin() == nil; i++ {
+			col := rng.Intn(boardWidth)
+			for !board.IsValidMove(col) {
+				col = (col + 1) % boardWidth
+			}
+			require.NoError(t, board.MakeMove(col, player))
+			player = getOpponent(player)
+		}
+		if board.CheckWin() != nil {
+			continue
+		}
+
+		score, err := s.Solve(context.Background(), &board, player)
+		require.NoError(t, err)
+		assert.Equal(t, referenceScore(bitboardFromBoard(&board, player)), score)
+		checked++
+	}
+}
+
+func TestSolver_AnalyzeImmediateWin(t *testing.T) {
+	s := NewSolver()
+	board, player := boardFromMoves(t, "444444121212")
+
+	scores, err := s.Analyze(context.Background(), &board, player)
+	require.NoError(t, err)
+	require.Len(t, scores, boardWidth)
+
+	// Red wins with their fourth stone on the next move
+	assert.Equal(t, OutcomeWin, scores[0].Outcome)
+	assert.Equal(t, 15, scores[0].Score)
+	assert.Equal(t, 1, scores[0].Plies)
+
+	// Anything else lets yellow block and red is no longer winning at once
+	assert.Less(t, scores[4].Score, scores[0].Score)
+	assert.Equal(t, 0, s.GetBestMove(&board, player, 1))
+}
+
+func TestSolver_AnalyzeFullColumn(t *testing.T) {
+	s := NewSolver()
+	board, player := boardFromMoves(t, "44444411112222")
+
+	scores, err := s.Analyze(context.Background(), &board, player)
+	require.NoError(t, err)
+	assert.False(t, scores[3].Playable)
+	for _, score := range scores {
+		if score.Playable {
+			assert.NotEmpty(t, score.Outcome)
+			assert.Greater(t, score.Plies, 0)
+		}
+	}
+}
+
+func TestSolver_OpeningBook(t *testing.T) {
+	s := NewSolver()
+	board := models.NewBoard()
+
+	score, err := s.Solve(context.Background(), &board, models.PlayerColorRed)
+	require.NoError(t, err)
+	assert.Equal(t, 1, score, "the first player wins with perfect play")
+
+	scores, err := s.Analyze(context.Background(), &board, models.PlayerColorRed)
+	require.NoError(t, err)
+	expected := []int{-2, -1, 0, 1, 0, -1, -2}
+	for col, score := range scores {
+		assert.Equal(t, expected[col], score.Score, "column %d", col)
+	}
+	assert.Equal(t, 3, bestColumn(scores))
+	assert.Equal(t, 41, scores[3].Plies, "red wins with the 41st stone")
+	assert.Equal(t, 40, scores[0].Plies, "yellow wins with the 40th stone")
+
+	// Mirrored positions share book entries
+	left, player := boardFromMoves(t, "1")
+	right, _ := boardFromMoves(t, "7")
+	leftScore, ok := DefaultOpeningBook().Lookup(&left, player)
+	require.True(t, ok)
+	rightScore, ok := DefaultOpeningBook().Lookup(&right, player)
+	require.True(t, ok)
+	assert.Equal(t, leftScore, rightScore)
+}
+
+func TestSolver_DecidedPosition(t *testing.T) {
+	s := NewSolver()
+	board, player := boardFromMoves(t, "1212121")
+
+	_, err := s.Solve(context.Background(), &board, player)
+	assert.ErrorIs(t, err, ErrPositionDecided)
+}
+
+func TestSolver_GetBestMoveWithTimeoutFallsBack(t *testing.T) {
+	s := NewSolver()
+	// Too early to solve in time and not in the book
+	board, player := boardFromMoves(t, "44")
+
+	start := time.Now()
+	move, err := s.GetBestMoveWithTimeout(context.Background(), &board, player, 200*time.Millisecond)
+	elapsed := time.Since(start)
+
+	require.NoError(t, err)
+	assert.True(t, board.IsValidMove(move))
+	assert.Less(t, elapsed, 400*time.Millisecond)
+}
+
+func TestSolver_SolveRespectsContext(t *testing.T) {
+	s := NewSolverWithBook(nil)
+	board := models.NewBoard()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := s.Solve(ctx, &board, models.PlayerColorRed)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestBitboard_MirrorKey(t *testing.T) {
+	left, player := boardFromMoves(t, "1123")
+	right, _ := boardFromMoves(t, "7765")
+
+	l := bitboardFromBoard(&left, player)
+	r := bitboardFromBoard(&right, player)
+	assert.Equal(t, l.key(), r.mirrorKey())
+	leftKey, leftMirrored := canonicalKey(&l)
+	rightKey, rightMirrored := canonicalKey(&r)
+	assert.Equal(t, leftKey, rightKey)
+	assert.NotEqual(t, leftMirrored, rightMirrored)
+}
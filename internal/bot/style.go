@@ -0,0 +1,130 @@
+package bot
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// PlayStyle controls how human-like a bot's move choice is. A zero
+// PlayStyle always plays the best move.
+type PlayStyle struct {
+	// Temperature of the softmax over column scores, in score units. Higher
+	// values make weaker moves more likely; 0 always picks the best move.
+	Temperature float64 `json:"temperature"`
+	// BlunderRate is the probability of deliberately playing a random
+	// non-best move when no immediate win or block is on the board
+	BlunderRate float64 `json:"blunderRate"`
+	// CloseMargin is how far below the best score a move may be and still
+	// count as a close alternative, which makes the bot think longer
+	CloseMargin int `json:"closeMargin"`
+}
+
+// PlayStyle returns the move selection style for a difficulty
+func (d Difficulty) PlayStyle() PlayStyle {
+	switch d {
+	case DifficultyEasy:
+		return PlayStyle{Temperature: 40, BlunderRate: 0.2, CloseMargin: scoreThreeInRow}
+	case DifficultyMedium:
+		return PlayStyle{Temperature: 15, BlunderRate: 0.07, CloseMargin: scoreThreeInRow}
+	case DifficultyHard:
+		return PlayStyle{Temperature: 3, BlunderRate: 0.02, CloseMargin: scoreThreeInRow}
+	case DifficultyImpossible:
+		return PlayStyle{CloseMargin: 1} // solver scores count stones, not heuristic points
+	default:
+		return DifficultyMedium.PlayStyle()
+	}
+}
+
+// deterministic reports whether the style always plays the best move
+func (s PlayStyle) deterministic() bool {
+	return s.Temperature <= 0 && s.BlunderRate <= 0
+}
+
+// chooseMove picks a column from scores according to style. It returns -1
+// when no column is playable.
+func chooseMove(scores []ColumnScore, style PlayStyle, rng *rand.Rand) int {
+	best := bestColumn(scores)
+	if best == -1 {
+		return -1
+	}
+
+	var playable []int
+	for _, score := range scores {
+		if score.Playable {
+			playable = append(playable, score.Column)
+		}
+	}
+	if len(playable) == 1 {
+		return best
+	}
+
+	if style.BlunderRate > 0 && rng.Float64() < style.BlunderRate {
+		others := make([]int, 0, len(playable)-1)
+		for _, col := range playable {
+			if col != best {
+				others = append(others, col)
+			}
+		}
+		return others[rng.Intn(len(others))]
+	}
+
+	if style.Temperature <= 0 {
+		return best
+	}
+
+	// Softmax relative to the best score so large scores cannot overflow
+	weights := make([]float64, len(playable))
+	total := 0.0
+	for i, col := range playable {
+		weights[i] = math.Exp(float64(scores[col].Score-scores[best].Score) / style.Temperature)
+		total += weights[i]
+	}
+
+	r := rng.Float64() * total
+	for i, col := range playable {
+		r -= weights[i]
+		if r < 0 {
+			return col
+		}
+	}
+	return best
+}
+
+// moveComplexity is the share of the non-best playable moves that score
+// within style.CloseMargin of the best, from 0 (obvious) to 1 (all close)
+func moveComplexity(scores []ColumnScore, style PlayStyle) float64 {
+	best := bestColumn(scores)
+	if best == -1 {
+		return 0
+	}
+
+	alternatives, closeMoves := 0, 0
+	for _, score := range scores {
+		if !score.Playable || score.Column == best {
+			continue
+		}
+		alternatives++
+		if scores[best].Score-score.Score <= style.CloseMargin {
+			closeMoves++
+		}
+	}
+	if alternatives == 0 {
+		return 0
+	}
+	return float64(closeMoves) / float64(alternatives)
+}
+
+// ThinkTime models how long a human at this difficulty would take over a
+// decision of the given complexity (0 for obvious moves, 1 when every move
+// looks as good as the best). It varies by up to 15% either way and never
+// exceeds DefaultBotTimeout.
+func (d Difficulty) ThinkTime(complexity float64, rng *rand.Rand) time.Duration {
+	complexity = math.Max(0, math.Min(1, complexity))
+	jitter := 0.85 + 0.3*rng.Float64()
+	think := time.Duration(float64(d.HumanDelay()) * (0.5 + complexity) * jitter)
+	if think > DefaultBotTimeout {
+		think = DefaultBotTimeout
+	}
+	return think
+}
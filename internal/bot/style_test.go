@@ -0,0 +1,134 @@
+package bot
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"connect4-multiplayer/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testScores(values ...int) []ColumnScore {
+	scores := make([]ColumnScore, len(values))
+	for col, value := range values {
+		scores[col] = ColumnScore{Column: col, Playable: true, Score: value}
+	}
+	return scores
+}
+
+func TestChooseMove_ZeroTemperaturePicksBest(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	scores := testScores(0, 5, 40, 10, 40, 5, 0)
+
+	for i := 0; i < 20; i++ {
+		// Ties go to the more central column
+		assert.Equal(t, 2, chooseMove(scores, PlayStyle{}, rng))
+	}
+}
+
+func TestChooseMove_BlunderAvoidsBest(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	scores := testScores(0, 5, 10, 50, 10, 5, 0)
+	scores[6].Playable = false
+
+	for i := 0; i < 50; i++ {
+		move := chooseMove(scores, PlayStyle{BlunderRate: 1}, rng)
+		assert.NotEqual(t, 3, move)
+		assert.NotEqual(t, 6, move)
+	}
+}
+
+func TestChooseMove_SoftmaxFavorsBetterMoves(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	scores := testScores(-1000, 0, 20, 30, 20, 0, -1000)
+
+	counts := make(map[int]int)
+	for i := 0; i < 2000; i++ {
+		counts[chooseMove(scores, PlayStyle{Temperature: 15}, rng)]++
+	}
+
+	assert.Greater(t, counts[3], counts[2])
+	assert.Greater(t, counts[2], counts[1])
+	assert.Greater(t, counts[1], 0, "weaker moves are still played sometimes")
+	assert.Zero(t, counts[0], "hopeless moves are effectively never played")
+}
+
+func TestMoveComplexity(t *testing.T) {
+	style := PlayStyle{CloseMargin: 10}
+
+	assert.Equal(t, 0.0, moveComplexity(testScores(100, 0, 0, 0), style))
+	assert.Equal(t, 1.0, moveComplexity(testScores(100, 95, 91, 100), style))
+	assert.InDelta(t, 0.5, moveComplexity(testScores(100, 95, 0), style), 1e-9)
+}
+
+func TestThinkTime_GrowsWithComplexity(t *testing.T) {
+	obvious := DifficultyMedium.ThinkTime(0, rand.New(rand.NewSource(3)))
+	hard := DifficultyMedium.ThinkTime(1, rand.New(rand.NewSource(3)))
+
+	assert.Less(t, obvious, hard)
+	assert.LessOrEqual(t, DifficultyEasy.ThinkTime(1, rand.New(rand.NewSource(3))), DefaultBotTimeout)
+
+	// Jitter varies the delay between moves of the same complexity
+	seen := make(map[time.Duration]bool)
+	rng := rand.New(rand.NewSource(3))
+	for i := 0; i < 10; i++ {
+		seen[DifficultyMedium.ThinkTime(0.5, rng)] = true
+	}
+	assert.Greater(t, len(seen), 1)
+}
+
+func TestBotPlayerService_SeededMovesAreReproducible(t *testing.T) {
+	play := func(seed int64) []int {
+		service := NewBotPlayerServiceWithSeed(seed).(*botPlayerService)
+		botPlayer := service.CreateBot(DifficultyEasy)
+		board := models.NewBoard()
+		color := models.PlayerColorRed
+
+		var moves []int
+		for i := 0; i < 6; i++ {
+			move, _, err := service.chooseMove(context.Background(), botPlayer, &board, color, 50*time.Millisecond, service.moveRand())
+			require.NoError(t, err)
+			require.NoError(t, board.MakeMove(move, color))
+			moves = append(moves, move)
+			color = getOpponent(color)
+		}
+		return moves
+	}
+
+	assert.Equal(t, play(42), play(42))
+}
+
+func TestBotPlayerService_EasyBotsVaryTheirOpenings(t *testing.T) {
+	board := models.NewBoard()
+	openings := make(map[int]bool)
+
+	for seed := int64(0); seed < 20; seed++ {
+		service := NewBotPlayerServiceWithSeed(seed).(*botPlayerService)
+		botPlayer := service.CreateBot(DifficultyEasy)
+		move, _, err := service.chooseMove(context.Background(), botPlayer, &board, models.PlayerColorRed, 50*time.Millisecond, service.moveRand())
+		require.NoError(t, err)
+		openings[move] = true
+	}
+
+	assert.Greater(t, len(openings), 1)
+}
+
+func TestBotPlayerService_TacticsAreNotRandom(t *testing.T) {
+	board := models.NewBoard()
+	board.MakeMove(0, models.PlayerColorYellow)
+	board.MakeMove(1, models.PlayerColorYellow)
+	board.MakeMove(2, models.PlayerColorYellow)
+
+	for seed := int64(0); seed < 20; seed++ {
+		service := NewBotPlayerServiceWithSeed(seed).(*botPlayerService)
+		botPlayer := service.CreateBot(DifficultyEasy)
+		botPlayer.Style.BlunderRate = 1
+		move, complexity, err := service.chooseMove(context.Background(), botPlayer, &board, models.PlayerColorRed, 50*time.Millisecond, service.moveRand())
+		require.NoError(t, err)
+		assert.Equal(t, 3, move)
+		assert.Zero(t, complexity)
+	}
+}
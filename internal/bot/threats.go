@@ -0,0 +1,195 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+// Parity is whether a row is odd or even, counting from 1 at the bottom
+type Parity string
+
+const (
+	ParityOdd  Parity = "odd"
+	ParityEven Parity = "even"
+)
+
+// rowParity returns the parity of a grid row, where row 0 is the bottom
+func rowParity(row int) Parity {
+	if row%2 == 0 {
+		return ParityOdd
+	}
+	return ParityEven
+}
+
+// ThreatUrgency is how soon a threat can be played
+type ThreatUrgency string
+
+const (
+	// UrgencyImmediate threats are playable now: the owner wins next move
+	// unless the opponent fills the cell first
+	UrgencyImmediate ThreatUrgency = "immediate"
+	// UrgencyPending threats sit just above the playable cell, so neither
+	// side wants to play beneath them
+	UrgencyPending ThreatUrgency = "pending"
+	// UrgencyLatent threats need more discs in the column first
+	UrgencyLatent ThreatUrgency = "latent"
+)
+
+// Threat is an empty cell that would complete four for Player
+type Threat struct {
+	Cell
+	Player  models.PlayerColor `json:"player"`
+	Parity  Parity             `json:"parity"`
+	Urgency ThreatUrgency      `json:"urgency"`
+	// GoodParity is set when the row suits the owner: odd rows for the
+	// player who moved first, even rows for the other. When the board fills
+	// up without either side getting a free move, those are the cells each
+	// player ends up with.
+	GoodParity bool `json:"goodParity"`
+}
+
+// ThreatAnalysis lists both sides' threats and who controls zugzwang
+type ThreatAnalysis struct {
+	Player      models.PlayerColor `json:"player"`      // side to move
+	FirstPlayer models.PlayerColor `json:"firstPlayer"` // owns the odd rows
+	// Threats are ordered by column, then row
+	Threats []Threat `json:"threats"`
+	// ZugzwangControl is the player who wins the odd/even battle, or holds
+	// the draw, if the board fills up column by column
+	ZugzwangControl models.PlayerColor `json:"zugzwangControl"`
+	// ZugzwangReason explains ZugzwangControl, counting columns from 1
+	ZugzwangReason string `json:"zugzwangReason"`
+}
+
+// AnalyzeThreats finds every threat on board with player to move.
+//
+// Zugzwang control follows the classic rules for the first player F and the
+// second player S. S can answer each move in the same column, which hands F
+// the odd rows and S the even ones, so S controls zugzwang by default. F
+// takes control with an odd threat, unless S has an even threat lower in the
+// same column, which the filling column reaches first.
+func AnalyzeThreats(board *models.Board, player models.PlayerColor) *ThreatAnalysis {
+	first := firstPlayer(board, player)
+	analysis := &ThreatAnalysis{
+		Player:      player,
+		FirstPlayer: first,
+		Threats:     []Threat{},
+	}
+
+	for column := range board.Height {
+		for row := board.Height[column]; row < len(board.Grid); row++ {
+			for _, owner := range []models.PlayerColor{models.PlayerColorRed, models.PlayerColorYellow} {
+				if !isThreat(board, Cell{Column: column, Row: row}, owner) {
+					continue
+				}
+				urgency := UrgencyLatent
+				switch row - board.Height[column] {
+				case 0:
+					urgency = UrgencyImmediate
+				case 1:
+					urgency = UrgencyPending
+				}
+				parity := rowParity(row)
+				analysis.Threats = append(analysis.Threats, Threat{
+					Cell:       Cell{Column: column, Row: row},
+					Player:     owner,
+					Parity:     parity,
+					Urgency:    urgency,
+					GoodParity: (parity == ParityOdd) == (owner == first),
+				})
+			}
+		}
+	}
+	sort.SliceStable(analysis.Threats, func(i, j int) bool {
+		a, b := analysis.Threats[i], analysis.Threats[j]
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		return a.Row < b.Row
+	})
+
+	second := getOpponent(first)
+	analysis.ZugzwangControl = second
+	analysis.ZugzwangReason = fmt.Sprintf("%s can answer every move in the same column and take the even rows", second)
+	for _, threat := range analysis.Threats {
+		if threat.Player == second && threat.GoodParity {
+			analysis.ZugzwangReason = fmt.Sprintf("%s has an even threat in column %d", second, threat.Column+1)
+			break
+		}
+	}
+	for _, threat := range analysis.Threats {
+		if threat.Player == first && threat.GoodParity && !undercut(analysis.Threats, threat) {
+			analysis.ZugzwangControl = first
+			analysis.ZugzwangReason = fmt.Sprintf("%s has an odd threat in column %d", first, threat.Column+1)
+			break
+		}
+	}
+	return analysis
+}
+
+// undercut reports whether the opponent has a good threat below threat in
+// its column, which the column reaches first
+func undercut(threats []Threat, threat Threat) bool {
+	for _, other := range threats {
+		if other.Column == threat.Column && other.Row < threat.Row && other.Player != threat.Player && other.GoodParity {
+			return true
+		}
+	}
+	return false
+}
+
+// firstPlayer returns the player who made the first move, from the side to
+// move and the number of discs: with turns alternating, the first player is
+// to move whenever the disc count is even
+func firstPlayer(board *models.Board, toMove models.PlayerColor) models.PlayerColor {
+	discs := 0
+	for _, height := range board.Height {
+		discs += height
+	}
+	if discs%2 == 0 {
+		return toMove
+	}
+	return getOpponent(toMove)
+}
+
+// parityThreats counts player's good-parity threats that are not yet
+// playable, minus the opponent's. Playable threats are left to the search.
+// The first player is taken to be the one with more discs, or red.
+func parityThreats(board *models.Board, player models.PlayerColor) int {
+	red, yellow := 0, 0
+	for row := range board.Grid {
+		for _, cell := range board.Grid[row] {
+			switch cell {
+			case models.PlayerColorRed:
+				red++
+			case models.PlayerColorYellow:
+				yellow++
+			}
+		}
+	}
+	first := models.PlayerColorRed
+	if yellow > red {
+		first = models.PlayerColorYellow
+	}
+
+	count := 0
+	for column := range board.Height {
+		for row := board.Height[column] + 1; row < len(board.Grid); row++ {
+			owner := first
+			if rowParity(row) == ParityEven {
+				owner = getOpponent(first)
+			}
+			if !connectsFour(board, row, column, owner) {
+				continue
+			}
+			if owner == player {
+				count++
+			} else {
+				count--
+			}
+		}
+	}
+	return count
+}
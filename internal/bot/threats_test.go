@@ -0,0 +1,69 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+func TestAnalyzeThreats(t *testing.T) {
+	red, yellow := models.PlayerColorRed, models.PlayerColorYellow
+
+	t.Run("EmptyBoard", func(t *testing.T) {
+		board := models.NewBoard()
+		analysis := AnalyzeThreats(&board, red)
+		assert.Empty(t, analysis.Threats)
+		assert.Equal(t, red, analysis.FirstPlayer)
+		assert.Equal(t, yellow, analysis.ZugzwangControl, "the second player controls zugzwang by default")
+	})
+
+	t.Run("OddThreatGivesFirstPlayerControl", func(t *testing.T) {
+		board := boardFromColumns(t, "YYR", "RYR", "YRR")
+		analysis := AnalyzeThreats(&board, yellow)
+
+		assert.Equal(t, red, analysis.FirstPlayer)
+		assert.Contains(t, analysis.Threats, Threat{
+			Cell:       Cell{Column: 3, Row: 2},
+			Player:     red,
+			Parity:     ParityOdd,
+			Urgency:    UrgencyLatent,
+			GoodParity: true,
+		})
+		assert.Equal(t, red, analysis.ZugzwangControl)
+		assert.Contains(t, analysis.ZugzwangReason, "column 4")
+	})
+
+	t.Run("LowerEvenThreatUndercuts", func(t *testing.T) {
+		board := boardFromColumns(t, "YYR", "RYR", "YRR", "", "YY", "YY", "YY")
+		analysis := AnalyzeThreats(&board, yellow)
+
+		var column3 []Threat
+		for _, threat := range analysis.Threats {
+			if threat.Column == 3 {
+				column3 = append(column3, threat)
+			}
+		}
+		assert.Equal(t, []Threat{
+			{Cell: Cell{Column: 3, Row: 0}, Player: yellow, Parity: ParityOdd, Urgency: UrgencyImmediate},
+			{Cell: Cell{Column: 3, Row: 1}, Player: yellow, Parity: ParityEven, Urgency: UrgencyPending, GoodParity: true},
+			{Cell: Cell{Column: 3, Row: 2}, Player: red, Parity: ParityOdd, Urgency: UrgencyLatent, GoodParity: true},
+		}, column3)
+		assert.Equal(t, yellow, analysis.ZugzwangControl)
+		assert.Equal(t, "yellow has an even threat in column 4", analysis.ZugzwangReason)
+	})
+}
+
+func TestEvaluatePosition_ParityThreats(t *testing.T) {
+	board := boardFromColumns(t, "YYR", "RYR", "YRR")
+	assert.Equal(t, 1, parityThreats(&board, models.PlayerColorRed))
+	assert.Equal(t, -1, parityThreats(&board, models.PlayerColorYellow))
+
+	weights := BuiltinEvaluationWeights()
+	without := weights
+	without.ParityThreat = 0
+	assert.Equal(t,
+		newMinimaxBot(1, without, 0).EvaluatePosition(&board, models.PlayerColorRed)+weights.ParityThreat,
+		newMinimaxBot(1, weights, 0).EvaluatePosition(&board, models.PlayerColorRed))
+}
@@ -0,0 +1,158 @@
+package bot
+
+import (
+	"sync/atomic"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+// defaultMinimaxTableSize is the number of minimax transposition table
+// entries, 1 MiB at 16 bytes each
+const defaultMinimaxTableSize = 1 << 16
+
+// zobristKeys holds a random key per cell and color. A board's hash is the
+// XOR of the keys of its discs, so playing a disc updates it with one XOR.
+var zobristKeys = func() (keys [boardHeight][boardWidth][2]uint64) {
+	// splitmix64 with a fixed seed keeps hashes stable between runs
+	state := uint64(0)
+	for row := 0; row < boardHeight; row++ {
+		for col := 0; col < boardWidth; col++ {
+			for color := 0; color < 2; color++ {
+				state += 0x9E3779B97F4A7C15
+				z := state
+				z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+				z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+				keys[row][col][color] = z ^ (z >> 31)
+			}
+		}
+	}
+	return keys
+}()
+
+// zobristYellowBot is mixed into hashes of searches for the yellow player,
+// because minimax scores are from the bot's point of view
+const zobristYellowBot uint64 = 0xD1B54A32D192ED03
+
+// zobristKey returns the key of a disc of color at row, col
+func zobristKey(row, col int, color models.PlayerColor) uint64 {
+	if color == models.PlayerColorYellow {
+		return zobristKeys[row][col][1]
+	}
+	return zobristKeys[row][col][0]
+}
+
+// zobristHash hashes a board for a search on behalf of botPlayer
+func zobristHash(board *models.Board, botPlayer models.PlayerColor) uint64 {
+	var hash uint64
+	if botPlayer == models.PlayerColorYellow {
+		hash = zobristYellowBot
+	}
+	for row := 0; row < boardHeight; row++ {
+		for col := 0; col < boardWidth; col++ {
+			if color := board.Grid[row][col]; color != "" {
+				hash ^= zobristKey(row, col, color)
+			}
+		}
+	}
+	return hash
+}
+
+// boundType tells how a stored score relates to the position's true score
+type boundType uint8
+
+const (
+	boundExact boundType = iota // the score is exact
+	boundLower                  // the true score is at least the stored one
+	boundUpper                  // the true score is at most the stored one
+)
+
+// ttEntry is a decoded transposition table slot
+type ttEntry struct {
+	key   uint64
+	score int32
+	depth int8
+	bound boundType
+	move  int8  // best column found, or -1
+	age   uint8 // search that stored the entry, 0 for empty slots
+}
+
+// pack encodes everything but the key in one word
+func (e ttEntry) pack() uint64 {
+	return uint64(uint32(e.score)) |
+		uint64(uint8(e.depth))<<32 |
+		uint64(e.bound)<<40 |
+		uint64(uint8(e.move))<<48 |
+		uint64(e.age)<<56
+}
+
+func unpackEntry(key, data uint64) ttEntry {
+	return ttEntry{
+		key:   key,
+		score: int32(uint32(data)),
+		depth: int8(data >> 32),
+		bound: boundType(data >> 40),
+		move:  int8(data >> 48),
+		age:   uint8(data >> 56),
+	}
+}
+
+// ttSlot stores an entry as its packed data and the key XORed with it, so
+// parallel searches can share the table without locks: a slot torn by
+// concurrent writes fails the key check and reads as empty.
+type ttSlot struct {
+	check uint64
+	data  uint64
+}
+
+// transpositionTable is a fixed-size hash table of search results that is
+// safe for concurrent use. Entries from the current search are only
+// replaced by results of at least the same depth; entries from earlier
+// searches are always replaced.
+type transpositionTable struct {
+	slots []ttSlot
+	mask  uint64
+	age   uint8
+}
+
+// newTranspositionTable creates a table; size must be a power of two
+func newTranspositionTable(size int) *transpositionTable {
+	return &transpositionTable{
+		slots: make([]ttSlot, size),
+		mask:  uint64(size - 1),
+		age:   1,
+	}
+}
+
+// newSearch ages the table so the next search prefers its own entries. It
+// must not run concurrently with other methods.
+func (t *transpositionTable) newSearch() {
+	t.age++
+	if t.age == 0 {
+		t.age = 1
+	}
+}
+
+// probe returns the entry stored for key
+func (t *transpositionTable) probe(key uint64) (ttEntry, bool) {
+	slot := &t.slots[key&t.mask]
+	data := atomic.LoadUint64(&slot.data)
+	if atomic.LoadUint64(&slot.check)^data != key {
+		return ttEntry{}, false
+	}
+	entry := unpackEntry(key, data)
+	return entry, entry.age != 0
+}
+
+// store records a search result for key
+func (t *transpositionTable) store(key uint64, depth, score int, bound boundType, move int) {
+	slot := &t.slots[key&t.mask]
+	data := atomic.LoadUint64(&slot.data)
+	old := unpackEntry(atomic.LoadUint64(&slot.check)^data, data)
+	if old.age == t.age && old.key != key && int(old.depth) > depth {
+		return
+	}
+
+	data = ttEntry{score: int32(score), depth: int8(depth), bound: bound, move: int8(move), age: t.age}.pack()
+	atomic.StoreUint64(&slot.data, data)
+	atomic.StoreUint64(&slot.check, key^data)
+}
@@ -0,0 +1,144 @@
+package bot
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"connect4-multiplayer/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// randomPosition plays up to plies random moves that do not end the game
+func randomPosition(rng *rand.Rand, plies int) (models.Board, models.PlayerColor) {
+	board := models.NewBoard()
+	player := models.PlayerColorRed
+	for i := 0; i < plies; i++ {
+		col := rng.Intn(boardWidth)
+		if !board.IsValidMove(col) {
+			continue
+		}
+		next := board
+		_ = next.MakeMove(col, player)
+		if next.CheckWin() != nil {
+			continue
+		}
+		board = next
+		player = getOpponent(player)
+	}
+	return board, player
+}
+
+func TestZobristHash_Incremental(t *testing.T) {
+	board := models.NewBoard()
+	player := models.PlayerColorRed
+	hash := zobristHash(&board, models.PlayerColorRed)
+
+	for _, col := range []int{3, 3, 2, 4, 0, 6, 3} {
+		row := board.Height[col]
+		require.NoError(t, board.MakeMove(col, player))
+		hash ^= zobristKey(row, col, player)
+		assert.Equal(t, zobristHash(&board, models.PlayerColorRed), hash)
+		player = getOpponent(player)
+	}
+
+	assert.NotEqual(t, zobristHash(&board, models.PlayerColorRed), zobristHash(&board, models.PlayerColorYellow),
+		"scores are relative to the bot, so its color must be part of the hash")
+}
+
+func TestTranspositionTable_Replacement(t *testing.T) {
+	table := newTranspositionTable(4)
+
+	_, ok := table.probe(0)
+	assert.False(t, ok, "empty slots must not match the zero key")
+
+	table.store(1, 5, 42, boundLower, 3)
+	entry, ok := table.probe(1)
+	require.True(t, ok)
+	assert.Equal(t, ttEntry{key: 1, score: 42, depth: 5, bound: boundLower, move: 3, age: table.age}, entry)
+
+	// A shallower result for another position in the same slot does not
+	// evict a deeper one from the same search
+	table.store(5, 2, 7, boundExact, 1)
+	_, ok = table.probe(5)
+	assert.False(t, ok)
+
+	// Entries from earlier searches are always replaced
+	table.newSearch()
+	table.store(5, 2, 7, boundExact, 1)
+	_, ok = table.probe(5)
+	assert.True(t, ok)
+	_, ok = table.probe(1)
+	assert.False(t, ok)
+}
+
+// TestMinimax_TableMatchesPlainSearch checks that the transposition table
+// speeds up the search without changing its results
+func TestMinimax_TableMatchesPlainSearch(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	for i := 0; i < 25; i++ {
+		board, player := randomPosition(rng, 4+rng.Intn(20))
+
+		cached := newMinimaxBot(5, DefaultEvaluationWeights(), defaultMinimaxTableSize)
+		plain := newMinimaxBot(5, DefaultEvaluationWeights(), 0)
+
+		assert.Equal(t, plain.GetBestMove(&board, player, 5), cached.GetBestMove(&board, player, 5))
+
+		want, err := plain.ScoreMoves(context.Background(), &board, player, time.Minute)
+		require.NoError(t, err)
+		got, err := cached.ScoreMoves(context.Background(), &board, player, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+		assert.Less(t, cached.LastSearchStats().Nodes, plain.LastSearchStats().Nodes)
+	}
+}
+
+func TestMoveOrder(t *testing.T) {
+	assert.Equal(t, [boardWidth]int{3, 2, 4, 1, 5, 0, 6}, moveOrder(-1))
+	assert.Equal(t, [boardWidth]int{5, 3, 2, 4, 1, 0, 6}, moveOrder(5))
+	assert.Equal(t, [boardWidth]int{3, 2, 4, 1, 5, 0, 6}, moveOrder(3))
+}
+
+// BenchmarkMinimax compares depth-7 searches with and without the
+// transposition table and reports the positions searched per move
+func BenchmarkMinimax(b *testing.B) {
+	positions := []struct{ name, moves string }{
+		{"opening", ""},
+		{"midgame", "443534"},
+		{"late", "4444221335566"},
+	}
+	tables := []struct {
+		name string
+		size int
+	}{
+		{"table", defaultMinimaxTableSize},
+		{"no-table", 0},
+	}
+
+	for _, position := range positions {
+		board, player := boardFromMoves(b, position.moves)
+		for _, table := range tables {
+			b.Run(position.name+"/"+table.name, func(b *testing.B) {
+				var nodes uint64
+				for i := 0; i < b.N; i++ {
+					ai := newMinimaxBot(maxSearchDepth, DefaultEvaluationWeights(), table.size)
+					ai.GetBestMove(&board, player, maxSearchDepth)
+					nodes += ai.LastSearchStats().Nodes
+				}
+				b.ReportMetric(float64(nodes)/float64(b.N), "nodes/op")
+			})
+		}
+	}
+}
+
+// BenchmarkMinimax_IterativeDeepening measures a timed move, where each
+// depth reuses the previous depth's best moves for ordering
+func BenchmarkMinimax_IterativeDeepening(b *testing.B) {
+	board, player := boardFromMoves(b, "443534")
+	for i := 0; i < b.N; i++ {
+		ai := NewMinimaxBot()
+		_, _ = ai.GetBestMoveWithTimeout(context.Background(), &board, player, time.Minute)
+	}
+}
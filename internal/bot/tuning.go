@@ -0,0 +1,378 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sync"
+	"time"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+// TuningPosition is a position labelled with how the game went for the
+// player to move
+type TuningPosition struct {
+	Board  models.Board
+	Player models.PlayerColor
+	// Result is 1 if Player won, 0.5 for a draw and 0 if Player lost
+	Result float64
+}
+
+// TuningConfig configures TuneEvaluationWeights. Tuning is Texel-style: the
+// evaluation is mapped to an expected result with sigmoid(score/Scale), and
+// the weights are changed one at a time while that lowers the mean squared
+// error against the labelled results.
+type TuningConfig struct {
+	// Scale converts evaluation points to a win probability; 0 fits it to
+	// the starting weights
+	Scale float64
+	// MaxIterations bounds the passes over the weights
+	MaxIterations int
+	// InitialStep is the first change tried on each weight. It is halved
+	// whenever no change helps, and tuning stops when it reaches zero.
+	InitialStep int
+	// Progress, if set, is called after every pass
+	Progress func(iteration int, loss float64, weights EvaluationWeights)
+}
+
+// DefaultTuningConfig returns default tuning configuration
+func DefaultTuningConfig() TuningConfig {
+	return TuningConfig{
+		MaxIterations: 500,
+		InitialStep:   16,
+	}
+}
+
+// TuningResult is the outcome of TuneEvaluationWeights
+type TuningResult struct {
+	Weights     EvaluationWeights `json:"weights"`
+	Scale       float64           `json:"scale"`
+	InitialLoss float64           `json:"initialLoss"`
+	Loss        float64           `json:"loss"`
+	Iterations  int               `json:"iterations"`
+	Positions   int               `json:"positions"`
+}
+
+// tuningFeatures counts what each weight is multiplied by in
+// EvaluatePosition, so a position's score under any weights is a dot product
+type tuningFeatures struct {
+	threes   int // player's open threes minus the opponent's
+	twos     int // player's open twos minus the opponent's
+	center   int // player's discs in the center column
+	adjacent int // player's discs next to the center column
+	parity   int // player's parity threats minus the opponent's
+}
+
+func positionFeatures(board *models.Board, player models.PlayerColor) tuningFeatures {
+	opponent := getOpponent(player)
+	threes := &minimaxBot{weights: EvaluationWeights{ThreeInRow: 1}}
+	twos := &minimaxBot{weights: EvaluationWeights{TwoInRow: 1}}
+
+	f := tuningFeatures{
+		threes: threes.evaluateWindows(board, player, opponent),
+		twos:   twos.evaluateWindows(board, player, opponent),
+		parity: parityThreats(board, player),
+	}
+	for row := 0; row < 6; row++ {
+		if board.Grid[row][3] == player {
+			f.center++
+		}
+		if board.Grid[row][2] == player {
+			f.adjacent++
+		}
+		if board.Grid[row][4] == player {
+			f.adjacent++
+		}
+	}
+	return f
+}
+
+// score returns EvaluatePosition's score for the position under w
+func (f tuningFeatures) score(w EvaluationWeights) int {
+	return f.threes*w.ThreeInRow + f.twos*w.TwoInRow + f.center*w.CenterBonus + f.adjacent*(w.CenterBonus/2) +
+		f.parity*w.ParityThreat
+}
+
+// tuningSet is the positions reduced to their features
+type tuningSet struct {
+	features []tuningFeatures
+	results  []float64
+}
+
+func newTuningSet(positions []TuningPosition) *tuningSet {
+	set := &tuningSet{
+		features: make([]tuningFeatures, len(positions)),
+		results:  make([]float64, len(positions)),
+	}
+	for i := range positions {
+		set.features[i] = positionFeatures(&positions[i].Board, positions[i].Player)
+		set.results[i] = positions[i].Result
+	}
+	return set
+}
+
+// loss is the mean squared error between the results and the expected
+// results under w
+func (s *tuningSet) loss(w EvaluationWeights, scale float64) float64 {
+	total := 0.0
+	for i, f := range s.features {
+		expected := 1 / (1 + math.Exp(-float64(f.score(w))/scale))
+		diff := s.results[i] - expected
+		total += diff * diff
+	}
+	return total / float64(len(s.features))
+}
+
+// fitScale returns the scale that fits w best, searched on a log scale
+func (s *tuningSet) fitScale(w EvaluationWeights) float64 {
+	best, bestLoss := 1.0, math.Inf(1)
+	for scale := 1.0; scale <= 100000; scale *= 1.05 {
+		if loss := s.loss(w, scale); loss < bestLoss {
+			best, bestLoss = scale, loss
+		}
+	}
+	return best
+}
+
+// TuneEvaluationWeights fits evaluation weights to labelled positions,
+// starting from start. When ctx is cancelled it returns the best weights
+// found so far along with the context's error.
+func TuneEvaluationWeights(ctx context.Context, positions []TuningPosition, start EvaluationWeights, config TuningConfig) (TuningResult, error) {
+	if len(positions) == 0 {
+		return TuningResult{}, fmt.Errorf("no positions to tune on")
+	}
+	if err := start.Validate(); err != nil {
+		return TuningResult{}, err
+	}
+	if config.MaxIterations <= 0 {
+		config.MaxIterations = DefaultTuningConfig().MaxIterations
+	}
+	if config.InitialStep <= 0 {
+		config.InitialStep = DefaultTuningConfig().InitialStep
+	}
+
+	set := newTuningSet(positions)
+	scale := config.Scale
+	if scale <= 0 {
+		scale = set.fitScale(start)
+	}
+
+	result := TuningResult{
+		Weights:     start,
+		Scale:       scale,
+		InitialLoss: set.loss(start, scale),
+		Positions:   len(positions),
+	}
+	result.Loss = result.InitialLoss
+
+	step := config.InitialStep
+	for result.Iterations < config.MaxIterations && step > 0 {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		result.Iterations++
+
+		improved := false
+		for _, weight := range []*int{&result.Weights.ThreeInRow, &result.Weights.TwoInRow, &result.Weights.CenterBonus, &result.Weights.ParityThreat} {
+			for _, delta := range []int{step, -step} {
+				original := *weight
+				*weight += delta
+				if result.Weights.Validate() == nil {
+					if loss := set.loss(result.Weights, scale); loss < result.Loss {
+						result.Loss = loss
+						improved = true
+						break
+					}
+				}
+				*weight = original
+			}
+		}
+		if !improved {
+			step /= 2
+		}
+
+		if config.Progress != nil {
+			config.Progress(result.Iterations, result.Loss, result.Weights)
+		}
+	}
+	return result, nil
+}
+
+// TuningDataConfig configures GenerateTuningPositions
+type TuningDataConfig struct {
+	// Positions is how many labelled positions to generate
+	Positions int
+	// MinPly and MaxPly bound the stones on the board. Early positions take
+	// the solver much longer.
+	MinPly int
+	MaxPly int
+	// Workers solve positions in parallel
+	Workers int
+	// SolveTimeout skips positions the solver cannot finish in time
+	SolveTimeout time.Duration
+	// Seed makes the positions reproducible for one worker; 0 seeds from
+	// the clock
+	Seed int64
+	// Progress, if set, is called as positions are labelled
+	Progress func(done, total int)
+}
+
+// DefaultTuningDataConfig returns default tuning data configuration
+func DefaultTuningDataConfig() TuningDataConfig {
+	return TuningDataConfig{
+		Positions:    5000,
+		MinPly:       12,
+		MaxPly:       30,
+		Workers:      max(1, runtime.NumCPU()/2),
+		SolveTimeout: 2 * time.Second,
+	}
+}
+
+// GenerateTuningPositions plays random games and labels quiet positions from
+// them with their solved result. Positions where the player to move can win
+// or must block at once are left out: the evaluation never has to judge them
+// because the search resolves them first.
+func GenerateTuningPositions(ctx context.Context, config TuningDataConfig) ([]TuningPosition, error) {
+	defaults := DefaultTuningDataConfig()
+	if config.Positions <= 0 {
+		config.Positions = defaults.Positions
+	}
+	if config.MaxPly <= 0 || config.MaxPly >= boardCells {
+		config.MaxPly = defaults.MaxPly
+	}
+	config.MinPly = min(max(config.MinPly, 0), config.MaxPly)
+	if config.Workers <= 0 {
+		config.Workers = defaults.Workers
+	}
+	if config.SolveTimeout <= 0 {
+		config.SolveTimeout = defaults.SolveTimeout
+	}
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu        sync.Mutex
+		positions = make([]TuningPosition, 0, config.Positions)
+		wg        sync.WaitGroup
+	)
+	for worker := 0; worker < config.Workers; worker++ {
+		wg.Add(1)
+		go func(rng *rand.Rand) {
+			defer wg.Done()
+			solver := NewSolver()
+			for ctx.Err() == nil {
+				position, ok := randomQuietPosition(rng, config.MinPly, config.MaxPly)
+				if !ok {
+					continue
+				}
+
+				solveCtx, cancelSolve := context.WithTimeout(ctx, config.SolveTimeout)
+				score, err := solver.Solve(solveCtx, &position.Board, position.Player)
+				cancelSolve()
+				if err != nil {
+					continue
+				}
+				position.Result = resultFromScore(score)
+
+				mu.Lock()
+				if len(positions) < config.Positions {
+					positions = append(positions, position)
+					if config.Progress != nil {
+						config.Progress(len(positions), config.Positions)
+					}
+				}
+				if len(positions) == config.Positions {
+					cancel()
+				}
+				mu.Unlock()
+			}
+		}(rand.New(rand.NewSource(seed + int64(worker))))
+	}
+	wg.Wait()
+
+	// Workers stop when enough positions are labelled or the caller gives up
+	if err := parent.Err(); err != nil && len(positions) < config.Positions {
+		return positions, err
+	}
+	return positions, nil
+}
+
+// randomQuietPosition plays a random game to a random length between minPly
+// and maxPly. It reports false if the game ended or the position is not quiet.
+func randomQuietPosition(rng *rand.Rand, minPly, maxPly int) (TuningPosition, bool) {
+	board := models.NewBoard()
+	player := models.PlayerColorRed
+	plies := minPly + rng.Intn(maxPly-minPly+1)
+
+	for ply := 0; ply < plies; ply++ {
+		moves := validColumns(&board)
+		column := moves[rng.Intn(len(moves))]
+		row := board.Height[column]
+		_ = board.MakeMove(column, player)
+		if connectsFour(&board, row, column, player) {
+			return TuningPosition{}, false
+		}
+		player = getOpponent(player)
+	}
+
+	if !isQuiet(&board, player) {
+		return TuningPosition{}, false
+	}
+	return TuningPosition{Board: board, Player: player}, true
+}
+
+// isQuiet reports whether neither player can win with their next disc
+func isQuiet(board *models.Board, player models.PlayerColor) bool {
+	moves := validColumns(board)
+	return len(moves) > 0 &&
+		winningColumn(board, moves, player) == -1 &&
+		winningColumn(board, moves, getOpponent(player)) == -1
+}
+
+// resultFromScore converts a solver score to a tuning result
+func resultFromScore(score int) float64 {
+	switch {
+	case score > 0:
+		return 1
+	case score < 0:
+		return 0
+	default:
+		return 0.5
+	}
+}
+
+// TuningPositionsFromGame labels the quiet positions of a finished game with
+// its result, from the position with minPly stones on. A nil winner is a
+// draw. Stored games are noisier than solved positions but reflect how
+// players actually play.
+func TuningPositionsFromGame(moves []*models.Move, winner *models.PlayerColor, minPly int) ([]TuningPosition, error) {
+	board := models.NewBoard()
+	var positions []TuningPosition
+
+	for ply, move := range moves {
+		if ply >= minPly && isQuiet(&board, move.Player) {
+			result := 0.5
+			if winner != nil {
+				result = 0
+				if *winner == move.Player {
+					result = 1
+				}
+			}
+			positions = append(positions, TuningPosition{Board: board, Player: move.Player, Result: result})
+		}
+
+		if err := board.MakeMove(move.Column, move.Player); err != nil {
+			return nil, fmt.Errorf("invalid move %d in column %d: %w", ply+1, move.Column, err)
+		}
+	}
+	return positions, nil
+}
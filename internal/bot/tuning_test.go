@@ -0,0 +1,148 @@
+package bot
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"connect4-multiplayer/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// randomPositions returns quiet positions from random games
+func randomPositions(t *testing.T, n int, seed int64) []TuningPosition {
+	rng := rand.New(rand.NewSource(seed))
+	var positions []TuningPosition
+	for len(positions) < n {
+		if position, ok := randomQuietPosition(rng, 6, 30); ok {
+			positions = append(positions, position)
+		}
+	}
+	return positions
+}
+
+func TestPositionFeaturesMatchEvaluatePosition(t *testing.T) {
+	weights := []EvaluationWeights{
+		BuiltinEvaluationWeights(),
+		{ThreeInRow: 77, TwoInRow: 13, CenterBonus: 9, ParityThreat: 31},
+	}
+	for _, position := range randomPositions(t, 200, 1) {
+		features := positionFeatures(&position.Board, position.Player)
+		for _, w := range weights {
+			bot := newMinimaxBot(1, w, 0)
+			assert.Equal(t, bot.EvaluatePosition(&position.Board, position.Player), features.score(w))
+		}
+	}
+}
+
+func TestTuneEvaluationWeights_FitsKnownWeights(t *testing.T) {
+	// Label positions with the expected results of known weights; tuning
+	// from the built-in weights should move towards them
+	target := EvaluationWeights{ThreeInRow: 60, TwoInRow: 20, CenterBonus: 8}
+	const scale = 100.0
+
+	positions := randomPositions(t, 2000, 2)
+	for i := range positions {
+		score := positionFeatures(&positions[i].Board, positions[i].Player).score(target)
+		positions[i].Result = 1 / (1 + math.Exp(-float64(score)/scale))
+	}
+
+	start := BuiltinEvaluationWeights()
+	iterations := 0
+	result, err := TuneEvaluationWeights(context.Background(), positions, start, TuningConfig{
+		Scale:    scale,
+		Progress: func(int, float64, EvaluationWeights) { iterations++ },
+	})
+	require.NoError(t, err)
+
+	assert.Less(t, result.Loss, result.InitialLoss)
+	assert.Less(t, result.Loss, 1e-4)
+	assert.InDelta(t, target.ThreeInRow, result.Weights.ThreeInRow, 3)
+	assert.InDelta(t, target.TwoInRow, result.Weights.TwoInRow, 2)
+	assert.Equal(t, result.Iterations, iterations)
+	assert.Equal(t, len(positions), result.Positions)
+	assert.NoError(t, result.Weights.Validate())
+}
+
+func TestTuneEvaluationWeights_FitsScale(t *testing.T) {
+	positions := randomPositions(t, 300, 3)
+	for i := range positions {
+		score := positionFeatures(&positions[i].Board, positions[i].Player).score(BuiltinEvaluationWeights())
+		positions[i].Result = 1 / (1 + math.Exp(-float64(score)/400))
+	}
+
+	result, err := TuneEvaluationWeights(context.Background(), positions, BuiltinEvaluationWeights(), TuningConfig{MaxIterations: 1})
+	require.NoError(t, err)
+	assert.InEpsilon(t, 400, result.Scale, 0.05)
+}
+
+func TestTuneEvaluationWeights_Errors(t *testing.T) {
+	_, err := TuneEvaluationWeights(context.Background(), nil, BuiltinEvaluationWeights(), DefaultTuningConfig())
+	assert.Error(t, err)
+
+	positions := randomPositions(t, 10, 4)
+	_, err = TuneEvaluationWeights(context.Background(), positions, EvaluationWeights{}, DefaultTuningConfig())
+	assert.Error(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := TuneEvaluationWeights(ctx, positions, BuiltinEvaluationWeights(), DefaultTuningConfig())
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, BuiltinEvaluationWeights(), result.Weights)
+}
+
+func TestGenerateTuningPositions(t *testing.T) {
+	positions, err := GenerateTuningPositions(context.Background(), TuningDataConfig{
+		Positions:    20,
+		MinPly:       24,
+		MaxPly:       32,
+		Workers:      2,
+		SolveTimeout: 5 * time.Second,
+		Seed:         5,
+	})
+	require.NoError(t, err)
+	require.Len(t, positions, 20)
+
+	solver := NewSolver()
+	for _, position := range positions {
+		assert.True(t, isQuiet(&position.Board, position.Player))
+		score, err := solver.Solve(context.Background(), &position.Board, position.Player)
+		require.NoError(t, err)
+		assert.Equal(t, resultFromScore(score), position.Result)
+	}
+}
+
+func TestTuningPositionsFromGame(t *testing.T) {
+	// Red stacks column 0 while yellow stacks column 1; red wins on move 7
+	var moves []*models.Move
+	for i, col := range []int{0, 1, 0, 1, 0, 1, 0} {
+		player := models.PlayerColorRed
+		if i%2 == 1 {
+			player = models.PlayerColorYellow
+		}
+		moves = append(moves, &models.Move{Column: col, Player: player})
+	}
+	winner := models.PlayerColorRed
+
+	positions, err := TuningPositionsFromGame(moves, &winner, 2)
+	require.NoError(t, err)
+
+	// Plies 2-4 are quiet; from ply 5 on someone threatens to win
+	require.Len(t, positions, 3)
+	assert.Equal(t, models.PlayerColorRed, positions[0].Player)
+	assert.Equal(t, 1.0, positions[0].Result)
+	assert.Equal(t, models.PlayerColorYellow, positions[1].Player)
+	assert.Equal(t, 0.0, positions[1].Result)
+
+	drawn, err := TuningPositionsFromGame(moves[:3], nil, 0)
+	require.NoError(t, err)
+	for _, position := range drawn {
+		assert.Equal(t, 0.5, position.Result)
+	}
+
+	_, err = TuningPositionsFromGame(append(moves, &models.Move{Column: 9, Player: models.PlayerColorYellow}), &winner, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,90 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+var (
+	evaluationWeightsMu sync.RWMutex
+	evaluationWeights   = BuiltinEvaluationWeights()
+)
+
+// DefaultEvaluationWeights returns the weights the bots play with: the tuned
+// weights installed with UseEvaluationWeights, or the built-in ones
+func DefaultEvaluationWeights() EvaluationWeights {
+	evaluationWeightsMu.RLock()
+	defer evaluationWeightsMu.RUnlock()
+	return evaluationWeights
+}
+
+// UseEvaluationWeights makes bots created afterwards evaluate positions with
+// weights. It is meant to be called once at startup.
+func UseEvaluationWeights(weights EvaluationWeights) error {
+	if err := weights.Validate(); err != nil {
+		return err
+	}
+
+	evaluationWeightsMu.Lock()
+	evaluationWeights = weights
+	evaluationWeightsMu.Unlock()
+	return nil
+}
+
+// Validate checks that the weights rank features the way the search relies
+// on: nothing negative, and three in a row worth more than two
+func (w EvaluationWeights) Validate() error {
+	if w.ThreeInRow < 0 || w.TwoInRow < 0 || w.CenterBonus < 0 || w.ParityThreat < 0 {
+		return fmt.Errorf("evaluation weights cannot be negative: %+v", w)
+	}
+	if w.ThreeInRow <= w.TwoInRow {
+		return fmt.Errorf("three in a row must outweigh two in a row: %+v", w)
+	}
+	if w.ThreeInRow >= scoreWin/10 {
+		return fmt.Errorf("three in a row must stay well below a win: %+v", w)
+	}
+	if w.ParityThreat >= scoreWin/10 {
+		return fmt.Errorf("parity threats must stay well below a win: %+v", w)
+	}
+	return nil
+}
+
+// ReadEvaluationWeights reads weights written by WriteEvaluationWeights
+func ReadEvaluationWeights(r io.Reader) (EvaluationWeights, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	var weights EvaluationWeights
+	if err := decoder.Decode(&weights); err != nil {
+		return EvaluationWeights{}, fmt.Errorf("failed to read evaluation weights: %w", err)
+	}
+	if err := weights.Validate(); err != nil {
+		return EvaluationWeights{}, err
+	}
+	return weights, nil
+}
+
+// LoadEvaluationWeights reads a weights file such as one written by
+// cmd/tuneweights
+func LoadEvaluationWeights(path string) (EvaluationWeights, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return EvaluationWeights{}, fmt.Errorf("failed to open evaluation weights: %w", err)
+	}
+	defer file.Close()
+
+	return ReadEvaluationWeights(file)
+}
+
+// WriteEvaluationWeights writes weights as indented JSON
+func WriteEvaluationWeights(w io.Writer, weights EvaluationWeights) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(weights); err != nil {
+		return fmt.Errorf("failed to write evaluation weights: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package bot
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluationWeightsFileRoundTrip(t *testing.T) {
+	weights := EvaluationWeights{ThreeInRow: 140, TwoInRow: 7, CenterBonus: 5}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteEvaluationWeights(&buf, weights))
+	assert.Contains(t, buf.String(), `"threeInRow": 140`)
+
+	path := filepath.Join(t.TempDir(), "weights.json")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o644))
+	loaded, err := LoadEvaluationWeights(path)
+	require.NoError(t, err)
+	assert.Equal(t, weights, loaded)
+}
+
+func TestReadEvaluationWeights_RejectsBadFiles(t *testing.T) {
+	for _, content := range []string{
+		`not json`,
+		`{"threeInRow": 100, "twoInRow": 10, "centreBonus": 3}`,
+		`{"threeInRow": 10, "twoInRow": 20, "centerBonus": 3}`,
+		`{"threeInRow": 100, "twoInRow": -1, "centerBonus": 3}`,
+		`{"threeInRow": 50000, "twoInRow": 10, "centerBonus": 3}`,
+	} {
+		_, err := ReadEvaluationWeights(strings.NewReader(content))
+		assert.Error(t, err, content)
+	}
+
+	_, err := LoadEvaluationWeights(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestUseEvaluationWeights(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, UseEvaluationWeights(BuiltinEvaluationWeights())) })
+
+	tuned := EvaluationWeights{ThreeInRow: 150, TwoInRow: 12, CenterBonus: 6}
+	require.NoError(t, UseEvaluationWeights(tuned))
+	assert.Equal(t, tuned, DefaultEvaluationWeights())
+	assert.Equal(t, tuned, NewMinimaxBot().(*minimaxBot).weights)
+
+	assert.Error(t, UseEvaluationWeights(EvaluationWeights{ThreeInRow: 1, TwoInRow: 2}))
+	assert.Equal(t, tuned, DefaultEvaluationWeights(), "invalid weights are not installed")
+}
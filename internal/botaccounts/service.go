@@ -0,0 +1,229 @@
+package botaccounts
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"connect4-multiplayer/internal/bot"
+	"connect4-multiplayer/internal/database/repositories"
+	"connect4-multiplayer/pkg/models"
+)
+
+var (
+	// ErrBotNotFound is returned when a developer has no bot with a username
+	ErrBotNotFound = errors.New("bot account not found")
+	// ErrUsernameTaken is returned when a bot username is already in use
+	ErrUsernameTaken = errors.New("username is already taken")
+	// ErrInvalidUsername is returned for usernames that are malformed or
+	// reserved for the built-in bots
+	ErrInvalidUsername = errors.New("invalid bot username")
+	// ErrTooManyBots is returned when a developer has as many bots as allowed
+	ErrTooManyBots = errors.New("bot account limit reached")
+	// ErrInvalidToken is returned for tokens that belong to no bot account
+	ErrInvalidToken = errors.New("invalid bot token")
+)
+
+// tokenPrefix marks bot API tokens so they are easy to spot in leaks
+const tokenPrefix = "c4bot_"
+
+// usernamePattern matches the usernames players may pick
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{3,20}$`)
+
+// BotAccountService manages the accounts programs use to play over the bot API
+type BotAccountService interface {
+	// CreateBot registers a bot account for a developer and returns its API
+	// token, which is not stored and cannot be shown again
+	CreateBot(ctx context.Context, ownerID, username, description string) (*models.BotAccount, string, error)
+	// ListBots returns a developer's bot accounts
+	ListBots(ctx context.Context, ownerID string) ([]*models.BotAccount, error)
+	// RotateToken replaces a bot's API token, revoking the old one
+	RotateToken(ctx context.Context, ownerID, username string) (string, error)
+
+	// Authenticate returns the bot account an API token belongs to
+	Authenticate(ctx context.Context, token string) (*models.BotAccount, error)
+	// IsBotAccount reports whether a username belongs to a bot account
+	IsBotAccount(ctx context.Context, username string) (bool, error)
+	// Allow spends one of a bot's API messages and reports whether it was
+	// within the rate limit
+	Allow(username string) bool
+}
+
+// botAccountService implements BotAccountService interface
+type botAccountService struct {
+	repo    repositories.BotAccountRepository
+	config  *ServiceConfig
+	limiter *rateLimiter
+	logger  *slog.Logger
+}
+
+// ServiceConfig holds configuration for the bot account service
+type ServiceConfig struct {
+	// MaxBotsPerOwner caps the bot accounts one developer may create
+	MaxBotsPerOwner int
+	// MessagesPerSecond and MessageBurst rate limit each bot's API messages
+	MessagesPerSecond float64
+	MessageBurst      int
+	Logger            *slog.Logger
+}
+
+// DefaultServiceConfig returns default service configuration
+func DefaultServiceConfig() *ServiceConfig {
+	return &ServiceConfig{
+		MaxBotsPerOwner:   5,
+		MessagesPerSecond: 2,
+		MessageBurst:      10,
+		Logger:            slog.Default(),
+	}
+}
+
+// NewBotAccountService creates a new BotAccountService instance
+func NewBotAccountService(repo repositories.BotAccountRepository, config *ServiceConfig) BotAccountService {
+	if config == nil {
+		config = DefaultServiceConfig()
+	}
+	if config.MaxBotsPerOwner <= 0 {
+		config.MaxBotsPerOwner = 1
+	}
+	if config.MessagesPerSecond <= 0 {
+		config.MessagesPerSecond = 1
+	}
+	if config.MessageBurst <= 0 {
+		config.MessageBurst = 1
+	}
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+
+	return &botAccountService{
+		repo:    repo,
+		config:  config,
+		limiter: newRateLimiter(config.MessagesPerSecond, config.MessageBurst),
+		logger:  config.Logger.With("component", "bot-accounts"),
+	}
+}
+
+// CreateBot registers a bot account for a developer
+func (s *botAccountService) CreateBot(ctx context.Context, ownerID, username, description string) (*models.BotAccount, string, error) {
+	if ownerID == "" {
+		return nil, "", fmt.Errorf("owner ID cannot be empty")
+	}
+	if !usernamePattern.MatchString(username) || bot.IsBotUsername(username) || strings.EqualFold(username, "waiting") {
+		return nil, "", fmt.Errorf("%w: %q", ErrInvalidUsername, username)
+	}
+
+	owned, err := s.repo.ListByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(owned) >= s.config.MaxBotsPerOwner {
+		return nil, "", fmt.Errorf("%w: %d bots", ErrTooManyBots, len(owned))
+	}
+
+	token, hash, err := newToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	account := &models.BotAccount{
+		Username:    username,
+		OwnerID:     ownerID,
+		Description: description,
+		TokenHash:   hash,
+	}
+	created, err := s.repo.Create(ctx, account)
+	if err != nil {
+		return nil, "", err
+	}
+	if !created {
+		return nil, "", fmt.Errorf("%w: %s", ErrUsernameTaken, username)
+	}
+
+	s.logger.Info("bot account created", "username", username, "owner", ownerID)
+	return account, token, nil
+}
+
+// ListBots returns a developer's bot accounts
+func (s *botAccountService) ListBots(ctx context.Context, ownerID string) ([]*models.BotAccount, error) {
+	return s.repo.ListByOwner(ctx, ownerID)
+}
+
+// RotateToken replaces a bot's API token, revoking the old one
+func (s *botAccountService) RotateToken(ctx context.Context, ownerID, username string) (string, error) {
+	account, err := s.repo.GetByUsername(ctx, username)
+	if err != nil {
+		return "", err
+	}
+	if account == nil || account.OwnerID != ownerID {
+		return "", fmt.Errorf("%w: %s", ErrBotNotFound, username)
+	}
+
+	token, hash, err := newToken()
+	if err != nil {
+		return "", err
+	}
+	if err := s.repo.UpdateTokenHash(ctx, account.ID, hash); err != nil {
+		return "", err
+	}
+
+	s.logger.Info("bot token rotated", "username", username)
+	return token, nil
+}
+
+// Authenticate returns the bot account an API token belongs to and records
+// when it was last seen
+func (s *botAccountService) Authenticate(ctx context.Context, token string) (*models.BotAccount, error) {
+	if !strings.HasPrefix(token, tokenPrefix) {
+		return nil, ErrInvalidToken
+	}
+
+	account, err := s.repo.GetByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrInvalidToken
+	}
+
+	now := time.Now()
+	if err := s.repo.TouchLastSeen(ctx, account.ID, now); err != nil {
+		s.logger.Warn("failed to record bot last seen", "username", account.Username, "error", err)
+	} else {
+		account.LastSeenAt = &now
+	}
+	return account, nil
+}
+
+// IsBotAccount reports whether a username belongs to a bot account
+func (s *botAccountService) IsBotAccount(ctx context.Context, username string) (bool, error) {
+	account, err := s.repo.GetByUsername(ctx, username)
+	return account != nil, err
+}
+
+// Allow spends one of a bot's API messages
+func (s *botAccountService) Allow(username string) bool {
+	return s.limiter.allow(username)
+}
+
+// newToken generates an API token and the hash stored in its place
+func newToken() (string, string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate bot token: %w", err)
+	}
+	token := tokenPrefix + hex.EncodeToString(b)
+	return token, hashToken(token), nil
+}
+
+// hashToken returns the hex SHA-256 of a token
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,170 @@
+package botaccounts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"connect4-multiplayer/internal/database/repositories"
+	"connect4-multiplayer/pkg/models"
+)
+
+type BotAccountServiceTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	repo    repositories.BotAccountRepository
+	service BotAccountService
+	ctx     context.Context
+}
+
+func (suite *BotAccountServiceTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	suite.Require().NoError(err)
+	suite.Require().NoError(db.AutoMigrate(&models.Player{}, &models.PlayerStats{}, &models.GameSession{}, &models.BotAccount{}))
+
+	suite.db = db
+	suite.repo = repositories.NewBotAccountRepository(db)
+	config := DefaultServiceConfig()
+	config.MaxBotsPerOwner = 2
+	suite.service = NewBotAccountService(suite.repo, config)
+	suite.ctx = context.Background()
+}
+
+func (suite *BotAccountServiceTestSuite) TestCreateBot() {
+	account, token, err := suite.service.CreateBot(suite.ctx, "dev-1", "deepdrop", "alpha-beta, depth 12")
+	suite.Require().NoError(err)
+	suite.Equal("deepdrop", account.Username)
+	suite.Equal("dev-1", account.OwnerID)
+	suite.True(len(token) > len(tokenPrefix))
+	suite.NotContains(account.TokenHash, token)
+
+	// The bot gets a player and a stats row flagged as a bot
+	var player models.Player
+	suite.Require().NoError(suite.db.Where("username = ?", "deepdrop").First(&player).Error)
+	suite.True(player.IsBot)
+	var stats models.PlayerStats
+	suite.Require().NoError(suite.db.Where("username = ?", "deepdrop").First(&stats).Error)
+	suite.True(stats.IsBot)
+
+	isBot, err := suite.service.IsBotAccount(suite.ctx, "deepdrop")
+	suite.NoError(err)
+	suite.True(isBot)
+
+	isBot, err = suite.service.IsBotAccount(suite.ctx, "alice")
+	suite.NoError(err)
+	suite.False(isBot)
+}
+
+func (suite *BotAccountServiceTestSuite) TestCreateBotRejectsTakenUsernames() {
+	suite.Require().NoError(suite.db.Create(&models.Player{Username: "alice"}).Error)
+	_, _, err := suite.service.CreateBot(suite.ctx, "dev-1", "alice", "")
+	suite.ErrorIs(err, ErrUsernameTaken)
+
+	suite.Require().NoError(suite.db.Create(&models.GameSession{Player1: "guest42", Player2: "Bot_Easy", Status: models.StatusCompleted}).Error)
+	_, _, err = suite.service.CreateBot(suite.ctx, "dev-1", "guest42", "")
+	suite.ErrorIs(err, ErrUsernameTaken)
+
+	_, _, err = suite.service.CreateBot(suite.ctx, "dev-1", "deepdrop", "")
+	suite.Require().NoError(err)
+	_, _, err = suite.service.CreateBot(suite.ctx, "dev-2", "deepdrop", "")
+	suite.ErrorIs(err, ErrUsernameTaken)
+}
+
+func (suite *BotAccountServiceTestSuite) TestCreateBotRejectsInvalidUsernames() {
+	for _, username := range []string{"", "ab", "has space", "Bot_Hard", "bot_sneaky", "waiting", "waytoolongforausername1"} {
+		_, _, err := suite.service.CreateBot(suite.ctx, "dev-1", username, "")
+		suite.ErrorIs(err, ErrInvalidUsername, username)
+	}
+}
+
+func (suite *BotAccountServiceTestSuite) TestCreateBotEnforcesOwnerLimit() {
+	_, _, err := suite.service.CreateBot(suite.ctx, "dev-1", "first", "")
+	suite.Require().NoError(err)
+	_, _, err = suite.service.CreateBot(suite.ctx, "dev-1", "second", "")
+	suite.Require().NoError(err)
+
+	_, _, err = suite.service.CreateBot(suite.ctx, "dev-1", "third", "")
+	suite.ErrorIs(err, ErrTooManyBots)
+
+	// Other developers have their own allowance
+	_, _, err = suite.service.CreateBot(suite.ctx, "dev-2", "third", "")
+	suite.NoError(err)
+
+	bots, err := suite.service.ListBots(suite.ctx, "dev-1")
+	suite.NoError(err)
+	suite.Len(bots, 2)
+}
+
+func (suite *BotAccountServiceTestSuite) TestAuthenticate() {
+	_, token, err := suite.service.CreateBot(suite.ctx, "dev-1", "deepdrop", "")
+	suite.Require().NoError(err)
+
+	account, err := suite.service.Authenticate(suite.ctx, token)
+	suite.Require().NoError(err)
+	suite.Equal("deepdrop", account.Username)
+	suite.NotNil(account.LastSeenAt)
+
+	_, err = suite.service.Authenticate(suite.ctx, tokenPrefix+"nope")
+	suite.ErrorIs(err, ErrInvalidToken)
+	_, err = suite.service.Authenticate(suite.ctx, "")
+	suite.ErrorIs(err, ErrInvalidToken)
+}
+
+func (suite *BotAccountServiceTestSuite) TestRotateTokenRevokesOldToken() {
+	_, oldToken, err := suite.service.CreateBot(suite.ctx, "dev-1", "deepdrop", "")
+	suite.Require().NoError(err)
+
+	_, err = suite.service.RotateToken(suite.ctx, "dev-2", "deepdrop")
+	suite.ErrorIs(err, ErrBotNotFound)
+	_, err = suite.service.RotateToken(suite.ctx, "dev-1", "missing")
+	suite.ErrorIs(err, ErrBotNotFound)
+
+	newToken, err := suite.service.RotateToken(suite.ctx, "dev-1", "deepdrop")
+	suite.Require().NoError(err)
+	suite.NotEqual(oldToken, newToken)
+
+	_, err = suite.service.Authenticate(suite.ctx, oldToken)
+	suite.ErrorIs(err, ErrInvalidToken)
+	account, err := suite.service.Authenticate(suite.ctx, newToken)
+	suite.Require().NoError(err)
+	suite.Equal("deepdrop", account.Username)
+}
+
+func TestBotAccountServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(BotAccountServiceTestSuite))
+}
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	limiter := newRateLimiter(2, 3)
+	limiter.now = func() time.Time { return now }
+
+	// A fresh bucket allows a burst, then refuses
+	for i := 0; i < 3; i++ {
+		assert.True(t, limiter.allow("deepdrop"))
+	}
+	assert.False(t, limiter.allow("deepdrop"))
+
+	// Buckets are per account
+	assert.True(t, limiter.allow("other"))
+
+	// Half a second refills one token at two per second
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, limiter.allow("deepdrop"))
+	assert.False(t, limiter.allow("deepdrop"))
+
+	// Refills never exceed the burst
+	now = now.Add(time.Minute)
+	for i := 0; i < 3; i++ {
+		assert.True(t, limiter.allow("deepdrop"))
+	}
+	assert.False(t, limiter.allow("deepdrop"))
+}
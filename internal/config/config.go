@@ -17,6 +17,7 @@ type Config struct {
 	Kafka       KafkaConfig    `mapstructure:"kafka"`
 	Redis       RedisConfig    `mapstructure:"redis"`
 	Supabase    SupabaseConfig `mapstructure:"supabase"`
+	Bot         BotConfig      `mapstructure:"bot"`
 }
 
 // ServerConfig holds server configuration
@@ -60,6 +61,37 @@ type SupabaseConfig struct {
 	AnonKey    string `mapstructure:"anon_key"`
 }
 
+// BotConfig holds bot configuration
+type BotConfig struct {
+	OpeningBookPath string         `mapstructure:"opening_book_path"`
+	WeightsPath     string         `mapstructure:"weights_path"`
+	SearchWorkers   int            `mapstructure:"search_workers"`
+	MoveWorkers     int            `mapstructure:"move_workers"`
+	MoveDelayMs     int            `mapstructure:"move_delay_ms"`
+	Engines         []EngineConfig `mapstructure:"engines"`
+	API             BotAPIConfig   `mapstructure:"api"`
+}
+
+// BotAPIConfig limits the bot accounts developers play through the bot API
+type BotAPIConfig struct {
+	MaxAccountsPerOwner int     `mapstructure:"max_accounts_per_owner"`
+	MessagesPerSecond   float64 `mapstructure:"messages_per_second"`
+	MessageBurst        int     `mapstructure:"message_burst"`
+}
+
+// EngineConfig registers an external engine as a bot
+type EngineConfig struct {
+	Name        string            `mapstructure:"name"`
+	Path        string            `mapstructure:"path"`
+	Args        []string          `mapstructure:"args"`
+	Dir         string            `mapstructure:"dir"`
+	Env         []string          `mapstructure:"env"`
+	Options     map[string]string `mapstructure:"options"`
+	MoveTimeMs  int               `mapstructure:"move_time_ms"`
+	MaxRestarts int               `mapstructure:"max_restarts"`
+	Processes   int               `mapstructure:"processes"`
+}
+
 // Load loads configuration from environment variables and config files
 func Load() (*Config, error) {
 	// Load .env file first (if it exists)
@@ -115,6 +147,10 @@ func Load() (*Config, error) {
 	viper.BindEnv("server.read_timeout", "SERVER_READ_TIMEOUT")
 	viper.BindEnv("server.write_timeout", "SERVER_WRITE_TIMEOUT")
 
+	viper.BindEnv("bot.opening_book_path", "BOT_OPENING_BOOK_PATH")
+	viper.BindEnv("bot.weights_path", "BOT_WEIGHTS_PATH")
+	viper.BindEnv("bot.search_workers", "BOT_SEARCH_WORKERS")
+
 	viper.BindEnv("environment", "ENVIRONMENT")
 
 	var config Config
@@ -163,6 +199,16 @@ func setDefaults() {
 	viper.SetDefault("supabase.url", "")
 	viper.SetDefault("supabase.service_key", "")
 	viper.SetDefault("supabase.anon_key", "")
+
+	// Bot defaults (an empty path uses the built-in opening book)
+	viper.SetDefault("bot.opening_book_path", "")
+	viper.SetDefault("bot.weights_path", "")
+	viper.SetDefault("bot.search_workers", 0) // 0 uses half the CPUs
+	viper.SetDefault("bot.move_workers", 4)
+	viper.SetDefault("bot.move_delay_ms", 500)
+	viper.SetDefault("bot.api.max_accounts_per_owner", 5)
+	viper.SetDefault("bot.api.messages_per_second", 2)
+	viper.SetDefault("bot.api.message_burst", 10)
 }
 
 // validate validates the configuration
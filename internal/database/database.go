@@ -62,5 +62,13 @@ func AutoMigrate(db *gorm.DB) error {
 		&models.Move{},
 		&models.PlayerStats{},
 		&models.GameEvent{},
+		&models.PlayerAchievement{},
+		&models.Friendship{},
+		&models.PlayerBlock{},
+		&models.Notification{},
+		&models.GameReview{},
+		&models.Puzzle{},
+		&models.PuzzleAttempt{},
+		&models.BotAccount{},
 	)
 }
\ No newline at end of file
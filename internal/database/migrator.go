@@ -34,6 +34,14 @@ func (m *Migrator) Up() error {
 		&models.PlayerStats{},
 		&models.GameEvent{},
 		&models.AnalyticsSnapshot{},
+		&models.PlayerAchievement{},
+		&models.Friendship{},
+		&models.PlayerBlock{},
+		&models.Notification{},
+		&models.GameReview{},
+		&models.Puzzle{},
+		&models.PuzzleAttempt{},
+		&models.BotAccount{},
 	); err != nil {
 		return fmt.Errorf("failed to run auto-migrations: %w", err)
 	}
@@ -102,6 +110,14 @@ func (m *Migrator) executeSQLFile(filePath string) error {
 func (m *Migrator) Down() error {
 	// Drop tables in reverse order to handle foreign key constraints
 	tables := []interface{}{
+		&models.BotAccount{},
+		&models.PuzzleAttempt{},
+		&models.Puzzle{},
+		&models.GameReview{},
+		&models.Notification{},
+		&models.PlayerBlock{},
+		&models.Friendship{},
+		&models.PlayerAchievement{},
 		&models.GameEvent{},
 		&models.PlayerStats{},
 		&models.Move{},
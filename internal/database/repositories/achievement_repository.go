@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+// achievementRepository implements AchievementRepository interface
+type achievementRepository struct {
+	db *gorm.DB
+}
+
+// NewAchievementRepository creates a new AchievementRepository instance
+func NewAchievementRepository(db *gorm.DB) AchievementRepository {
+	return &achievementRepository{db: db}
+}
+
+// Unlock inserts an achievement unless the player already holds it.
+// The unique (username, achievement_id) index makes repeated unlocks a no-op.
+func (r *achievementRepository) Unlock(ctx context.Context, achievement *models.PlayerAchievement) (bool, error) {
+	if achievement == nil {
+		return false, fmt.Errorf("achievement cannot be nil")
+	}
+	if achievement.Username == "" || achievement.AchievementID == "" {
+		return false, fmt.Errorf("username and achievement ID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(achievement)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to unlock achievement: %w", result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// GetByUsername retrieves all achievements unlocked by a player
+func (r *achievementRepository) GetByUsername(ctx context.Context, username string) ([]*models.PlayerAchievement, error) {
+	if username == "" {
+		return nil, fmt.Errorf("username cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var achievements []*models.PlayerAchievement
+	err := r.db.WithContext(ctx).
+		Where("username = ?", username).
+		Order("unlocked_at ASC").
+		Find(&achievements).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get achievements by username: %w", err)
+	}
+
+	return achievements, nil
+}
+
+// HasAchievement checks whether a player already holds an achievement
+func (r *achievementRepository) HasAchievement(ctx context.Context, username, achievementID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.PlayerAchievement{}).
+		Where("username = ? AND achievement_id = ?", username, achievementID).
+		Count(&count).Error
+
+	if err != nil {
+		return false, fmt.Errorf("failed to check achievement: %w", err)
+	}
+
+	return count > 0, nil
+}
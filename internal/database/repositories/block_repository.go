@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+// blockRepository implements BlockRepository interface
+type blockRepository struct {
+	db *gorm.DB
+}
+
+// NewBlockRepository creates a new BlockRepository instance
+func NewBlockRepository(db *gorm.DB) BlockRepository {
+	return &blockRepository{db: db}
+}
+
+// Create records a block; blocking the same player twice is a no-op
+func (r *blockRepository) Create(ctx context.Context, block *models.PlayerBlock) error {
+	if block == nil {
+		return fmt.Errorf("player block cannot be nil")
+	}
+	if block.Blocker == "" || block.Blocked == "" {
+		return fmt.Errorf("blocker and blocked cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(block).Error
+	if err != nil {
+		return fmt.Errorf("failed to create player block: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a block
+func (r *blockRepository) Delete(ctx context.Context, blocker, blocked string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).
+		Where("blocker = ? AND blocked = ?", blocker, blocked).
+		Delete(&models.PlayerBlock{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete player block: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("player block not found")
+	}
+
+	return nil
+}
+
+// ListByBlocker retrieves the players blocked by a player
+func (r *blockRepository) ListByBlocker(ctx context.Context, blocker string) ([]*models.PlayerBlock, error) {
+	if blocker == "" {
+		return nil, fmt.Errorf("blocker cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var blocks []*models.PlayerBlock
+	err := r.db.WithContext(ctx).
+		Where("blocker = ?", blocker).
+		Order("created_at DESC").
+		Find(&blocks).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list player blocks: %w", err)
+	}
+
+	return blocks, nil
+}
+
+// ExistsBetween checks whether either player has blocked the other
+func (r *blockRepository) ExistsBetween(ctx context.Context, playerA, playerB string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.PlayerBlock{}).
+		Where("(blocker = ? AND blocked = ?) OR (blocker = ? AND blocked = ?)", playerA, playerB, playerB, playerA).
+		Count(&count).Error
+
+	if err != nil {
+		return false, fmt.Errorf("failed to check player block: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// ListAmong retrieves every block where both players are among usernames
+func (r *blockRepository) ListAmong(ctx context.Context, usernames []string) ([]*models.PlayerBlock, error) {
+	if len(usernames) < 2 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var blocks []*models.PlayerBlock
+	err := r.db.WithContext(ctx).
+		Where("blocker IN ? AND blocked IN ?", usernames, usernames).
+		Find(&blocks).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list player blocks: %w", err)
+	}
+
+	return blocks, nil
+}
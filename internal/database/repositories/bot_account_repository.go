@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+// botAccountRepository implements BotAccountRepository interface
+type botAccountRepository struct {
+	db *gorm.DB
+}
+
+// NewBotAccountRepository creates a new BotAccountRepository instance
+func NewBotAccountRepository(db *gorm.DB) BotAccountRepository {
+	return &botAccountRepository{db: db}
+}
+
+// Create stores a bot account together with its player and stats rows,
+// both flagged as a bot. It reports false and changes nothing if the
+// username already belongs to a player, has stats or appears in a game.
+func (r *botAccountRepository) Create(ctx context.Context, account *models.BotAccount) (bool, error) {
+	if account == nil {
+		return false, fmt.Errorf("bot account cannot be nil")
+	}
+	if account.Username == "" {
+		return false, fmt.Errorf("bot username cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	created := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		taken, err := usernameInUse(tx, account.Username)
+		if err != nil || taken {
+			return err
+		}
+
+		player := &models.Player{Username: account.Username, IsGuest: false, IsBot: true}
+		if err := tx.Create(player).Error; err != nil {
+			return err
+		}
+		stats := &models.PlayerStats{Username: account.Username, IsBot: true, LastPlayed: time.Now()}
+		if err := tx.Create(stats).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(account).Error; err != nil {
+			return err
+		}
+
+		created = true
+		return nil
+	})
+
+	if err != nil {
+		return false, fmt.Errorf("failed to create bot account: %w", err)
+	}
+
+	return created, nil
+}
+
+// usernameInUse reports whether any player, stats row or game uses username
+func usernameInUse(tx *gorm.DB, username string) (bool, error) {
+	var count int64
+	if err := tx.Model(&models.Player{}).Where("username = ?", username).Count(&count).Error; err != nil || count > 0 {
+		return count > 0, err
+	}
+	if err := tx.Model(&models.PlayerStats{}).Where("username = ?", username).Count(&count).Error; err != nil || count > 0 {
+		return count > 0, err
+	}
+	err := tx.Model(&models.GameSession{}).
+		Where("player1 = ? OR player2 = ?", username, username).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetByUsername retrieves a bot account by username, or nil if there is none
+func (r *botAccountRepository) GetByUsername(ctx context.Context, username string) (*models.BotAccount, error) {
+	return r.first(ctx, "username = ?", username)
+}
+
+// GetByTokenHash retrieves the bot account with the given token hash, or nil
+// if there is none
+func (r *botAccountRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.BotAccount, error) {
+	return r.first(ctx, "token_hash = ?", tokenHash)
+}
+
+// first retrieves the bot account matching the condition, or nil
+func (r *botAccountRepository) first(ctx context.Context, query string, value string) (*models.BotAccount, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var account models.BotAccount
+	err := r.db.WithContext(ctx).Where(query, value).First(&account).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get bot account: %w", err)
+	}
+
+	return &account, nil
+}
+
+// ListByOwner retrieves a developer's bot accounts, oldest first
+func (r *botAccountRepository) ListByOwner(ctx context.Context, ownerID string) ([]*models.BotAccount, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var accounts []*models.BotAccount
+	err := r.db.WithContext(ctx).
+		Where("owner_id = ?", ownerID).
+		Order("created_at ASC").
+		Find(&accounts).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bot accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+// UpdateTokenHash replaces a bot account's token hash
+func (r *botAccountRepository) UpdateTokenHash(ctx context.Context, id, tokenHash string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	err := r.db.WithContext(ctx).
+		Model(&models.BotAccount{}).
+		Where("id = ?", id).
+		Update("token_hash", tokenHash).Error
+
+	if err != nil {
+		return fmt.Errorf("failed to update bot token: %w", err)
+	}
+
+	return nil
+}
+
+// TouchLastSeen records when a bot account last connected
+func (r *botAccountRepository) TouchLastSeen(ctx context.Context, id string, at time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	err := r.db.WithContext(ctx).
+		Model(&models.BotAccount{}).
+		Where("id = ?", id).
+		Update("last_seen_at", at).Error
+
+	if err != nil {
+		return fmt.Errorf("failed to update bot last seen: %w", err)
+	}
+
+	return nil
+}
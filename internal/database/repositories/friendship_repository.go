@@ -0,0 +1,224 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+// friendshipRepository implements FriendshipRepository interface
+type friendshipRepository struct {
+	db *gorm.DB
+}
+
+// NewFriendshipRepository creates a new FriendshipRepository instance
+func NewFriendshipRepository(db *gorm.DB) FriendshipRepository {
+	return &friendshipRepository{db: db}
+}
+
+// Create creates a new friendship
+func (r *friendshipRepository) Create(ctx context.Context, friendship *models.Friendship) error {
+	if friendship == nil {
+		return fmt.Errorf("friendship cannot be nil")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := r.db.WithContext(ctx).Create(friendship).Error; err != nil {
+		return fmt.Errorf("failed to create friendship: %w", err)
+	}
+
+	return nil
+}
+
+// Request records a pending friend request from requester to addressee, or
+// accepts a pending request the other way, in one transaction. It returns an
+// existing friendship unchanged, and false, when the players are friends or
+// requester has already asked.
+func (r *friendshipRepository) Request(ctx context.Context, requester, addressee string) (*models.Friendship, bool, error) {
+	if requester == "" || addressee == "" {
+		return nil, false, fmt.Errorf("player IDs cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var friendship *models.Friendship
+	changed := false
+	request := func(tx *gorm.DB) error {
+		friendship, changed = nil, false
+
+		var existing models.Friendship
+		err := tx.Where("(requester = ? AND addressee = ?) OR (requester = ? AND addressee = ?)", requester, addressee, addressee, requester).
+			First(&existing).Error
+		switch {
+		case err == gorm.ErrRecordNotFound:
+			friendship = &models.Friendship{Requester: requester, Addressee: addressee, Status: models.FriendshipPending}
+			if err := tx.Create(friendship).Error; err != nil {
+				return err
+			}
+			changed = true
+			return nil
+		case err != nil:
+			return err
+		}
+
+		friendship = &existing
+		if existing.Status != models.FriendshipPending || existing.Requester == requester {
+			return nil
+		}
+
+		// Crossed requests: the other player asked first
+		now := time.Now()
+		existing.Status = models.FriendshipAccepted
+		existing.AcceptedAt = &now
+		if err := tx.Save(&existing).Error; err != nil {
+			return err
+		}
+		changed = true
+		return nil
+	}
+
+	// Players asking each other at once race to insert the pair; the loser
+	// finds the winner's request on its second try and accepts it
+	err := r.db.WithContext(ctx).Transaction(request)
+	if err != nil {
+		err = r.db.WithContext(ctx).Transaction(request)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to request friendship: %w", err)
+	}
+
+	return friendship, changed, nil
+}
+
+// GetByID retrieves a friendship by ID
+func (r *friendshipRepository) GetByID(ctx context.Context, id string) (*models.Friendship, error) {
+	if id == "" {
+		return nil, fmt.Errorf("friendship ID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var friendship models.Friendship
+	err := r.db.WithContext(ctx).First(&friendship, "id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("friendship not found")
+		}
+		return nil, fmt.Errorf("failed to get friendship by ID: %w", err)
+	}
+
+	return &friendship, nil
+}
+
+// GetBetween retrieves the friendship between two players in either direction.
+// It returns nil without an error when the players are not related.
+func (r *friendshipRepository) GetBetween(ctx context.Context, playerA, playerB string) (*models.Friendship, error) {
+	if playerA == "" || playerB == "" {
+		return nil, fmt.Errorf("player IDs cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var friendship models.Friendship
+	err := r.db.WithContext(ctx).
+		Where("(requester = ? AND addressee = ?) OR (requester = ? AND addressee = ?)", playerA, playerB, playerB, playerA).
+		First(&friendship).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get friendship: %w", err)
+	}
+
+	return &friendship, nil
+}
+
+// Update updates an existing friendship
+func (r *friendshipRepository) Update(ctx context.Context, friendship *models.Friendship) error {
+	if friendship == nil {
+		return fmt.Errorf("friendship cannot be nil")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := r.db.WithContext(ctx).Save(friendship).Error; err != nil {
+		return fmt.Errorf("failed to update friendship: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes a friendship by ID
+func (r *friendshipRepository) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("friendship ID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Delete(&models.Friendship{}, "id = ?", id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete friendship: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("friendship not found")
+	}
+
+	return nil
+}
+
+// ListFriends retrieves accepted friendships involving a player
+func (r *friendshipRepository) ListFriends(ctx context.Context, username string) ([]*models.Friendship, error) {
+	if username == "" {
+		return nil, fmt.Errorf("username cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var friendships []*models.Friendship
+	err := r.db.WithContext(ctx).
+		Where("status = ?", models.FriendshipAccepted).
+		Where("requester = ? OR addressee = ?", username, username).
+		Order("accepted_at DESC").
+		Find(&friendships).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list friends: %w", err)
+	}
+
+	return friendships, nil
+}
+
+// ListPendingRequests retrieves friend requests waiting for a player's answer
+func (r *friendshipRepository) ListPendingRequests(ctx context.Context, username string) ([]*models.Friendship, error) {
+	if username == "" {
+		return nil, fmt.Errorf("username cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var friendships []*models.Friendship
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND addressee = ?", models.FriendshipPending, username).
+		Order("created_at DESC").
+		Find(&friendships).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending friend requests: %w", err)
+	}
+
+	return friendships, nil
+}
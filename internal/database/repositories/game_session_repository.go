@@ -175,6 +175,29 @@ func (r *gameSessionRepository) GetGamesByPlayer(ctx context.Context, playerID s
 	return sessions, nil
 }
 
+// GetGamesBetweenPlayers retrieves completed games played between two players, most recent first
+func (r *gameSessionRepository) GetGamesBetweenPlayers(ctx context.Context, playerA, playerB string) ([]*models.GameSession, error) {
+	if playerA == "" || playerB == "" {
+		return nil, fmt.Errorf("player IDs cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var sessions []*models.GameSession
+	err := r.db.WithContext(ctx).
+		Where("status = ?", models.StatusCompleted).
+		Where("(player1 = ? AND player2 = ?) OR (player1 = ? AND player2 = ?)", playerA, playerB, playerB, playerA).
+		Order("created_at DESC").
+		Find(&sessions).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get games between players: %w", err)
+	}
+
+	return sessions, nil
+}
+
 // GetGameHistory retrieves completed games with pagination
 func (r *gameSessionRepository) GetGameHistory(ctx context.Context, limit, offset int) ([]*models.GameSession, error) {
 	if limit <= 0 {
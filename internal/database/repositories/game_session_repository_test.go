@@ -65,6 +65,34 @@ func (suite *GameSessionRepositoryTestSuite) TestCreate_Success() {
 	assert.Equal(suite.T(), gameSession.Player2, retrieved.Player2)
 }
 
+func (suite *GameSessionRepositoryTestSuite) TestCreate_KeepsHandicapDiscs() {
+	ctx := context.Background()
+	gameSession := &models.GameSession{
+		ID:          "test-game-handicap",
+		Player1:     "player1",
+		Player2:     "player2",
+		CurrentTurn: models.PlayerColorRed,
+		Status:      models.StatusInProgress,
+		Handicap:    &models.Handicap{Player: models.PlayerColorRed, Discs: []int{3, 3}},
+	}
+
+	err := suite.repo.Create(ctx, gameSession)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), models.PlayerColorRed, gameSession.Board.Grid[1][3])
+
+	retrieved, err := suite.repo.GetByID(ctx, gameSession.ID)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), gameSession.Handicap, retrieved.Handicap)
+	assert.Equal(suite.T(), 2, retrieved.Board.Height[3])
+
+	// Even games store no handicap
+	evenGame := &models.GameSession{ID: "test-game-even", Player1: "player1", Player2: "player2"}
+	suite.Require().NoError(suite.repo.Create(ctx, evenGame))
+	retrieved, err = suite.repo.GetByID(ctx, evenGame.ID)
+	suite.Require().NoError(err)
+	assert.Nil(suite.T(), retrieved.Handicap)
+}
+
 func (suite *GameSessionRepositoryTestSuite) TestCreate_NilGameSession() {
 	ctx := context.Background()
 	err := suite.repo.Create(ctx, nil)
@@ -255,6 +283,37 @@ func (suite *GameSessionRepositoryTestSuite) TestGetGameHistory_Success() {
 	assert.GreaterOrEqual(suite.T(), completedCount, 1)
 }
 
+func (suite *GameSessionRepositoryTestSuite) TestGetGamesBetweenPlayers_Success() {
+	ctx := context.Background()
+
+	gameSessions := []*models.GameSession{
+		{ID: "h2h-1", Player1: "alice", Player2: "bobby", CurrentTurn: models.PlayerColorRed, Status: models.StatusCompleted, Board: models.NewBoard()},
+		{ID: "h2h-2", Player1: "bobby", Player2: "alice", CurrentTurn: models.PlayerColorRed, Status: models.StatusCompleted, Board: models.NewBoard()},
+		{ID: "h2h-3", Player1: "alice", Player2: "bobby", CurrentTurn: models.PlayerColorRed, Status: models.StatusInProgress, Board: models.NewBoard()},
+		{ID: "h2h-4", Player1: "alice", Player2: "carol", CurrentTurn: models.PlayerColorRed, Status: models.StatusCompleted, Board: models.NewBoard()},
+	}
+
+	for _, gameSession := range gameSessions {
+		err := suite.db.Create(gameSession).Error
+		suite.Require().NoError(err)
+	}
+
+	games, err := suite.repo.GetGamesBetweenPlayers(ctx, "alice", "bobby")
+	assert.NoError(suite.T(), err)
+
+	ids := make([]string, 0, len(games))
+	for _, game := range games {
+		ids = append(ids, game.ID)
+	}
+	assert.ElementsMatch(suite.T(), []string{"h2h-1", "h2h-2"}, ids)
+}
+
+func (suite *GameSessionRepositoryTestSuite) TestGetGamesBetweenPlayers_EmptyPlayer() {
+	ctx := context.Background()
+	_, err := suite.repo.GetGamesBetweenPlayers(ctx, "alice", "")
+	assert.Error(suite.T(), err)
+}
+
 func TestGameSessionRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(GameSessionRepositoryTestSuite))
 }
\ No newline at end of file
@@ -27,6 +27,7 @@ type GameSessionRepository interface {
 	Delete(ctx context.Context, id string) error
 	GetActiveGames(ctx context.Context) ([]*models.GameSession, error)
 	GetGamesByPlayer(ctx context.Context, playerID string) ([]*models.GameSession, error)
+	GetGamesBetweenPlayers(ctx context.Context, playerA, playerB string) ([]*models.GameSession, error)
 	GetGameHistory(ctx context.Context, limit, offset int) ([]*models.GameSession, error)
 
 	// Optimized queries for active session lookups
@@ -47,6 +48,7 @@ type PlayerStatsRepository interface {
 	Update(ctx context.Context, stats *models.PlayerStats) error
 	Delete(ctx context.Context, id string) error
 	GetLeaderboard(ctx context.Context, limit int) ([]*models.PlayerStats, error)
+	GetBotLeaderboard(ctx context.Context, limit int) ([]*models.PlayerStats, error)
 	UpdateGameStats(ctx context.Context, username string, won bool, gameDuration int) error
 }
 
@@ -67,3 +69,76 @@ type GameEventRepository interface {
 	GetByEventType(ctx context.Context, eventType models.EventType, limit, offset int) ([]*models.GameEvent, error)
 	GetEventsByTimeRange(ctx context.Context, start, end string, limit, offset int) ([]*models.GameEvent, error)
 }
+
+// AchievementRepository defines the interface for player achievement operations
+type AchievementRepository interface {
+	// Unlock records an achievement and reports whether it was newly unlocked
+	Unlock(ctx context.Context, achievement *models.PlayerAchievement) (bool, error)
+	GetByUsername(ctx context.Context, username string) ([]*models.PlayerAchievement, error)
+	HasAchievement(ctx context.Context, username, achievementID string) (bool, error)
+}
+
+// FriendshipRepository defines the interface for friendship operations
+type FriendshipRepository interface {
+	Create(ctx context.Context, friendship *models.Friendship) error
+	Request(ctx context.Context, requester, addressee string) (*models.Friendship, bool, error)
+	GetByID(ctx context.Context, id string) (*models.Friendship, error)
+	GetBetween(ctx context.Context, playerA, playerB string) (*models.Friendship, error)
+	Update(ctx context.Context, friendship *models.Friendship) error
+	Delete(ctx context.Context, id string) error
+	ListFriends(ctx context.Context, username string) ([]*models.Friendship, error)
+	ListPendingRequests(ctx context.Context, username string) ([]*models.Friendship, error)
+}
+
+// BlockRepository defines the interface for player block list operations
+type BlockRepository interface {
+	Create(ctx context.Context, block *models.PlayerBlock) error
+	Delete(ctx context.Context, blocker, blocked string) error
+	ListByBlocker(ctx context.Context, blocker string) ([]*models.PlayerBlock, error)
+	ExistsBetween(ctx context.Context, playerA, playerB string) (bool, error)
+	ListAmong(ctx context.Context, usernames []string) ([]*models.PlayerBlock, error)
+}
+
+// NotificationRepository defines the interface for player notification operations
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *models.Notification) error
+	ListByUsername(ctx context.Context, username string, unreadOnly bool, limit, offset int) ([]*models.Notification, error)
+	CountUnread(ctx context.Context, username string) (int64, error)
+	// MarkRead marks one of the player's notifications as read and reports whether it exists
+	MarkRead(ctx context.Context, username, id string) (bool, error)
+	MarkAllRead(ctx context.Context, username string) (int64, error)
+}
+
+// ReviewRepository defines the interface for post-game review operations
+type ReviewRepository interface {
+	// CreatePending records a game awaiting review and reports whether it was new
+	CreatePending(ctx context.Context, gameID string) (bool, error)
+	Save(ctx context.Context, review *models.GameReview) error
+	GetByGameID(ctx context.Context, gameID string) (*models.GameReview, error)
+	ListPending(ctx context.Context, limit int) ([]*models.GameReview, error)
+}
+
+// PuzzleRepository defines the interface for puzzle operations
+type PuzzleRepository interface {
+	// Create stores a puzzle and reports whether its game position was new
+	Create(ctx context.Context, puzzle *models.Puzzle) (bool, error)
+	GetByID(ctx context.Context, id string) (*models.Puzzle, error)
+	// NextForPlayer returns the untried puzzle rated closest to rating
+	NextForPlayer(ctx context.Context, username string, rating float64) (*models.Puzzle, error)
+	// RecordAttempt stores a first attempt and reports whether it was one
+	RecordAttempt(ctx context.Context, attempt *models.PuzzleAttempt, ratingDelta float64) (bool, error)
+	GetAttempt(ctx context.Context, username, puzzleID string) (*models.PuzzleAttempt, error)
+	LatestAttempt(ctx context.Context, username string) (*models.PuzzleAttempt, error)
+}
+
+// BotAccountRepository defines the interface for bot account operations
+type BotAccountRepository interface {
+	// Create stores the account with its flagged player and stats rows and
+	// reports whether the username was free
+	Create(ctx context.Context, account *models.BotAccount) (bool, error)
+	GetByUsername(ctx context.Context, username string) (*models.BotAccount, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.BotAccount, error)
+	ListByOwner(ctx context.Context, ownerID string) ([]*models.BotAccount, error)
+	UpdateTokenHash(ctx context.Context, id, tokenHash string) error
+	TouchLastSeen(ctx context.Context, id string, at time.Time) error
+}
@@ -13,22 +13,36 @@ type Manager struct {
 	db *gorm.DB
 
 	// Repository instances
-	Player      PlayerRepository
-	GameSession GameSessionRepository
-	PlayerStats PlayerStatsRepository
-	Move        MoveRepository
-	GameEvent   GameEventRepository
+	Player       PlayerRepository
+	GameSession  GameSessionRepository
+	PlayerStats  PlayerStatsRepository
+	Move         MoveRepository
+	GameEvent    GameEventRepository
+	Achievement  AchievementRepository
+	Friendship   FriendshipRepository
+	Block        BlockRepository
+	Notification NotificationRepository
+	Review       ReviewRepository
+	Puzzle       PuzzleRepository
+	BotAccount   BotAccountRepository
 }
 
 // NewManager creates a new repository manager with all repositories
 func NewManager(db *gorm.DB) *Manager {
 	return &Manager{
-		db:          db,
-		Player:      NewPlayerRepository(db),
-		GameSession: NewGameSessionRepository(db),
-		PlayerStats: NewPlayerStatsRepository(db),
-		Move:        NewMoveRepository(db),
-		GameEvent:   NewGameEventRepository(db),
+		db:           db,
+		Player:       NewPlayerRepository(db),
+		GameSession:  NewGameSessionRepository(db),
+		PlayerStats:  NewPlayerStatsRepository(db),
+		Move:         NewMoveRepository(db),
+		GameEvent:    NewGameEventRepository(db),
+		Achievement:  NewAchievementRepository(db),
+		Friendship:   NewFriendshipRepository(db),
+		Block:        NewBlockRepository(db),
+		Notification: NewNotificationRepository(db),
+		Review:       NewReviewRepository(db),
+		Puzzle:       NewPuzzleRepository(db),
+		BotAccount:   NewBotAccountRepository(db),
 	}
 }
 
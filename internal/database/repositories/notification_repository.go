@@ -0,0 +1,143 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+// notificationRepository implements NotificationRepository interface
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates a new NotificationRepository instance
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+// Create stores a new notification
+func (r *notificationRepository) Create(ctx context.Context, notification *models.Notification) error {
+	if notification == nil {
+		return fmt.Errorf("notification cannot be nil")
+	}
+	if notification.Username == "" {
+		return fmt.Errorf("notification username cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := r.db.WithContext(ctx).Create(notification).Error; err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	return nil
+}
+
+// ListByUsername retrieves a player's notifications, newest first
+func (r *notificationRepository) ListByUsername(ctx context.Context, username string, unreadOnly bool, limit, offset int) ([]*models.Notification, error) {
+	if username == "" {
+		return nil, fmt.Errorf("username cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	query := r.db.WithContext(ctx).Where("username = ?", username)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var notifications []*models.Notification
+	err := query.
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&notifications).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// CountUnread counts a player's unread notifications
+func (r *notificationRepository) CountUnread(ctx context.Context, username string) (int64, error) {
+	if username == "" {
+		return 0, fmt.Errorf("username cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Notification{}).
+		Where("username = ? AND read_at IS NULL", username).
+		Count(&count).Error
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return count, nil
+}
+
+// MarkRead marks a notification as read. Reading an already read
+// notification is a no-op that still reports it as found.
+func (r *notificationRepository) MarkRead(ctx context.Context, username, id string) (bool, error) {
+	if username == "" || id == "" {
+		return false, fmt.Errorf("username and notification ID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Notification{}).
+		Where("id = ? AND username = ?", id, username).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to get notification: %w", err)
+	}
+	if count == 0 {
+		return false, nil
+	}
+
+	err = r.db.WithContext(ctx).
+		Model(&models.Notification{}).
+		Where("id = ? AND username = ? AND read_at IS NULL", id, username).
+		Update("read_at", time.Now()).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+
+	return true, nil
+}
+
+// MarkAllRead marks all of a player's notifications as read and returns how many changed
+func (r *notificationRepository) MarkAllRead(ctx context.Context, username string) (int64, error) {
+	if username == "" {
+		return 0, fmt.Errorf("username cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).
+		Model(&models.Notification{}).
+		Where("username = ? AND read_at IS NULL", username).
+		Update("read_at", time.Now())
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to mark notifications as read: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
@@ -139,8 +139,18 @@ func (r *playerStatsRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-// GetLeaderboard retrieves top players sorted by wins
+// GetLeaderboard retrieves top players sorted by wins, leaving out bot accounts
 func (r *playerStatsRepository) GetLeaderboard(ctx context.Context, limit int) ([]*models.PlayerStats, error) {
+	return r.leaderboard(ctx, limit, false)
+}
+
+// GetBotLeaderboard retrieves top bot accounts sorted by wins
+func (r *playerStatsRepository) GetBotLeaderboard(ctx context.Context, limit int) ([]*models.PlayerStats, error) {
+	return r.leaderboard(ctx, limit, true)
+}
+
+// leaderboard ranks either human players or bot accounts by wins
+func (r *playerStatsRepository) leaderboard(ctx context.Context, limit int, bots bool) ([]*models.PlayerStats, error) {
 	if limit <= 0 {
 		limit = 10
 	}
@@ -150,7 +160,7 @@ func (r *playerStatsRepository) GetLeaderboard(ctx context.Context, limit int) (
 
 	var stats []*models.PlayerStats
 	err := r.db.WithContext(ctx).
-		Where("games_played > 0").
+		Where("games_played > 0 AND is_bot = ?", bots).
 		Order("games_won DESC, win_rate DESC, games_played DESC").
 		Limit(limit).
 		Find(&stats).Error
@@ -182,6 +182,29 @@ func (suite *PlayerStatsRepositoryTestSuite) TestGetLeaderboard_Success() {
 	}
 }
 
+func (suite *PlayerStatsRepositoryTestSuite) TestGetBotLeaderboard_SeparatesBots() {
+	ctx := context.Background()
+
+	statsData := []*models.PlayerStats{
+		{ID: "test-stats-7", Username: "human", GamesPlayed: 10, GamesWon: 5, LastPlayed: time.Now()},
+		{ID: "test-stats-8", Username: "deepdrop", GamesPlayed: 10, GamesWon: 9, IsBot: true, LastPlayed: time.Now()},
+	}
+	for _, stats := range statsData {
+		suite.Require().NoError(suite.db.Create(stats).Error)
+	}
+
+	players, err := suite.repo.GetLeaderboard(ctx, 10)
+	suite.Require().NoError(err)
+	for _, stats := range players {
+		assert.NotEqual(suite.T(), "deepdrop", stats.Username)
+	}
+
+	bots, err := suite.repo.GetBotLeaderboard(ctx, 10)
+	suite.Require().NoError(err)
+	suite.Require().Len(bots, 1)
+	assert.Equal(suite.T(), "deepdrop", bots[0].Username)
+}
+
 func (suite *PlayerStatsRepositoryTestSuite) TestUpdateGameStats_NewPlayer() {
 	ctx := context.Background()
 	
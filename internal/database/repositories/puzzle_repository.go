@@ -0,0 +1,187 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+// puzzleRepository implements PuzzleRepository interface
+type puzzleRepository struct {
+	db *gorm.DB
+}
+
+// NewPuzzleRepository creates a new PuzzleRepository instance
+func NewPuzzleRepository(db *gorm.DB) PuzzleRepository {
+	return &puzzleRepository{db: db}
+}
+
+// Create stores a puzzle unless its game position already is one, and
+// reports whether it was created
+func (r *puzzleRepository) Create(ctx context.Context, puzzle *models.Puzzle) (bool, error) {
+	if puzzle == nil {
+		return false, fmt.Errorf("puzzle cannot be nil")
+	}
+	if puzzle.GameID == "" {
+		return false, fmt.Errorf("puzzle game ID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(puzzle)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to create puzzle: %w", result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// GetByID retrieves a puzzle by ID, or nil if there is none
+func (r *puzzleRepository) GetByID(ctx context.Context, id string) (*models.Puzzle, error) {
+	if id == "" {
+		return nil, fmt.Errorf("puzzle ID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var puzzle models.Puzzle
+	err := r.db.WithContext(ctx).First(&puzzle, "id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get puzzle: %w", err)
+	}
+
+	return &puzzle, nil
+}
+
+// NextForPlayer retrieves the puzzle the player has not tried whose rating
+// is closest to the given one, or nil if the player has tried them all
+func (r *puzzleRepository) NextForPlayer(ctx context.Context, username string, rating float64) (*models.Puzzle, error) {
+	if username == "" {
+		return nil, fmt.Errorf("username cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	attempted := r.db.Model(&models.PuzzleAttempt{}).
+		Select("puzzle_id").
+		Where("username = ?", username)
+
+	var puzzles []*models.Puzzle
+	err := r.db.WithContext(ctx).
+		Where("id NOT IN (?)", attempted).
+		Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "ABS(rating - ?), created_at",
+			Vars:               []interface{}{rating},
+			WithoutParentheses: true,
+		}}).
+		Limit(1).
+		Find(&puzzles).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find next puzzle: %w", err)
+	}
+	if len(puzzles) == 0 {
+		return nil, nil
+	}
+
+	return puzzles[0], nil
+}
+
+// RecordAttempt stores a player's first attempt at a puzzle and moves the
+// puzzle's rating by ratingDelta. It reports false and changes nothing if
+// the player already tried the puzzle.
+func (r *puzzleRepository) RecordAttempt(ctx context.Context, attempt *models.PuzzleAttempt, ratingDelta float64) (bool, error) {
+	if attempt == nil {
+		return false, fmt.Errorf("attempt cannot be nil")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	recorded := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(attempt)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		solves := 0
+		if attempt.Solved {
+			solves = 1
+		}
+		result = tx.Model(&models.Puzzle{}).
+			Where("id = ?", attempt.PuzzleID).
+			Updates(map[string]interface{}{
+				"rating":   gorm.Expr("rating + ?", ratingDelta),
+				"attempts": gorm.Expr("attempts + 1"),
+				"solves":   gorm.Expr("solves + ?", solves),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		recorded = true
+		return nil
+	})
+
+	if err != nil {
+		return false, fmt.Errorf("failed to record puzzle attempt: %w", err)
+	}
+
+	return recorded, nil
+}
+
+// GetAttempt retrieves a player's attempt at a puzzle, or nil if there is none
+func (r *puzzleRepository) GetAttempt(ctx context.Context, username, puzzleID string) (*models.PuzzleAttempt, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var attempt models.PuzzleAttempt
+	err := r.db.WithContext(ctx).
+		Where("username = ? AND puzzle_id = ?", username, puzzleID).
+		First(&attempt).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get puzzle attempt: %w", err)
+	}
+
+	return &attempt, nil
+}
+
+// LatestAttempt retrieves the player's most recent attempt, which carries
+// their current puzzle rating, or nil if they have not tried any puzzle
+func (r *puzzleRepository) LatestAttempt(ctx context.Context, username string) (*models.PuzzleAttempt, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var attempts []*models.PuzzleAttempt
+	err := r.db.WithContext(ctx).
+		Where("username = ?", username).
+		Order("created_at DESC").
+		Limit(1).
+		Find(&attempts).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest puzzle attempt: %w", err)
+	}
+	if len(attempts) == 0 {
+		return nil, nil
+	}
+
+	return attempts[0], nil
+}
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+// reviewRepository implements ReviewRepository interface
+type reviewRepository struct {
+	db *gorm.DB
+}
+
+// NewReviewRepository creates a new ReviewRepository instance
+func NewReviewRepository(db *gorm.DB) ReviewRepository {
+	return &reviewRepository{db: db}
+}
+
+// CreatePending records that a game awaits review unless it already has a
+// review, and reports whether one was created
+func (r *reviewRepository) CreatePending(ctx context.Context, gameID string) (bool, error) {
+	if gameID == "" {
+		return false, fmt.Errorf("game ID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.GameReview{GameID: gameID, Status: models.ReviewPending})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to create pending review: %w", result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// Save stores a review, replacing any earlier one for the game but keeping
+// the time it was first queued
+func (r *reviewRepository) Save(ctx context.Context, review *models.GameReview) error {
+	if review == nil {
+		return fmt.Errorf("review cannot be nil")
+	}
+	if review.GameID == "" {
+		return fmt.Errorf("review game ID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := r.db.WithContext(ctx).Omit("created_at").Save(review).Error; err != nil {
+		return fmt.Errorf("failed to save review: %w", err)
+	}
+
+	return nil
+}
+
+// GetByGameID retrieves the review of a game, or nil if there is none
+func (r *reviewRepository) GetByGameID(ctx context.Context, gameID string) (*models.GameReview, error) {
+	if gameID == "" {
+		return nil, fmt.Errorf("game ID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var review models.GameReview
+	err := r.db.WithContext(ctx).First(&review, "game_id = ?", gameID).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	return &review, nil
+}
+
+// ListPending retrieves up to limit reviews that have not run yet, oldest first
+func (r *reviewRepository) ListPending(ctx context.Context, limit int) ([]*models.GameReview, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var reviews []*models.GameReview
+	err := r.db.WithContext(ctx).
+		Where("status = ?", models.ReviewPending).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&reviews).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending reviews: %w", err)
+	}
+
+	return reviews, nil
+}
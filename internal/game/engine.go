@@ -12,6 +12,7 @@ import (
 type Engine interface {
 	// Game state operations
 	CreateGame(ctx context.Context, player1, player2 string) (*models.GameSession, error)
+	CreateHandicapGame(ctx context.Context, player1, player2 string, handicap models.Handicap) (*models.GameSession, error)
 	GetGame(ctx context.Context, gameID string) (*models.GameSession, error)
 	
 	// Move operations
@@ -56,6 +57,20 @@ func NewEngine(gameRepo repositories.GameSessionRepository, moveRepo repositorie
 
 // CreateGame creates a new Connect 4 game session
 func (e *engine) CreateGame(ctx context.Context, player1, player2 string) (*models.GameSession, error) {
+	return e.createGame(ctx, player1, player2, nil)
+}
+
+// CreateHandicapGame creates a game session that gives the weaker player a
+// head start. Pre-placed discs are on the board before the first move.
+func (e *engine) CreateHandicapGame(ctx context.Context, player1, player2 string, handicap models.Handicap) (*models.GameSession, error) {
+	if err := handicap.Validate(); err != nil {
+		return nil, err
+	}
+	return e.createGame(ctx, player1, player2, &handicap)
+}
+
+// createGame creates and persists an in-progress game session
+func (e *engine) createGame(ctx context.Context, player1, player2 string, handicap *models.Handicap) (*models.GameSession, error) {
 	if player1 == "" || player2 == "" {
 		return nil, fmt.Errorf("player usernames cannot be empty")
 	}
@@ -69,8 +84,16 @@ func (e *engine) CreateGame(ctx context.Context, player1, player2 string) (*mode
 		Player2:     player2,
 		Status:      models.StatusInProgress,
 		CurrentTurn: models.PlayerColorRed, // Player1 always starts as red
-		Board:       models.NewBoard(),
+		Handicap:    handicap,
+	}
+	if handicap != nil {
+		game.CurrentTurn = handicap.FirstTurn()
 	}
+	board, err := game.InitialBoard()
+	if err != nil {
+		return nil, err
+	}
+	game.Board = board
 	
 	if err := e.gameRepo.Create(ctx, game); err != nil {
 		return nil, fmt.Errorf("failed to create game: %w", err)
@@ -188,6 +211,13 @@ func (e *engine) ValidateMove(ctx context.Context, gameID string, playerUsername
 	if !game.Board.IsValidMove(column) {
 		return fmt.Errorf("column %d is full", column)
 	}
+
+	// Check the handicap allows the move
+	if game.Handicap != nil {
+		if err := game.Handicap.CheckMove(&game.Board, game.GetPlayerColor(playerUsername), column); err != nil {
+			return err
+		}
+	}
 	
 	return nil
 }
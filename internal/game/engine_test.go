@@ -67,6 +67,59 @@ func TestCreateGame_SamePlayer(t *testing.T) {
 	assert.Contains(t, err.Error(), "different usernames")
 }
 
+func TestCreateHandicapGame_PlacesDiscsAndFirstTurn(t *testing.T) {
+	engine, _, _ := createTestEngine()
+	ctx := context.Background()
+
+	handicap := models.Handicap{Player: models.PlayerColorYellow, Discs: []int{3, 2}, MovesFirst: true}
+	gameSession, err := engine.CreateHandicapGame(ctx, "player1", "player2", handicap)
+
+	require.NoError(t, err)
+	assert.Equal(t, &handicap, gameSession.Handicap)
+	assert.Equal(t, models.PlayerColorYellow, gameSession.Board.Grid[0][3])
+	assert.Equal(t, models.PlayerColorYellow, gameSession.Board.Grid[0][2])
+	assert.Equal(t, models.PlayerColorYellow, gameSession.CurrentTurn, "the weaker player moves first")
+	assert.True(t, engine.IsPlayerTurn(ctx, gameSession, "player2"))
+}
+
+func TestCreateHandicapGame_InvalidHandicap(t *testing.T) {
+	engine, _, _ := createTestEngine()
+	ctx := context.Background()
+
+	for name, handicap := range map[string]models.Handicap{
+		"no player":      {Discs: []int{3}},
+		"nothing set":    {Player: models.PlayerColorRed},
+		"too many discs": {Player: models.PlayerColorRed, Discs: []int{0, 1, 2, 3}},
+		"bad column":     {Player: models.PlayerColorRed, Discs: []int{7}},
+	} {
+		_, err := engine.CreateHandicapGame(ctx, "player1", "player2", handicap)
+		assert.ErrorIs(t, err, models.ErrInvalidHandicap, name)
+	}
+}
+
+func TestValidateMove_EdgeOpening(t *testing.T) {
+	engine, _, _ := createTestEngine()
+	ctx := context.Background()
+
+	handicap := models.Handicap{Player: models.PlayerColorYellow, EdgeOpening: true}
+	gameSession, err := engine.CreateHandicapGame(ctx, "player1", "player2", handicap)
+	require.NoError(t, err)
+
+	// The stronger player's first move must be at the edge
+	err = engine.ValidateMove(ctx, gameSession.ID, "player1", 3)
+	assert.ErrorIs(t, err, models.ErrInvalidMove)
+	assert.NoError(t, engine.ValidateMove(ctx, gameSession.ID, "player1", 0))
+	assert.NoError(t, engine.ValidateMove(ctx, gameSession.ID, "player1", 6))
+
+	// The weaker player and later moves are not restricted
+	_, err = engine.MakeMove(ctx, gameSession.ID, "player1", 6)
+	require.NoError(t, err)
+	assert.NoError(t, engine.ValidateMove(ctx, gameSession.ID, "player2", 3))
+	_, err = engine.MakeMove(ctx, gameSession.ID, "player2", 3)
+	require.NoError(t, err)
+	assert.NoError(t, engine.ValidateMove(ctx, gameSession.ID, "player1", 3))
+}
+
 // =============================================================================
 // Move Validation Tests - Requirements 5.1, 5.2
 // =============================================================================
@@ -110,6 +110,19 @@ func (m *MockGameSessionRepository) BulkUpdateStatus(ctx context.Context, sessio
 	return nil
 }
 
+func (m *MockGameSessionRepository) GetGamesBetweenPlayers(ctx context.Context, playerA, playerB string) ([]*models.GameSession, error) {
+	var games []*models.GameSession
+	for _, game := range m.games {
+		if game.Status != models.StatusCompleted {
+			continue
+		}
+		if (game.Player1 == playerA && game.Player2 == playerB) || (game.Player1 == playerB && game.Player2 == playerA) {
+			games = append(games, game)
+		}
+	}
+	return games, nil
+}
+
 func (m *MockGameSessionRepository) GetByRoomCode(ctx context.Context, roomCode string) (*models.GameSession, error) {
 	for _, game := range m.games {
 		if game.RoomCode != nil && *game.RoomCode == roomCode {
@@ -3,6 +3,7 @@ package game
 import (
 	"context"
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math/big"
@@ -10,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"connect4-multiplayer/internal/bot"
 	"connect4-multiplayer/internal/database/repositories"
 	"connect4-multiplayer/pkg/models"
 )
@@ -24,10 +26,26 @@ type AnalyticsProducer interface {
 	SendPlayerReconnected(ctx context.Context, gameID, playerID string) error
 }
 
+// BlockChecker reports whether either of two players has blocked the other
+type BlockChecker interface {
+	IsBlocked(ctx context.Context, playerA, playerB string) (bool, error)
+}
+
+// ErrPlayerBlocked is returned when a block list prevents two players from meeting
+var ErrPlayerBlocked = errors.New("player is not available")
+
+// EventListener receives game events as they are recorded by the game service.
+// Listeners are invoked asynchronously and must be safe for concurrent use.
+type EventListener interface {
+	OnGameEvent(ctx context.Context, event *models.GameEvent)
+}
+
 // GameService defines the interface for game session management
 type GameService interface {
 	// Session lifecycle management
 	CreateSession(ctx context.Context, player1, player2 string) (*models.GameSession, error)
+	CreateBotSession(ctx context.Context, player, botUsername string, difficulty bot.Difficulty) (*models.GameSession, error)
+	CreateHandicapBotSession(ctx context.Context, player, botUsername string, difficulty bot.Difficulty, handicap models.Handicap) (*models.GameSession, error)
 	GetSession(ctx context.Context, gameID string) (*models.GameSession, error)
 	EndSession(ctx context.Context, gameID string, winner *models.PlayerColor, reason string) error
 
@@ -40,6 +58,7 @@ type GameService interface {
 	// Turn management
 	GetCurrentTurn(ctx context.Context, gameID string) (string, models.PlayerColor, error)
 	SwitchTurn(ctx context.Context, gameID string) error
+	RecordMove(ctx context.Context, gameID string, player models.PlayerColor, column, row int) error
 
 	// Player color assignment
 	AssignPlayerColors(ctx context.Context, gameID string) (map[string]models.PlayerColor, error)
@@ -83,6 +102,12 @@ type gameService struct {
 	// Analytics producer for Kafka events (Requirement 9, 10)
 	analyticsProducer AnalyticsProducer
 
+	// In-process listeners for recorded game events
+	eventListeners []EventListener
+
+	// Player block lists
+	blockChecker BlockChecker
+
 	// In-memory cache for active sessions
 	sessionCache map[string]*cachedSession
 	cacheMutex   sync.RWMutex
@@ -114,6 +139,8 @@ type ServiceConfig struct {
 	DisconnectTimeout time.Duration
 	Logger            *slog.Logger
 	AnalyticsProducer AnalyticsProducer // Optional: Kafka producer for analytics
+	EventListeners    []EventListener   // Optional: in-process game event listeners
+	BlockChecker      BlockChecker      // Optional: reject custom room joins between blocked players
 }
 
 // DefaultServiceConfig returns default service configuration
@@ -144,6 +171,8 @@ func NewGameService(
 		moveRepo:            moveRepo,
 		eventRepo:           eventRepo,
 		analyticsProducer:   config.AnalyticsProducer,
+		eventListeners:      config.EventListeners,
+		blockChecker:        config.BlockChecker,
 		sessionCache:        make(map[string]*cachedSession),
 		disconnectedPlayers: make(map[string]map[string]time.Time),
 		sessionTimeout:      config.SessionTimeout,
@@ -154,6 +183,35 @@ func NewGameService(
 
 // CreateSession creates a new game session with player color assignment
 func (s *gameService) CreateSession(ctx context.Context, player1, player2 string) (*models.GameSession, error) {
+	return s.createSession(ctx, player1, player2, "", nil)
+}
+
+// CreateBotSession creates a game between a player and a bot, persisting the
+// bot difficulty so every bot move uses it, even after a server restart.
+// Games against an external engine use bot.DifficultyEngine.
+func (s *gameService) CreateBotSession(ctx context.Context, player, botUsername string, difficulty bot.Difficulty) (*models.GameSession, error) {
+	if !difficulty.IsValid() && difficulty != bot.DifficultyEngine {
+		return nil, fmt.Errorf("invalid bot difficulty: %d", int(difficulty))
+	}
+	return s.createSession(ctx, player, botUsername, difficulty.String(), nil)
+}
+
+// CreateHandicapBotSession creates a bot game that gives the weaker side a
+// head start. The handicap is persisted with the game, so its opening rule
+// holds for every move and the game stays out of player ratings.
+func (s *gameService) CreateHandicapBotSession(ctx context.Context, player, botUsername string, difficulty bot.Difficulty, handicap models.Handicap) (*models.GameSession, error) {
+	if !difficulty.IsValid() && difficulty != bot.DifficultyEngine {
+		return nil, fmt.Errorf("invalid bot difficulty: %d", int(difficulty))
+	}
+	if err := handicap.Validate(); err != nil {
+		return nil, err
+	}
+	return s.createSession(ctx, player, botUsername, difficulty.String(), &handicap)
+}
+
+// createSession creates and persists an in-progress game session, starting
+// from the handicap's discs and first turn when there is one
+func (s *gameService) createSession(ctx context.Context, player1, player2, botDifficulty string, handicap *models.Handicap) (*models.GameSession, error) {
 	if player1 == "" || player2 == "" {
 		return nil, fmt.Errorf("player usernames cannot be empty")
 	}
@@ -164,12 +222,22 @@ func (s *gameService) CreateSession(ctx context.Context, player1, player2 string
 
 	// Create new game session
 	session := &models.GameSession{
-		Player1:     player1,
-		Player2:     player2,
-		Status:      models.StatusInProgress,
-		CurrentTurn: models.PlayerColorRed, // Player1 (red) always starts
-		Board:       models.NewBoard(),
-		StartTime:   time.Now(),
+		Player1:       player1,
+		Player2:       player2,
+		Status:        models.StatusInProgress,
+		CurrentTurn:   models.PlayerColorRed, // Player1 (red) always starts
+		Board:         models.NewBoard(),
+		StartTime:     time.Now(),
+		BotDifficulty: botDifficulty,
+		Handicap:      handicap,
+	}
+	if handicap != nil {
+		session.CurrentTurn = handicap.FirstTurn()
+		board, err := session.InitialBoard()
+		if err != nil {
+			return nil, err
+		}
+		session.Board = board
 	}
 
 	// Persist to database
@@ -185,6 +253,7 @@ func (s *gameService) CreateSession(ctx context.Context, player1, player2 string
 		"gameID", session.ID,
 		"player1", player1,
 		"player2", player2,
+		"botDifficulty", botDifficulty,
 	)
 
 	// Create game started event in database
@@ -196,6 +265,8 @@ func (s *gameService) CreateSession(ctx context.Context, player1, player2 string
 		)
 	}
 
+	s.notifyEventListeners(event)
+
 	// Send analytics event to Kafka (Requirement 9.1)
 	if s.analyticsProducer != nil {
 		go func() {
@@ -330,6 +401,11 @@ func (s *gameService) JoinCustomRoom(ctx context.Context, roomCode, username str
 		return nil, fmt.Errorf("room is already full")
 	}
 
+	// Reject players the creator has blocked, or who blocked the creator
+	if err := s.checkBlocked(ctx, session.Player1, username); err != nil {
+		return nil, err
+	}
+
 	// Add player as Player2 and start the game
 	session.Player2 = username
 	session.Status = models.StatusInProgress
@@ -360,6 +436,8 @@ func (s *gameService) JoinCustomRoom(ctx context.Context, roomCode, username str
 		)
 	}
 
+	s.notifyEventListeners(event)
+
 	// Send analytics event to Kafka
 	if s.analyticsProducer != nil {
 		go func() {
@@ -406,6 +484,10 @@ func (s *gameService) RematchCustomRoom(ctx context.Context, gameID, username st
 		return nil, fmt.Errorf("cannot rematch without a second player")
 	}
 
+	if err := s.checkBlocked(ctx, session.Player1, session.Player2); err != nil {
+		return nil, err
+	}
+
 	roomCode := *session.RoomCode
 
 	// Release room code from completed session to preserve history
@@ -442,6 +524,8 @@ func (s *gameService) RematchCustomRoom(ctx context.Context, gameID, username st
 		)
 	}
 
+	s.notifyEventListeners(event)
+
 	// Send analytics event to Kafka
 	if s.analyticsProducer != nil {
 		go func() {
@@ -580,6 +664,21 @@ func (s *gameService) SwitchTurn(ctx context.Context, gameID string) error {
 	return nil
 }
 
+// RecordMove adds a move to the game's history, which reviews and puzzle
+// mining replay. Record each move before the game completes.
+func (s *gameService) RecordMove(ctx context.Context, gameID string, player models.PlayerColor, column, row int) error {
+	move := &models.Move{
+		GameID: gameID,
+		Player: player,
+		Column: column,
+		Row:    row,
+	}
+	if err := s.moveRepo.Create(ctx, move); err != nil {
+		return fmt.Errorf("failed to record move: %w", err)
+	}
+	return nil
+}
+
 // AssignPlayerColors returns the color assignment for both players
 func (s *gameService) AssignPlayerColors(ctx context.Context, gameID string) (map[string]models.PlayerColor, error) {
 	session, err := s.GetSession(ctx, gameID)
@@ -620,12 +719,15 @@ func (s *gameService) CompleteGame(ctx context.Context, gameID string, winner *m
 		return fmt.Errorf("failed to complete game: %w", err)
 	}
 
-	// Update player statistics
-	if err := s.updatePlayerStats(ctx, session, winner, gameDuration); err != nil {
-		s.logger.Warn("failed to update player stats",
-			"gameID", gameID,
-			"error", err,
-		)
+	// Update player statistics. Handicap games are left out, so a head
+	// start never counts towards a player's record or the leaderboard.
+	if session.Handicap == nil {
+		if err := s.updatePlayerStats(ctx, session, winner, gameDuration); err != nil {
+			s.logger.Warn("failed to update player stats",
+				"gameID", gameID,
+				"error", err,
+			)
+		}
 	}
 
 	// Create game completed event
@@ -649,6 +751,8 @@ func (s *gameService) CompleteGame(ctx context.Context, gameID string, winner *m
 		)
 	}
 
+	s.notifyEventListeners(event)
+
 	// Send analytics event to Kafka (Requirement 9.3)
 	if s.analyticsProducer != nil {
 		go func() {
@@ -674,25 +778,54 @@ func (s *gameService) CompleteGame(ctx context.Context, gameID string, winner *m
 	return nil
 }
 
-// updatePlayerStats updates statistics for both players after a game
+// updatePlayerStats updates statistics for both players after a game. Bots
+// played by the server keep no stats, so they never reach the leaderboard.
 func (s *gameService) updatePlayerStats(ctx context.Context, session *models.GameSession, winner *models.PlayerColor, gameDuration int) error {
 	// Determine winners and losers
 	player1Won := winner != nil && *winner == models.PlayerColorRed
 	player2Won := winner != nil && *winner == models.PlayerColorYellow
 
 	// Update player1 stats
-	if err := s.statsRepo.UpdateGameStats(ctx, session.Player1, player1Won, gameDuration); err != nil {
-		return fmt.Errorf("failed to update player1 stats: %w", err)
+	if !bot.IsBotUsername(session.Player1) {
+		if err := s.statsRepo.UpdateGameStats(ctx, session.Player1, player1Won, gameDuration); err != nil {
+			return fmt.Errorf("failed to update player1 stats: %w", err)
+		}
 	}
 
 	// Update player2 stats
-	if err := s.statsRepo.UpdateGameStats(ctx, session.Player2, player2Won, gameDuration); err != nil {
-		return fmt.Errorf("failed to update player2 stats: %w", err)
+	if !bot.IsBotUsername(session.Player2) {
+		if err := s.statsRepo.UpdateGameStats(ctx, session.Player2, player2Won, gameDuration); err != nil {
+			return fmt.Errorf("failed to update player2 stats: %w", err)
+		}
 	}
 
 	return nil
 }
 
+// checkBlocked returns ErrPlayerBlocked if either player has blocked the other
+func (s *gameService) checkBlocked(ctx context.Context, playerA, playerB string) error {
+	if s.blockChecker == nil {
+		return nil
+	}
+
+	blocked, err := s.blockChecker.IsBlocked(ctx, playerA, playerB)
+	if err != nil {
+		return fmt.Errorf("failed to check block list: %w", err)
+	}
+	if blocked {
+		return ErrPlayerBlocked
+	}
+
+	return nil
+}
+
+// notifyEventListeners fans a recorded event out to the registered listeners
+func (s *gameService) notifyEventListeners(event *models.GameEvent) {
+	for _, listener := range s.eventListeners {
+		go listener.OnGameEvent(context.Background(), event)
+	}
+}
+
 // GetActiveSessions retrieves all active game sessions
 func (s *gameService) GetActiveSessions(ctx context.Context) ([]*models.GameSession, error) {
 	return s.gameRepo.GetActiveGames(ctx)
@@ -954,6 +1087,8 @@ func (s *gameService) MarkPlayerDisconnected(ctx context.Context, gameID string,
 		)
 	}
 
+	s.notifyEventListeners(event)
+
 	// Send analytics event to Kafka (Requirement 9.4)
 	if s.analyticsProducer != nil {
 		go func() {
@@ -1019,6 +1154,8 @@ func (s *gameService) MarkPlayerReconnected(ctx context.Context, gameID string,
 		)
 	}
 
+	s.notifyEventListeners(event)
+
 	// Send analytics event to Kafka (Requirement 9.4)
 	if s.analyticsProducer != nil {
 		go func() {
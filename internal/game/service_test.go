@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"connect4-multiplayer/internal/bot"
 	"connect4-multiplayer/pkg/models"
 )
 
@@ -92,6 +93,14 @@ func (m *MockGameSessionRepository) BulkUpdateStatus(ctx context.Context, sessio
 	return args.Error(0)
 }
 
+func (m *MockGameSessionRepository) GetGamesBetweenPlayers(ctx context.Context, playerA, playerB string) ([]*models.GameSession, error) {
+	args := m.Called(ctx, playerA, playerB)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.GameSession), args.Error(1)
+}
+
 func (m *MockGameSessionRepository) GetByRoomCode(ctx context.Context, roomCode string) (*models.GameSession, error) {
 	args := m.Called(ctx, roomCode)
 	if args.Get(0) == nil {
@@ -144,6 +153,14 @@ func (m *MockPlayerStatsRepository) GetLeaderboard(ctx context.Context, limit in
 	return args.Get(0).([]*models.PlayerStats), args.Error(1)
 }
 
+func (m *MockPlayerStatsRepository) GetBotLeaderboard(ctx context.Context, limit int) ([]*models.PlayerStats, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PlayerStats), args.Error(1)
+}
+
 func (m *MockPlayerStatsRepository) UpdateGameStats(ctx context.Context, username string, won bool, gameDuration int) error {
 	args := m.Called(ctx, username, won, gameDuration)
 	return args.Error(0)
@@ -285,6 +302,72 @@ func TestCreateSession(t *testing.T) {
 	})
 }
 
+func TestCreateBotSession(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("persists the bot difficulty", func(t *testing.T) {
+		service, gameRepo, _, _, eventRepo := createTestService()
+		gameRepo.On("Create", ctx, mock.MatchedBy(func(session *models.GameSession) bool {
+			return session.BotDifficulty == "hard"
+		})).Return(nil).Once()
+		eventRepo.On("Create", ctx, mock.AnythingOfType("*models.GameEvent")).Return(nil).Once()
+
+		session, err := service.CreateBotSession(ctx, "player1", "bot_1", bot.DifficultyHard)
+
+		require.NoError(t, err)
+		assert.Equal(t, "hard", session.BotDifficulty)
+		gameRepo.AssertExpectations(t)
+	})
+
+	t.Run("marks engine games", func(t *testing.T) {
+		service, gameRepo, _, _, eventRepo := createTestService()
+		gameRepo.On("Create", ctx, mock.AnythingOfType("*models.GameSession")).Return(nil).Once()
+		eventRepo.On("Create", ctx, mock.AnythingOfType("*models.GameEvent")).Return(nil).Once()
+
+		session, err := service.CreateBotSession(ctx, "player1", bot.EngineUsername("leftmost"), bot.DifficultyEngine)
+
+		require.NoError(t, err)
+		assert.Equal(t, "engine", session.BotDifficulty)
+	})
+
+	t.Run("rejects unknown difficulty", func(t *testing.T) {
+		service, _, _, _, _ := createTestService()
+		session, err := service.CreateBotSession(ctx, "player1", "bot_1", bot.Difficulty(9))
+
+		assert.Error(t, err)
+		assert.Nil(t, session)
+	})
+}
+
+func TestCreateHandicapBotSession(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("starts from the handicap", func(t *testing.T) {
+		service, gameRepo, _, _, eventRepo := createTestService()
+		gameRepo.On("Create", ctx, mock.MatchedBy(func(session *models.GameSession) bool {
+			return session.Handicap != nil && session.Board.Height[3] == 1
+		})).Return(nil).Once()
+		eventRepo.On("Create", ctx, mock.AnythingOfType("*models.GameEvent")).Return(nil).Once()
+
+		handicap := models.Handicap{Player: models.PlayerColorYellow, Discs: []int{3}, MovesFirst: true}
+		session, err := service.CreateHandicapBotSession(ctx, "player1", "bot_1", bot.DifficultyHard, handicap)
+
+		require.NoError(t, err)
+		assert.Equal(t, "hard", session.BotDifficulty)
+		assert.Equal(t, models.PlayerColorYellow, session.CurrentTurn)
+		assert.Equal(t, models.PlayerColorYellow, session.Board.Grid[0][3])
+		gameRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects invalid handicaps", func(t *testing.T) {
+		service, _, _, _, _ := createTestService()
+		session, err := service.CreateHandicapBotSession(ctx, "player1", "bot_1", bot.DifficultyHard, models.Handicap{Player: models.PlayerColorRed})
+
+		assert.ErrorIs(t, err, models.ErrInvalidHandicap)
+		assert.Nil(t, session)
+	})
+}
+
 func TestAssignPlayerColors(t *testing.T) {
 	ctx := context.Background()
 
@@ -347,6 +430,20 @@ func TestGetCurrentTurn(t *testing.T) {
 	})
 }
 
+func TestRecordMove(t *testing.T) {
+	ctx := context.Background()
+	service, _, _, moveRepo, _ := createTestService()
+	moveRepo.On("Create", ctx, mock.MatchedBy(func(move *models.Move) bool {
+		return move.GameID == "game-130" && move.Player == models.PlayerColorYellow && move.Column == 4 && move.Row == 1
+	})).Return(nil).Once()
+
+	require.NoError(t, service.RecordMove(ctx, "game-130", models.PlayerColorYellow, 4, 1))
+	moveRepo.AssertExpectations(t)
+
+	moveRepo.On("Create", ctx, mock.Anything).Return(assert.AnError).Once()
+	assert.ErrorIs(t, service.RecordMove(ctx, "game-130", models.PlayerColorRed, 4, 2), assert.AnError)
+}
+
 func TestSwitchTurn(t *testing.T) {
 	ctx := context.Background()
 
@@ -414,6 +511,55 @@ func TestCompleteGame(t *testing.T) {
 		assert.NotNil(t, session.EndTime)
 	})
 
+	t.Run("leaves handicap games out of player stats", func(t *testing.T) {
+		service, gameRepo, statsRepo, _, eventRepo := createTestService()
+		session := &models.GameSession{
+			ID:          "game-130",
+			Player1:     "alice",
+			Player2:     "bob",
+			Status:      models.StatusInProgress,
+			CurrentTurn: models.PlayerColorRed,
+			StartTime:   time.Now().Add(-5 * time.Minute),
+			Handicap:    &models.Handicap{Player: models.PlayerColorRed, Discs: []int{3}},
+		}
+		winner := models.PlayerColorRed
+
+		gameRepo.On("GetByID", ctx, "game-130").Return(session, nil).Once()
+		gameRepo.On("Update", ctx, mock.AnythingOfType("*models.GameSession")).Return(nil).Once()
+		eventRepo.On("Create", ctx, mock.AnythingOfType("*models.GameEvent")).Return(nil).Once()
+
+		err := service.CompleteGame(ctx, "game-130", &winner)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusCompleted, session.Status)
+		statsRepo.AssertNotCalled(t, "UpdateGameStats", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("keeps no stats for bots", func(t *testing.T) {
+		service, gameRepo, statsRepo, _, eventRepo := createTestService()
+		session := &models.GameSession{
+			ID:            "game-131",
+			Player1:       "alice",
+			Player2:       "Bot_MCTS_Hard_4",
+			Status:        models.StatusInProgress,
+			CurrentTurn:   models.PlayerColorRed,
+			StartTime:     time.Now().Add(-5 * time.Minute),
+			BotDifficulty: "hard",
+		}
+		winner := models.PlayerColorYellow
+
+		gameRepo.On("GetByID", ctx, "game-131").Return(session, nil).Once()
+		gameRepo.On("Update", ctx, mock.AnythingOfType("*models.GameSession")).Return(nil).Once()
+		statsRepo.On("UpdateGameStats", ctx, "alice", false, mock.AnythingOfType("int")).Return(nil).Once()
+		eventRepo.On("Create", ctx, mock.AnythingOfType("*models.GameEvent")).Return(nil).Once()
+
+		err := service.CompleteGame(ctx, "game-131", &winner)
+
+		require.NoError(t, err)
+		statsRepo.AssertExpectations(t)
+		statsRepo.AssertNotCalled(t, "UpdateGameStats", mock.Anything, "Bot_MCTS_Hard_4", mock.Anything, mock.Anything)
+	})
+
 	t.Run("fails for inactive game", func(t *testing.T) {
 		service, gameRepo, _, _, _ := createTestService()
 		session := &models.GameSession{
@@ -567,3 +713,53 @@ func TestCacheOperations(t *testing.T) {
 		assert.True(t, stats["cached_sessions"].(int) >= 1)
 	})
 }
+
+// staticBlockChecker treats the listed pairs as blocked in either direction
+type staticBlockChecker map[[2]string]bool
+
+func (b staticBlockChecker) IsBlocked(ctx context.Context, playerA, playerB string) (bool, error) {
+	return b[[2]string{playerA, playerB}] || b[[2]string{playerB, playerA}], nil
+}
+
+func TestJoinCustomRoomBlocked(t *testing.T) {
+	ctx := context.Background()
+	roomCode := "ABCD1234"
+
+	newRoom := func() *models.GameSession {
+		code := roomCode
+		return &models.GameSession{
+			ID:       "room-game",
+			Player1:  "alice",
+			Player2:  "waiting",
+			Status:   models.StatusWaiting,
+			RoomCode: &code,
+			IsCustom: true,
+		}
+	}
+
+	t.Run("rejects player blocked by creator", func(t *testing.T) {
+		service, gameRepo, _, _, _ := createTestService()
+		service.blockChecker = staticBlockChecker{{"alice", "mallory"}: true}
+		gameRepo.On("GetByRoomCode", ctx, roomCode).Return(newRoom(), nil)
+
+		session, err := service.JoinCustomRoom(ctx, roomCode, "mallory")
+
+		assert.ErrorIs(t, err, ErrPlayerBlocked)
+		assert.Nil(t, session)
+		gameRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("allows players who are not blocked", func(t *testing.T) {
+		service, gameRepo, _, _, eventRepo := createTestService()
+		service.blockChecker = staticBlockChecker{{"alice", "mallory"}: true}
+		gameRepo.On("GetByRoomCode", ctx, roomCode).Return(newRoom(), nil)
+		gameRepo.On("Update", ctx, mock.AnythingOfType("*models.GameSession")).Return(nil)
+		eventRepo.On("Create", ctx, mock.AnythingOfType("*models.GameEvent")).Return(nil)
+
+		session, err := service.JoinCustomRoom(ctx, roomCode, "bobby")
+
+		require.NoError(t, err)
+		assert.Equal(t, "bobby", session.Player2)
+		assert.Equal(t, models.StatusInProgress, session.Status)
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/mock"
 
+	"connect4-multiplayer/internal/bot"
 	"connect4-multiplayer/pkg/models"
 )
 
@@ -19,6 +20,16 @@ func (m *MockGameService) CreateSession(ctx context.Context, player1, player2 st
 	return args.Get(0).(*models.GameSession), args.Error(1)
 }
 
+func (m *MockGameService) CreateBotSession(ctx context.Context, player, botUsername string, difficulty bot.Difficulty) (*models.GameSession, error) {
+	args := m.Called(ctx, player, botUsername, difficulty)
+	return args.Get(0).(*models.GameSession), args.Error(1)
+}
+
+func (m *MockGameService) CreateHandicapBotSession(ctx context.Context, player, botUsername string, difficulty bot.Difficulty, handicap models.Handicap) (*models.GameSession, error) {
+	args := m.Called(ctx, player, botUsername, difficulty, handicap)
+	return args.Get(0).(*models.GameSession), args.Error(1)
+}
+
 func (m *MockGameService) GetActiveSessionByPlayer(ctx context.Context, username string) (*models.GameSession, error) {
 	args := m.Called(ctx, username)
 	if args.Get(0) == nil {
@@ -47,6 +58,11 @@ func (m *MockGameService) SwitchTurn(ctx context.Context, gameID string) error {
 	return args.Error(0)
 }
 
+func (m *MockGameService) RecordMove(ctx context.Context, gameID string, player models.PlayerColor, column, row int) error {
+	args := m.Called(ctx, gameID, player, column, row)
+	return args.Error(0)
+}
+
 func (m *MockGameService) AssignPlayerColors(ctx context.Context, gameID string) (map[string]models.PlayerColor, error) {
 	args := m.Called(ctx, gameID)
 	return args.Get(0).(map[string]models.PlayerColor), args.Error(1)
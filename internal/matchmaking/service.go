@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"connect4-multiplayer/internal/bot"
 	"connect4-multiplayer/internal/game"
 	"connect4-multiplayer/pkg/models"
 )
@@ -15,6 +16,7 @@ import (
 type MatchmakingService interface {
 	// Queue management
 	JoinQueue(ctx context.Context, username string) (*QueueEntry, error)
+	JoinQueueWithPreferences(ctx context.Context, username string, preferences QueuePreferences) (*QueueEntry, error)
 	LeaveQueue(ctx context.Context, username string) error
 	GetQueueStatus(ctx context.Context, username string) (*QueueStatus, error)
 	GetQueueLength(ctx context.Context) int
@@ -24,7 +26,7 @@ type MatchmakingService interface {
 	StopMatchmaking()
 
 	// Direct bot game creation
-	CreateBotGame(ctx context.Context, player string) (*models.GameSession, error)
+	CreateBotGame(ctx context.Context, player string, difficulty bot.Difficulty) (*models.GameSession, error)
 
 	// Event callbacks
 	SetGameCreatedCallback(callback GameCreatedCallback)
@@ -33,9 +35,17 @@ type MatchmakingService interface {
 
 // QueueEntry represents a player in the matchmaking queue
 type QueueEntry struct {
-	Username string    `json:"username"`
-	JoinedAt time.Time `json:"joinedAt"`
-	Timeout  time.Time `json:"timeout"`
+	Username      string         `json:"username"`
+	JoinedAt      time.Time      `json:"joinedAt"`
+	Timeout       time.Time      `json:"timeout"`
+	BotDifficulty bot.Difficulty `json:"botDifficulty"` // Used if the player falls back to a bot game
+}
+
+// QueuePreferences holds a player's choices when joining the queue
+type QueuePreferences struct {
+	// BotDifficulty is used for the bot fallback game; zero selects
+	// bot.DifficultyAdaptive so new players are not crushed by a strong bot
+	BotDifficulty bot.Difficulty
 }
 
 // QueueStatus represents the current status of a player in the queue
@@ -46,6 +56,12 @@ type QueueStatus struct {
 	TimeRemaining time.Duration `json:"timeRemaining"`
 }
 
+// BlockChecker lists the blocks between players waiting in the queue
+type BlockChecker interface {
+	// BlocksAmong returns every block between two of the given players
+	BlocksAmong(ctx context.Context, usernames []string) ([]*models.PlayerBlock, error)
+}
+
 // GameCreatedCallback is called when a game is created between two players
 type GameCreatedCallback func(ctx context.Context, player1, player2 string, gameSession *models.GameSession) error
 
@@ -54,7 +70,8 @@ type BotGameCallback func(ctx context.Context, player string, gameSession *model
 
 // matchmakingService implements MatchmakingService interface
 type matchmakingService struct {
-	gameService game.GameService
+	gameService  game.GameService
+	blockChecker BlockChecker
 
 	// Queue management
 	queue       []*QueueEntry
@@ -80,6 +97,7 @@ type ServiceConfig struct {
 	MatchTimeout  time.Duration
 	MatchInterval time.Duration
 	Logger        *slog.Logger
+	BlockChecker  BlockChecker // Optional: never pair players who blocked each other
 }
 
 // DefaultServiceConfig returns default matchmaking service configuration
@@ -102,6 +120,7 @@ func NewMatchmakingService(
 
 	return &matchmakingService{
 		gameService:   gameService,
+		blockChecker:  config.BlockChecker,
 		queue:         make([]*QueueEntry, 0),
 		playerIndex:   make(map[string]int),
 		matchTimeout:  config.MatchTimeout,
@@ -110,13 +129,26 @@ func NewMatchmakingService(
 	}
 }
 
-// JoinQueue adds a player to the matchmaking queue
+// JoinQueue adds a player to the matchmaking queue with default preferences
 // Implements Requirement 1.1: add player to queue when requesting a game
 func (s *matchmakingService) JoinQueue(ctx context.Context, username string) (*QueueEntry, error) {
+	return s.JoinQueueWithPreferences(ctx, username, QueuePreferences{})
+}
+
+// JoinQueueWithPreferences adds a player to the matchmaking queue
+func (s *matchmakingService) JoinQueueWithPreferences(ctx context.Context, username string, preferences QueuePreferences) (*QueueEntry, error) {
 	if username == "" {
 		return nil, fmt.Errorf("username cannot be empty")
 	}
 
+	difficulty := preferences.BotDifficulty
+	if difficulty == 0 {
+		difficulty = bot.DifficultyAdaptive
+	}
+	if !difficulty.IsValid() {
+		return nil, fmt.Errorf("invalid bot difficulty: %d", int(difficulty))
+	}
+
 	s.queueMutex.Lock()
 	defer s.queueMutex.Unlock()
 
@@ -137,9 +169,10 @@ func (s *matchmakingService) JoinQueue(ctx context.Context, username string) (*Q
 	// Create queue entry
 	now := time.Now()
 	entry := &QueueEntry{
-		Username: username,
-		JoinedAt: now,
-		Timeout:  now.Add(s.matchTimeout),
+		Username:      username,
+		JoinedAt:      now,
+		Timeout:       now.Add(s.matchTimeout),
+		BotDifficulty: difficulty,
 	}
 
 	// Add to queue
@@ -285,33 +318,48 @@ func (s *matchmakingService) StopMatchmaking() {
 
 // processMatchmaking handles the core matchmaking logic
 func (s *matchmakingService) processMatchmaking(ctx context.Context) {
+	// Look up blocks before taking the queue lock, so that slow block list
+	// queries never hold up players joining or leaving the queue
+	blockedPairs := s.loadBlockedPairs(ctx)
+
 	s.queueMutex.Lock()
 	defer s.queueMutex.Unlock()
 
 	now := time.Now()
-	var toRemove []int
+	matched := make(map[int]bool)
 
 	// Process queue from oldest to newest
 	for i := 0; i < len(s.queue); i++ {
+		if matched[i] {
+			continue
+		}
 		entry := s.queue[i]
 
 		// Check if player has timed out (Requirement 1.3: 10-second timeout)
 		if now.After(entry.Timeout) {
 			// Start bot game
-			if err := s.createBotGame(ctx, entry.Username); err != nil {
+			if err := s.createBotGame(ctx, entry.Username, entry.BotDifficulty); err != nil {
 				s.logger.Error("failed to create bot game",
 					"username", entry.Username,
 					"error", err,
 				)
 			}
-			toRemove = append(toRemove, i)
+			matched[i] = true
 			continue
 		}
 
 		// Try to find a match with another player (Requirement 1.2)
 		for j := i + 1; j < len(s.queue); j++ {
+			if matched[j] {
+				continue
+			}
 			otherEntry := s.queue[j]
 
+			// Never pair players where either has blocked the other
+			if blockedPairs.isBlocked(entry.Username, otherEntry.Username) {
+				continue
+			}
+
 			// Create game between the two players
 			if err := s.createPlayerGame(ctx, entry.Username, otherEntry.Username); err != nil {
 				s.logger.Error("failed to create player game",
@@ -323,20 +371,72 @@ func (s *matchmakingService) processMatchmaking(ctx context.Context) {
 			}
 
 			// Mark both players for removal
-			toRemove = append(toRemove, i, j)
+			matched[i] = true
+			matched[j] = true
 			break
 		}
+	}
 
-		// If we found a match, skip to next iteration
-		if len(toRemove) > 0 && toRemove[len(toRemove)-1] == i {
-			break
+	// Remove matched/timed-out players (in reverse order to maintain indices)
+	for i := len(s.queue) - 1; i >= 0; i-- {
+		if matched[i] {
+			s.removeFromQueue(i)
 		}
 	}
+}
 
-	// Remove matched/timed-out players (in reverse order to maintain indices)
-	for i := len(toRemove) - 1; i >= 0; i-- {
-		s.removeFromQueue(toRemove[i])
+// blockedPairs holds the block list lookups for the players in the queue,
+// keyed by username pair in queue order. A nil blockedPairs blocks nothing.
+type blockedPairs map[[2]string]bool
+
+// isBlocked reports whether two queued players must not be matched. Pairs
+// that were not looked up, because a player joined after the lookup, are
+// treated as blocked until the next matchmaking pass.
+func (b blockedPairs) isBlocked(player1, player2 string) bool {
+	if b == nil {
+		return false
+	}
+	blocked, ok := b[[2]string{player1, player2}]
+	return blocked || !ok
+}
+
+// loadBlockedPairs looks up every pair of players currently in the queue
+// with a single block list query. Lookup failures are treated as blocked so
+// that a safety check never fails open.
+func (s *matchmakingService) loadBlockedPairs(ctx context.Context) blockedPairs {
+	if s.blockChecker == nil {
+		return nil
+	}
+
+	s.queueMutex.RLock()
+	usernames := make([]string, len(s.queue))
+	for i, entry := range s.queue {
+		usernames[i] = entry.Username
 	}
+	s.queueMutex.RUnlock()
+
+	blocks, err := s.blockChecker.BlocksAmong(ctx, usernames)
+	if err != nil {
+		s.logger.Warn("failed to check block lists",
+			"players", len(usernames),
+			"error", err,
+		)
+	}
+
+	blocked := make(map[[2]string]bool, len(blocks))
+	for _, block := range blocks {
+		blocked[[2]string{block.Blocker, block.Blocked}] = true
+	}
+
+	pairs := make(blockedPairs)
+	for i, player1 := range usernames {
+		for _, player2 := range usernames[i+1:] {
+			pairs[[2]string{player1, player2}] = err != nil ||
+				blocked[[2]string{player1, player2}] || blocked[[2]string{player2, player1}]
+		}
+	}
+
+	return pairs
 }
 
 // createPlayerGame creates a game between two players
@@ -369,11 +469,11 @@ func (s *matchmakingService) createPlayerGame(ctx context.Context, player1, play
 
 // createBotGame creates a game between a player and a bot
 // Implements Requirement 1.3: start bot game after 10-second timeout
-func (s *matchmakingService) createBotGame(ctx context.Context, player string) error {
+func (s *matchmakingService) createBotGame(ctx context.Context, player string, difficulty bot.Difficulty) error {
 	botUsername := "bot_" + generateBotID()
 
 	// Create game session with bot
-	gameSession, err := s.gameService.CreateSession(ctx, player, botUsername)
+	gameSession, err := s.gameService.CreateBotSession(ctx, player, botUsername, difficulty)
 	if err != nil {
 		return fmt.Errorf("failed to create bot game session: %w", err)
 	}
@@ -382,6 +482,7 @@ func (s *matchmakingService) createBotGame(ctx context.Context, player string) e
 		"gameID", gameSession.ID,
 		"player", player,
 		"bot", botUsername,
+		"difficulty", difficulty.String(),
 	)
 
 	// Notify via callback if set
@@ -399,11 +500,11 @@ func (s *matchmakingService) createBotGame(ctx context.Context, player string) e
 
 // CreateBotGame creates a game between a player and a bot (public method)
 // Returns the created game session
-func (s *matchmakingService) CreateBotGame(ctx context.Context, player string) (*models.GameSession, error) {
+func (s *matchmakingService) CreateBotGame(ctx context.Context, player string, difficulty bot.Difficulty) (*models.GameSession, error) {
 	botUsername := "bot_" + generateBotID()
 
 	// Create game session with bot
-	gameSession, err := s.gameService.CreateSession(ctx, player, botUsername)
+	gameSession, err := s.gameService.CreateBotSession(ctx, player, botUsername, difficulty)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create bot game session: %w", err)
 	}
@@ -412,6 +513,7 @@ func (s *matchmakingService) CreateBotGame(ctx context.Context, player string) (
 		"gameID", gameSession.ID,
 		"player", player,
 		"bot", botUsername,
+		"difficulty", difficulty.String(),
 	)
 
 	return gameSession, nil
@@ -15,6 +15,7 @@ import (
 	"github.com/leanovate/gopter/prop"
 	"github.com/stretchr/testify/mock"
 
+	"connect4-multiplayer/internal/bot"
 	"connect4-multiplayer/internal/matchmaking"
 	"connect4-multiplayer/pkg/models"
 )
@@ -65,6 +66,15 @@ func TestMatchmakingQueueManagementProperty(t *testing.T) {
 				},
 				nil,
 			).Maybe() // Allow multiple calls with different parameters
+			mockGameService.On("CreateBotSession", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string"), bot.DifficultyAdaptive).Return(
+				&models.GameSession{
+					ID:      "bot-game-1",
+					Player1: "player1",
+					Player2: "bot_123",
+					Status:  models.StatusInProgress,
+				},
+				nil,
+			).Maybe()
 
 			// Create matchmaking service with short timeout for testing
 			config := &matchmaking.ServiceConfig{
@@ -194,7 +204,7 @@ func TestMatchmakingTimeoutProperty(t *testing.T) {
 			// Create mock game service
 			mockGameService := new(MockGameService)
 			mockGameService.On("GetActiveSessionByPlayer", mock.Anything, username).Return(nil, fmt.Errorf("not found"))
-			mockGameService.On("CreateSession", mock.Anything, username, mock.AnythingOfType("string")).Return(
+			mockGameService.On("CreateBotSession", mock.Anything, username, mock.AnythingOfType("string"), bot.DifficultyAdaptive).Return(
 				&models.GameSession{
 					ID:      "bot-game-1",
 					Player1: username,
@@ -3,6 +3,7 @@ package matchmaking_test
 import (
 	"context"
 	"log/slog"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
+	"connect4-multiplayer/internal/bot"
 	"connect4-multiplayer/internal/matchmaking"
 	"connect4-multiplayer/pkg/models"
 )
@@ -53,6 +55,7 @@ func (suite *MatchmakingServiceTestSuite) TestJoinQueue_Success() {
 	assert.Equal(suite.T(), "player1", entry.Username)
 	assert.False(suite.T(), entry.JoinedAt.IsZero())
 	assert.False(suite.T(), entry.Timeout.IsZero())
+	assert.Equal(suite.T(), bot.DifficultyAdaptive, entry.BotDifficulty, "the bot fallback adapts to the player by default")
 
 	// Verify queue length
 	length := suite.service.GetQueueLength(suite.ctx)
@@ -224,7 +227,7 @@ func (suite *MatchmakingServiceTestSuite) TestBotMatchmaking_Timeout() {
 		Player2: "bot_123456", // Mock bot name
 		Status:  models.StatusInProgress,
 	}
-	suite.mockGameService.On("CreateSession", mock.AnythingOfType("*context.cancelCtx"), "player1", mock.AnythingOfType("string")).Return(gameSession, nil)
+	suite.mockGameService.On("CreateBotSession", mock.AnythingOfType("*context.cancelCtx"), "player1", mock.AnythingOfType("string"), bot.DifficultyHard).Return(gameSession, nil)
 
 	// Set up callback to track bot game creation
 	var createdBotGame *models.GameSession
@@ -233,8 +236,10 @@ func (suite *MatchmakingServiceTestSuite) TestBotMatchmaking_Timeout() {
 		return nil
 	})
 
-	// Join player to queue
-	_, err := suite.service.JoinQueue(suite.ctx, "player1")
+	// Join player to queue; the bot fallback uses the chosen difficulty
+	_, err := suite.service.JoinQueueWithPreferences(suite.ctx, "player1", matchmaking.QueuePreferences{
+		BotDifficulty: bot.DifficultyHard,
+	})
 	assert.NoError(suite.T(), err)
 
 	// Start matchmaking
@@ -258,6 +263,144 @@ func (suite *MatchmakingServiceTestSuite) TestBotMatchmaking_Timeout() {
 	suite.mockGameService.AssertExpectations(suite.T())
 }
 
+// blockedPairs holds blocks as blocker and blocked pairs
+type blockedPairs map[[2]string]bool
+
+func (b blockedPairs) BlocksAmong(ctx context.Context, usernames []string) ([]*models.PlayerBlock, error) {
+	var blocks []*models.PlayerBlock
+	for _, blocker := range usernames {
+		for _, blocked := range usernames {
+			if b[[2]string{blocker, blocked}] {
+				blocks = append(blocks, &models.PlayerBlock{Blocker: blocker, Blocked: blocked})
+			}
+		}
+	}
+	return blocks, nil
+}
+
+func TestPlayerMatchmaking_SkipsBlockedPairs(t *testing.T) {
+	ctx := context.Background()
+	mockGameService := new(MockGameService)
+
+	service := matchmaking.NewMatchmakingService(mockGameService, &matchmaking.ServiceConfig{
+		MatchTimeout:  5 * time.Second,
+		MatchInterval: 50 * time.Millisecond,
+		Logger:        slog.Default(),
+		BlockChecker:  blockedPairs{{"player2", "player1"}: true},
+	})
+
+	for _, username := range []string{"player1", "player2", "player3"} {
+		mockGameService.On("GetActiveSessionByPlayer", ctx, username).Return(nil, assert.AnError)
+	}
+
+	// player2 blocked player1, so player1 must be paired with player3
+	gameSession := &models.GameSession{ID: "game-789", Player1: "player1", Player2: "player3", Status: models.StatusInProgress}
+	mockGameService.On("CreateSession", mock.Anything, "player1", "player3").Return(gameSession, nil).Once()
+
+	var pairs [][2]string
+	service.SetGameCreatedCallback(func(ctx context.Context, player1, player2 string, gameSession *models.GameSession) error {
+		pairs = append(pairs, [2]string{player1, player2})
+		return nil
+	})
+
+	for _, username := range []string{"player1", "player2", "player3"} {
+		_, err := service.JoinQueue(ctx, username)
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, service.StartMatchmaking(ctx))
+	time.Sleep(200 * time.Millisecond)
+	service.StopMatchmaking()
+
+	assert.Equal(t, [][2]string{{"player1", "player3"}}, pairs)
+
+	// The blocked player keeps waiting for someone else
+	status, err := service.GetQueueStatus(ctx, "player2")
+	assert.NoError(t, err)
+	assert.True(t, status.InQueue)
+
+	mockGameService.AssertNotCalled(t, "CreateSession", mock.Anything, "player1", "player2")
+}
+
+// queueReadingBlockChecker reads the queue on every lookup, which deadlocks
+// if block lists are checked while the queue is locked
+type queueReadingBlockChecker struct {
+	service matchmaking.MatchmakingService
+	lookups atomic.Int32
+}
+
+func (b *queueReadingBlockChecker) BlocksAmong(ctx context.Context, usernames []string) ([]*models.PlayerBlock, error) {
+	b.service.GetQueueLength(ctx)
+	b.lookups.Add(1)
+	return nil, nil
+}
+
+func TestPlayerMatchmaking_ChecksBlocksOutsideQueueLock(t *testing.T) {
+	ctx := context.Background()
+	mockGameService := new(MockGameService)
+	checker := &queueReadingBlockChecker{}
+
+	service := matchmaking.NewMatchmakingService(mockGameService, &matchmaking.ServiceConfig{
+		MatchTimeout:  5 * time.Second,
+		MatchInterval: 50 * time.Millisecond,
+		Logger:        slog.Default(),
+		BlockChecker:  checker,
+	})
+	checker.service = service
+
+	for _, username := range []string{"player1", "player2"} {
+		mockGameService.On("GetActiveSessionByPlayer", ctx, username).Return(nil, assert.AnError)
+	}
+	gameSession := &models.GameSession{ID: "game-790", Player1: "player1", Player2: "player2", Status: models.StatusInProgress}
+	mockGameService.On("CreateSession", mock.Anything, "player1", "player2").Return(gameSession, nil).Once()
+
+	for _, username := range []string{"player1", "player2"} {
+		_, err := service.JoinQueue(ctx, username)
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, service.StartMatchmaking(ctx))
+	assert.Eventually(t, func() bool {
+		return service.GetQueueLength(ctx) == 0
+	}, time.Second, 10*time.Millisecond)
+	service.StopMatchmaking()
+
+	assert.Positive(t, checker.lookups.Load())
+	mockGameService.AssertExpectations(t)
+}
+
+// failingBlockChecker cannot read the block lists
+type failingBlockChecker struct{}
+
+func (failingBlockChecker) BlocksAmong(ctx context.Context, usernames []string) ([]*models.PlayerBlock, error) {
+	return nil, assert.AnError
+}
+
+func TestPlayerMatchmaking_BlockLookupFailuresPairNobody(t *testing.T) {
+	ctx := context.Background()
+	mockGameService := new(MockGameService)
+
+	service := matchmaking.NewMatchmakingService(mockGameService, &matchmaking.ServiceConfig{
+		MatchTimeout:  5 * time.Second,
+		MatchInterval: 50 * time.Millisecond,
+		Logger:        slog.Default(),
+		BlockChecker:  failingBlockChecker{},
+	})
+
+	for _, username := range []string{"player1", "player2"} {
+		mockGameService.On("GetActiveSessionByPlayer", ctx, username).Return(nil, assert.AnError)
+		_, err := service.JoinQueue(ctx, username)
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, service.StartMatchmaking(ctx))
+	time.Sleep(200 * time.Millisecond)
+	service.StopMatchmaking()
+
+	assert.Equal(t, 2, service.GetQueueLength(ctx))
+	mockGameService.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestMatchmakingServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(MatchmakingServiceTestSuite))
 }
@@ -0,0 +1,179 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"connect4-multiplayer/internal/database/repositories"
+	"connect4-multiplayer/pkg/models"
+)
+
+// ErrNotificationNotFound is returned when a player's notification does not exist
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// Publisher pushes notifications to players that are currently connected
+type Publisher interface {
+	PublishNotification(username string, notification *models.Notification) error
+}
+
+// NotificationService stores player notifications and pushes them live
+type NotificationService interface {
+	// Notify stores a notification and pushes it to the player if they are online
+	Notify(ctx context.Context, username string, notificationType models.NotificationType, title, body string, data models.NotificationData) (*models.Notification, error)
+
+	List(ctx context.Context, username string, unreadOnly bool, limit, offset int) ([]*models.Notification, error)
+	UnreadCount(ctx context.Context, username string) (int64, error)
+	// GetInbox returns the unread count with the most recent unread notifications
+	GetInbox(ctx context.Context, username string) (*Inbox, error)
+	MarkRead(ctx context.Context, username, id string) error
+	MarkAllRead(ctx context.Context, username string) (int64, error)
+
+	// SetPublisher enables live delivery of new notifications
+	SetPublisher(publisher Publisher)
+}
+
+// Inbox summarises what a player has not read yet
+type Inbox struct {
+	UnreadCount   int64                  `json:"unreadCount"`
+	Notifications []*models.Notification `json:"notifications"`
+}
+
+// notificationService implements NotificationService interface
+type notificationService struct {
+	repo         repositories.NotificationRepository
+	inboxLimit   int
+	logger       *slog.Logger
+	publisher    Publisher
+	publishMutex sync.RWMutex
+}
+
+// ServiceConfig holds configuration for the notification service
+type ServiceConfig struct {
+	// InboxLimit caps how many unread notifications GetInbox returns
+	InboxLimit int
+	Logger     *slog.Logger
+}
+
+// DefaultServiceConfig returns default service configuration
+func DefaultServiceConfig() *ServiceConfig {
+	return &ServiceConfig{
+		InboxLimit: 20,
+		Logger:     slog.Default(),
+	}
+}
+
+// NewNotificationService creates a new NotificationService instance
+func NewNotificationService(repo repositories.NotificationRepository, config *ServiceConfig) NotificationService {
+	if config == nil {
+		config = DefaultServiceConfig()
+	}
+	if config.InboxLimit <= 0 {
+		config.InboxLimit = 20
+	}
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+
+	return &notificationService{
+		repo:       repo,
+		inboxLimit: config.InboxLimit,
+		logger:     config.Logger.With("component", "notifications"),
+	}
+}
+
+// SetPublisher enables live delivery of new notifications
+func (s *notificationService) SetPublisher(publisher Publisher) {
+	s.publishMutex.Lock()
+	defer s.publishMutex.Unlock()
+	s.publisher = publisher
+}
+
+// Notify stores a notification and pushes it to the player if they are online
+func (s *notificationService) Notify(
+	ctx context.Context,
+	username string,
+	notificationType models.NotificationType,
+	title, body string,
+	data models.NotificationData,
+) (*models.Notification, error) {
+	notification := &models.Notification{
+		Username: username,
+		Type:     notificationType,
+		Title:    title,
+		Body:     body,
+		Data:     data,
+	}
+	if err := s.repo.Create(ctx, notification); err != nil {
+		return nil, err
+	}
+
+	s.publishMutex.RLock()
+	publisher := s.publisher
+	s.publishMutex.RUnlock()
+
+	// Offline players pick the notification up from their inbox on next login
+	if publisher != nil {
+		if err := publisher.PublishNotification(username, notification); err != nil {
+			s.logger.Debug("notification stored for offline player",
+				"username", username,
+				"type", notificationType,
+				"error", err)
+		}
+	}
+
+	return notification, nil
+}
+
+// List returns a page of a player's notifications, newest first
+func (s *notificationService) List(ctx context.Context, username string, unreadOnly bool, limit, offset int) ([]*models.Notification, error) {
+	return s.repo.ListByUsername(ctx, username, unreadOnly, limit, offset)
+}
+
+// UnreadCount returns how many notifications the player has not read
+func (s *notificationService) UnreadCount(ctx context.Context, username string) (int64, error) {
+	return s.repo.CountUnread(ctx, username)
+}
+
+// GetInbox returns the unread count with the most recent unread notifications
+func (s *notificationService) GetInbox(ctx context.Context, username string) (*Inbox, error) {
+	count, err := s.repo.CountUnread(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	inbox := &Inbox{
+		UnreadCount:   count,
+		Notifications: []*models.Notification{},
+	}
+	if count == 0 {
+		return inbox, nil
+	}
+
+	unread, err := s.repo.ListByUsername(ctx, username, true, s.inboxLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+	inbox.Notifications = unread
+
+	return inbox, nil
+}
+
+// MarkRead marks one of the player's notifications as read
+func (s *notificationService) MarkRead(ctx context.Context, username, id string) error {
+	found, err := s.repo.MarkRead(ctx, username, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("%w: %s", ErrNotificationNotFound, id)
+	}
+	return nil
+}
+
+// MarkAllRead marks all of the player's notifications as read
+func (s *notificationService) MarkAllRead(ctx context.Context, username string) (int64, error) {
+	return s.repo.MarkAllRead(ctx, username)
+}
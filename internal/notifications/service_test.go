@@ -0,0 +1,118 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"connect4-multiplayer/internal/database/repositories"
+	"connect4-multiplayer/pkg/models"
+)
+
+// recordingPublisher delivers to a fixed set of online players
+type recordingPublisher struct {
+	mu        sync.Mutex
+	online    map[string]bool
+	delivered []*models.Notification
+}
+
+func (p *recordingPublisher) PublishNotification(username string, notification *models.Notification) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.online[username] {
+		return errors.New("user not connected")
+	}
+	p.delivered = append(p.delivered, notification)
+	return nil
+}
+
+type NotificationServiceTestSuite struct {
+	suite.Suite
+	service   NotificationService
+	publisher *recordingPublisher
+	ctx       context.Context
+}
+
+func (suite *NotificationServiceTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	suite.Require().NoError(err)
+	suite.Require().NoError(db.AutoMigrate(&models.Notification{}))
+
+	suite.publisher = &recordingPublisher{online: map[string]bool{"alice": true}}
+	suite.service = NewNotificationService(repositories.NewNotificationRepository(db), nil)
+	suite.service.SetPublisher(suite.publisher)
+	suite.ctx = context.Background()
+}
+
+func (suite *NotificationServiceTestSuite) TestNotifyPushesToOnlinePlayers() {
+	_, err := suite.service.Notify(suite.ctx, "alice", models.NotificationChallenge, "New challenge", "bobby challenged you", models.NotificationData{"from": "bobby"})
+	suite.Require().NoError(err)
+	_, err = suite.service.Notify(suite.ctx, "bobby", models.NotificationFriendRequest, "Friend request", "alice wants to be your friend", nil)
+	suite.Require().NoError(err)
+
+	suite.Require().Len(suite.publisher.delivered, 1)
+	assert.Equal(suite.T(), "alice", suite.publisher.delivered[0].Username)
+}
+
+func (suite *NotificationServiceTestSuite) TestOfflinePlayerSeesInbox() {
+	for _, title := range []string{"first", "second", "third"} {
+		_, err := suite.service.Notify(suite.ctx, "bobby", models.NotificationAchievement, title, "", models.NotificationData{"achievementId": title})
+		suite.Require().NoError(err)
+	}
+
+	inbox, err := suite.service.GetInbox(suite.ctx, "bobby")
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), int64(3), inbox.UnreadCount)
+	assert.Len(suite.T(), inbox.Notifications, 3)
+
+	empty, err := suite.service.GetInbox(suite.ctx, "carol")
+	suite.Require().NoError(err)
+	assert.Zero(suite.T(), empty.UnreadCount)
+	assert.Empty(suite.T(), empty.Notifications)
+}
+
+func (suite *NotificationServiceTestSuite) TestMarkRead() {
+	first, err := suite.service.Notify(suite.ctx, "bobby", models.NotificationChallenge, "one", "", nil)
+	suite.Require().NoError(err)
+	_, err = suite.service.Notify(suite.ctx, "bobby", models.NotificationChallenge, "two", "", nil)
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.service.MarkRead(suite.ctx, "bobby", first.ID))
+	// Marking twice is harmless
+	suite.Require().NoError(suite.service.MarkRead(suite.ctx, "bobby", first.ID))
+
+	// Players cannot touch each other's notifications
+	err = suite.service.MarkRead(suite.ctx, "alice", first.ID)
+	assert.ErrorIs(suite.T(), err, ErrNotificationNotFound)
+
+	unread, err := suite.service.List(suite.ctx, "bobby", true, 10, 0)
+	suite.Require().NoError(err)
+	suite.Require().Len(unread, 1)
+	assert.Equal(suite.T(), "two", unread[0].Title)
+
+	updated, err := suite.service.MarkAllRead(suite.ctx, "bobby")
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), int64(1), updated)
+
+	count, err := suite.service.UnreadCount(suite.ctx, "bobby")
+	suite.Require().NoError(err)
+	assert.Zero(suite.T(), count)
+
+	all, err := suite.service.List(suite.ctx, "bobby", false, 10, 0)
+	suite.Require().NoError(err)
+	assert.Len(suite.T(), all, 2)
+	assert.True(suite.T(), all[0].IsRead())
+}
+
+func TestNotificationServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(NotificationServiceTestSuite))
+}
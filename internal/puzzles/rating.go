@@ -0,0 +1,67 @@
+package puzzles
+
+import (
+	"math"
+
+	"connect4-multiplayer/internal/bot"
+)
+
+const (
+	// defaultPlayerRating is the puzzle rating of a player's first attempt
+	defaultPlayerRating = 1200.0
+
+	// A win in two starts at baseRating; each extra move, and each feature
+	// that makes a win harder to spot, adds to it
+	baseRating        = 1000.0
+	ratingPerMove     = 200.0
+	hiddenRatingBonus = 150.0
+	uniqueRatingBonus = 50.0
+	missedRatingBonus = 100.0
+
+	// K-factors: how far one attempt moves a rating. Puzzles with fewer than
+	// provisionalAttempts attempts move faster.
+	playerK             = 32.0
+	provisionalPuzzleK  = 48.0
+	establishedPuzzleK  = 16.0
+	provisionalAttempts = 20
+)
+
+// initialRating estimates a new puzzle's difficulty before anyone tries it.
+// Longer wins are harder, as are wins a shallow search overlooks, wins with
+// a single solution and wins the player in the game missed.
+func initialRating(win *bot.ForcedWin, missed bool) float64 {
+	rating := baseRating + ratingPerMove*float64(win.Moves-2)
+	if win.Hidden {
+		rating += hiddenRatingBonus
+	}
+	if len(win.Solutions) == 1 {
+		rating += uniqueRatingBonus
+	}
+	if missed {
+		rating += missedRatingBonus
+	}
+	return rating
+}
+
+// expectedSolve is the chance that a player solves a puzzle, treating the
+// attempt as an Elo game between the two ratings
+func expectedSolve(playerRating, puzzleRating float64) float64 {
+	return 1 / (1 + math.Pow(10, (puzzleRating-playerRating)/400))
+}
+
+// ratingChanges returns how far an attempt moves the player's and the
+// puzzle's ratings. New puzzles move faster so their starting estimate is
+// corrected quickly.
+func ratingChanges(playerRating, puzzleRating float64, puzzleAttempts int, solved bool) (player, puzzle float64) {
+	result := 0.0
+	if solved {
+		result = 1
+	}
+	surprise := result - expectedSolve(playerRating, puzzleRating)
+
+	puzzleK := establishedPuzzleK
+	if puzzleAttempts < provisionalAttempts {
+		puzzleK = provisionalPuzzleK
+	}
+	return playerK * surprise, -puzzleK * surprise
+}
@@ -0,0 +1,372 @@
+package puzzles
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"connect4-multiplayer/internal/bot"
+	"connect4-multiplayer/internal/database/repositories"
+	"connect4-multiplayer/pkg/models"
+)
+
+var (
+	// ErrPuzzleNotFound is returned when a puzzle does not exist
+	ErrPuzzleNotFound = errors.New("puzzle not found")
+	// ErrNoPuzzles is returned when a player has tried every puzzle
+	ErrNoPuzzles = errors.New("no puzzles left")
+	// ErrGameNotCompleted is returned when asked to mine a game still in progress
+	ErrGameNotCompleted = errors.New("game is not completed")
+)
+
+// Challenge is a puzzle served to a player
+type Challenge struct {
+	Puzzle       *models.Puzzle `json:"puzzle"`
+	Board        models.Board   `json:"board"` // the position after Puzzle.Moves
+	PlayerRating float64        `json:"playerRating"`
+}
+
+// AttemptResult is the verdict on a player's move in a puzzle
+type AttemptResult struct {
+	PuzzleID  string `json:"puzzleId"`
+	Column    int    `json:"column"`
+	Solved    bool   `json:"solved"`
+	Solutions []int  `json:"solutions"`
+	// Rated is false for repeat attempts, which leave both ratings alone
+	Rated              bool    `json:"rated"`
+	PlayerRating       float64 `json:"playerRating"`
+	PlayerRatingChange float64 `json:"playerRatingChange"`
+	PuzzleRating       float64 `json:"puzzleRating"`
+}
+
+// PuzzleService mines forced wins from completed games and serves them
+type PuzzleService interface {
+	// OnGameEvent queues a game for mining when it completes
+	OnGameEvent(ctx context.Context, event *models.GameEvent)
+	// MineGame stores a puzzle for every new forced win in a completed game
+	MineGame(ctx context.Context, gameID string) ([]*models.Puzzle, error)
+	// NextPuzzle picks the untried puzzle closest to the player's rating
+	NextPuzzle(ctx context.Context, username string) (*Challenge, error)
+	// Attempt checks a player's move and rates their first try at a puzzle
+	Attempt(ctx context.Context, puzzleID, username string, column int) (*AttemptResult, error)
+
+	// Start runs the mining workers
+	Start(ctx context.Context)
+	// Stop waits for the workers to finish their current game
+	Stop()
+}
+
+// puzzleService implements PuzzleService interface
+type puzzleService struct {
+	puzzleRepo repositories.PuzzleRepository
+	gameRepo   repositories.GameSessionRepository
+	moveRepo   repositories.MoveRepository
+	config     *ServiceConfig
+	logger     *slog.Logger
+
+	queue  chan string
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// ServiceConfig holds configuration for the puzzle service
+type ServiceConfig struct {
+	// Workers is the number of games mined at once. Each searches on a
+	// single goroutine.
+	Workers int
+	// QueueSize is how many completed games may wait for a worker; games
+	// beyond it, and games queued at shutdown, are not mined
+	QueueSize int
+	// MinMoves and MaxMoves bound the length of a puzzle's forced win,
+	// counting the winning move. Wins in one are too easy to be puzzles.
+	MinMoves int
+	MaxMoves int
+	// PositionTimeout bounds the search of each position
+	PositionTimeout time.Duration
+	Logger          *slog.Logger
+}
+
+// DefaultServiceConfig returns default service configuration
+func DefaultServiceConfig() *ServiceConfig {
+	return &ServiceConfig{
+		Workers:         1,
+		QueueSize:       100,
+		MinMoves:        2,
+		MaxMoves:        4,
+		PositionTimeout: 2 * time.Second,
+		Logger:          slog.Default(),
+	}
+}
+
+// NewPuzzleService creates a new PuzzleService instance
+func NewPuzzleService(
+	puzzleRepo repositories.PuzzleRepository,
+	gameRepo repositories.GameSessionRepository,
+	moveRepo repositories.MoveRepository,
+	config *ServiceConfig,
+) PuzzleService {
+	if config == nil {
+		config = DefaultServiceConfig()
+	}
+	if config.Workers <= 0 {
+		config.Workers = 1
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = 1
+	}
+	if config.MinMoves < 1 {
+		config.MinMoves = 1
+	}
+	if config.MaxMoves < config.MinMoves {
+		config.MaxMoves = config.MinMoves
+	}
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+
+	return &puzzleService{
+		puzzleRepo: puzzleRepo,
+		gameRepo:   gameRepo,
+		moveRepo:   moveRepo,
+		config:     config,
+		logger:     config.Logger.With("component", "puzzles"),
+		queue:      make(chan string, config.QueueSize),
+	}
+}
+
+// OnGameEvent queues a game for mining when it completes
+func (s *puzzleService) OnGameEvent(ctx context.Context, event *models.GameEvent) {
+	if event == nil || event.EventType != models.EventGameCompleted {
+		return
+	}
+
+	select {
+	case s.queue <- event.GameID:
+	default:
+		s.logger.Warn("puzzle queue full, game not mined", "gameID", event.GameID)
+	}
+}
+
+// MineGame replays a completed game and stores a puzzle for each position
+// where the player to move had a forced win of puzzle length, whether they
+// found it or not. Positions that merely continue a win the player already
+// had are skipped, so one combination yields one puzzle. Handicap games
+// yield none.
+func (s *puzzleService) MineGame(ctx context.Context, gameID string) ([]*models.Puzzle, error) {
+	session, err := s.gameRepo.GetByID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsCompleted() {
+		return nil, ErrGameNotCompleted
+	}
+	if session.Handicap != nil {
+		// Puzzles are replayed from the empty board, which handicap games
+		// do not start from
+		return nil, nil
+	}
+
+	moves, err := s.moveRepo.GetByGameID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	board := models.NewBoard()
+	played := make(models.Columns, 0, len(moves))
+	keptWin := make(map[models.PlayerColor]bool)
+	var created []*models.Puzzle
+
+	for i, move := range moves {
+		if !board.IsValidMove(move.Column) || board.Height[move.Column] != move.Row {
+			return created, fmt.Errorf("move %d in column %d does not replay", i+1, move.Column)
+		}
+
+		win, err := s.findWin(ctx, &board, move.Player)
+		if ctx.Err() != nil {
+			return created, ctx.Err()
+		}
+		if err != nil {
+			s.logger.Warn("skipping puzzle candidate",
+				"gameID", gameID,
+				"ply", i,
+				"error", err,
+			)
+		}
+
+		continuing := keptWin[move.Player]
+		keptWin[move.Player] = win != nil && win.IsSolution(move.Column)
+
+		if win != nil && win.Moves >= s.config.MinMoves && !continuing {
+			missed := !win.IsSolution(move.Column)
+			puzzle := &models.Puzzle{
+				GameID:    gameID,
+				Ply:       i,
+				Moves:     append(models.Columns(nil), played...),
+				Player:    move.Player,
+				WinIn:     win.Moves,
+				Solutions: win.Solutions,
+				Missed:    missed,
+				Rating:    initialRating(win, missed),
+			}
+			isNew, err := s.puzzleRepo.Create(ctx, puzzle)
+			if err != nil {
+				return created, err
+			}
+			if isNew {
+				created = append(created, puzzle)
+			}
+		}
+
+		if err := board.MakeMove(move.Column, move.Player); err != nil {
+			return created, fmt.Errorf("move %d in column %d does not replay", i+1, move.Column)
+		}
+		played = append(played, move.Column)
+	}
+
+	return created, nil
+}
+
+// findWin looks for a forced win of puzzle length within the position timeout
+func (s *puzzleService) findWin(ctx context.Context, board *models.Board, player models.PlayerColor) (*bot.ForcedWin, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.PositionTimeout)
+	defer cancel()
+	return bot.FindForcedWin(ctx, board, player, s.config.MaxMoves)
+}
+
+// NextPuzzle picks the untried puzzle rated closest to the player
+func (s *puzzleService) NextPuzzle(ctx context.Context, username string) (*Challenge, error) {
+	rating, err := s.playerRating(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	puzzle, err := s.puzzleRepo.NextForPlayer(ctx, username, rating)
+	if err != nil {
+		return nil, err
+	}
+	if puzzle == nil {
+		return nil, ErrNoPuzzles
+	}
+
+	board, _, err := bot.PositionFromMoves(puzzle.Moves)
+	if err != nil {
+		return nil, fmt.Errorf("puzzle %s: %w", puzzle.ID, err)
+	}
+
+	return &Challenge{Puzzle: puzzle, Board: board, PlayerRating: rating}, nil
+}
+
+// Attempt checks a player's move. The first attempt at a puzzle moves the
+// player's and the puzzle's ratings; later ones only report the verdict.
+func (s *puzzleService) Attempt(ctx context.Context, puzzleID, username string, column int) (*AttemptResult, error) {
+	if column < 0 || column >= 7 {
+		return nil, fmt.Errorf("%w: column %d", models.ErrInvalidMove, column)
+	}
+
+	puzzle, err := s.puzzleRepo.GetByID(ctx, puzzleID)
+	if err != nil {
+		return nil, err
+	}
+	if puzzle == nil {
+		return nil, fmt.Errorf("%w: %s", ErrPuzzleNotFound, puzzleID)
+	}
+
+	rating, err := s.playerRating(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AttemptResult{
+		PuzzleID:     puzzle.ID,
+		Column:       column,
+		Solved:       puzzle.IsSolution(column),
+		Solutions:    puzzle.Solutions,
+		PlayerRating: rating,
+		PuzzleRating: puzzle.Rating,
+	}
+
+	previous, err := s.puzzleRepo.GetAttempt(ctx, username, puzzle.ID)
+	if err != nil || previous != nil {
+		return result, err
+	}
+
+	playerDelta, puzzleDelta := ratingChanges(rating, puzzle.Rating, puzzle.Attempts, result.Solved)
+	attempt := &models.PuzzleAttempt{
+		Username:     username,
+		PuzzleID:     puzzle.ID,
+		Column:       column,
+		Solved:       result.Solved,
+		PlayerRating: rating + playerDelta,
+	}
+	recorded, err := s.puzzleRepo.RecordAttempt(ctx, attempt, puzzleDelta)
+	if err != nil || !recorded {
+		// A concurrent first attempt won the race and was rated instead
+		return result, err
+	}
+
+	result.Rated = true
+	result.PlayerRating = attempt.PlayerRating
+	result.PlayerRatingChange = playerDelta
+	result.PuzzleRating = puzzle.Rating + puzzleDelta
+	return result, nil
+}
+
+// playerRating returns the player's current puzzle rating
+func (s *puzzleService) playerRating(ctx context.Context, username string) (float64, error) {
+	latest, err := s.puzzleRepo.LatestAttempt(ctx, username)
+	if err != nil {
+		return 0, err
+	}
+	if latest == nil {
+		return defaultPlayerRating, nil
+	}
+	return latest.PlayerRating, nil
+}
+
+// Start runs the mining workers
+func (s *puzzleService) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	for i := 0; i < s.config.Workers; i++ {
+		s.wg.Add(1)
+		go s.work(ctx)
+	}
+}
+
+// Stop waits for the workers to finish their current game
+func (s *puzzleService) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+// work mines queued games until ctx is cancelled
+func (s *puzzleService) work(ctx context.Context) {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case gameID := <-s.queue:
+			start := time.Now()
+			created, err := s.MineGame(ctx, gameID)
+			if err != nil {
+				if ctx.Err() == nil {
+					s.logger.Warn("failed to mine game for puzzles", "gameID", gameID, "error", err)
+				}
+				continue
+			}
+			if len(created) > 0 {
+				s.logger.Info("puzzles mined",
+					"gameID", gameID,
+					"count", len(created),
+					"duration", time.Since(start).String(),
+				)
+			}
+		}
+	}
+}
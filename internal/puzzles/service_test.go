@@ -0,0 +1,244 @@
+package puzzles
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"connect4-multiplayer/internal/bot"
+	"connect4-multiplayer/internal/database/repositories"
+	"connect4-multiplayer/pkg/models"
+)
+
+type PuzzleServiceTestSuite struct {
+	suite.Suite
+	db         *gorm.DB
+	gameRepo   repositories.GameSessionRepository
+	moveRepo   repositories.MoveRepository
+	puzzleRepo repositories.PuzzleRepository
+	service    PuzzleService
+	ctx        context.Context
+}
+
+func (suite *PuzzleServiceTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	suite.Require().NoError(err)
+	suite.Require().NoError(db.AutoMigrate(&models.GameSession{}, &models.Move{}, &models.Puzzle{}, &models.PuzzleAttempt{}))
+
+	suite.db = db
+	suite.gameRepo = repositories.NewGameSessionRepository(db)
+	suite.moveRepo = repositories.NewMoveRepository(db)
+	suite.puzzleRepo = repositories.NewPuzzleRepository(db)
+	suite.service = NewPuzzleService(suite.puzzleRepo, suite.gameRepo, suite.moveRepo, DefaultServiceConfig())
+	suite.ctx = context.Background()
+}
+
+// playGame stores a game with the given columns, red first, and marks it
+// completed unless the game is still running
+func (suite *PuzzleServiceTestSuite) playGame(columns []int, completed bool) string {
+	session := &models.GameSession{Player1: "alice", Player2: "bobby", Status: models.StatusInProgress}
+	suite.Require().NoError(suite.gameRepo.Create(suite.ctx, session))
+
+	board := models.NewBoard()
+	player := models.PlayerColorRed
+	start := time.Now().Add(-time.Hour)
+	for i, col := range columns {
+		row := board.Height[col]
+		suite.Require().NoError(board.MakeMove(col, player))
+		suite.Require().NoError(suite.moveRepo.Create(suite.ctx, &models.Move{
+			GameID:    session.ID,
+			Player:    player,
+			Column:    col,
+			Row:       row,
+			Timestamp: start.Add(time.Duration(i) * time.Second),
+		}))
+		if player == models.PlayerColorRed {
+			player = models.PlayerColorYellow
+		} else {
+			player = models.PlayerColorRed
+		}
+	}
+
+	if completed {
+		session.Status = models.StatusCompleted
+		session.Winner = board.CheckWin()
+		suite.Require().NoError(suite.db.Save(session).Error)
+	}
+	return session.ID
+}
+
+// Red sets up an open three on the bottom row with its fifth move and wins
+var foundWin = []int{1, 1, 2, 2, 3, 6, 4}
+
+func (suite *PuzzleServiceTestSuite) TestMineGameFindsForcedWin() {
+	gameID := suite.playGame(foundWin, true)
+
+	created, err := suite.service.MineGame(suite.ctx, gameID)
+	suite.Require().NoError(err)
+	suite.Require().Len(created, 1, "the win in one that follows continues the same combination")
+
+	puzzle := created[0]
+	assert.Equal(suite.T(), 4, puzzle.Ply)
+	assert.Equal(suite.T(), models.Columns{1, 1, 2, 2}, puzzle.Moves)
+	assert.Equal(suite.T(), models.PlayerColorRed, puzzle.Player)
+	assert.Equal(suite.T(), 2, puzzle.WinIn)
+	assert.Equal(suite.T(), models.Columns{3}, puzzle.Solutions)
+	assert.False(suite.T(), puzzle.Missed)
+	assert.GreaterOrEqual(suite.T(), puzzle.Rating, baseRating)
+
+	again, err := suite.service.MineGame(suite.ctx, gameID)
+	suite.Require().NoError(err)
+	assert.Empty(suite.T(), again, "a game is mined once")
+}
+
+func (suite *PuzzleServiceTestSuite) TestMineGameRecordsMissedWins() {
+	gameID := suite.playGame([]int{1, 1, 2, 2, 5, 3}, true)
+
+	created, err := suite.service.MineGame(suite.ctx, gameID)
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(created)
+
+	puzzle := created[0]
+	assert.Equal(suite.T(), 4, puzzle.Ply)
+	assert.True(suite.T(), puzzle.Missed)
+	assert.Equal(suite.T(), initialRating(&bot.ForcedWin{Moves: 2, Solutions: []int{3}}, true), puzzle.Rating)
+}
+
+func (suite *PuzzleServiceTestSuite) TestMineGameRejectsActiveGames() {
+	gameID := suite.playGame([]int{3}, false)
+
+	_, err := suite.service.MineGame(suite.ctx, gameID)
+	assert.ErrorIs(suite.T(), err, ErrGameNotCompleted)
+}
+
+func (suite *PuzzleServiceTestSuite) TestMineGameSkipsHandicapGames() {
+	gameID := suite.playGame([]int{1, 1, 2, 2, 5, 3}, true)
+	handicap := &models.Handicap{Player: models.PlayerColorRed, MovesFirst: true}
+	suite.Require().NoError(suite.db.Model(&models.GameSession{}).Where("id = ?", gameID).Update("handicap", handicap).Error)
+
+	created, err := suite.service.MineGame(suite.ctx, gameID)
+	suite.Require().NoError(err)
+	assert.Empty(suite.T(), created)
+}
+
+func (suite *PuzzleServiceTestSuite) TestAttemptAdjustsRatings() {
+	_, err := suite.service.NextPuzzle(suite.ctx, "alice")
+	assert.ErrorIs(suite.T(), err, ErrNoPuzzles)
+
+	_, err = suite.service.MineGame(suite.ctx, suite.playGame(foundWin, true))
+	suite.Require().NoError(err)
+
+	challenge, err := suite.service.NextPuzzle(suite.ctx, "alice")
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), defaultPlayerRating, challenge.PlayerRating)
+	assert.Equal(suite.T(), models.PlayerColorRed, challenge.Board.Grid[0][2])
+	assert.Equal(suite.T(), [7]int{0, 2, 2, 0, 0, 0, 0}, challenge.Board.Height)
+	puzzle := challenge.Puzzle
+
+	solved, err := suite.service.Attempt(suite.ctx, puzzle.ID, "alice", 3)
+	suite.Require().NoError(err)
+	assert.True(suite.T(), solved.Solved)
+	assert.True(suite.T(), solved.Rated)
+	assert.Greater(suite.T(), solved.PlayerRating, defaultPlayerRating)
+	assert.Less(suite.T(), solved.PuzzleRating, puzzle.Rating)
+	assert.Equal(suite.T(), []int{3}, solved.Solutions)
+
+	repeat, err := suite.service.Attempt(suite.ctx, puzzle.ID, "alice", 0)
+	suite.Require().NoError(err)
+	assert.False(suite.T(), repeat.Solved)
+	assert.False(suite.T(), repeat.Rated, "only the first attempt is rated")
+	assert.Equal(suite.T(), solved.PlayerRating, repeat.PlayerRating)
+	assert.InDelta(suite.T(), solved.PuzzleRating, repeat.PuzzleRating, 1e-9)
+
+	failed, err := suite.service.Attempt(suite.ctx, puzzle.ID, "bobby", 0)
+	suite.Require().NoError(err)
+	assert.True(suite.T(), failed.Rated)
+	assert.Less(suite.T(), failed.PlayerRating, defaultPlayerRating)
+	assert.Greater(suite.T(), failed.PuzzleRating, solved.PuzzleRating)
+
+	stored, err := suite.puzzleRepo.GetByID(suite.ctx, puzzle.ID)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 2, stored.Attempts)
+	assert.Equal(suite.T(), 1, stored.Solves)
+	assert.InDelta(suite.T(), failed.PuzzleRating, stored.Rating, 1e-9)
+
+	_, err = suite.service.NextPuzzle(suite.ctx, "alice")
+	assert.ErrorIs(suite.T(), err, ErrNoPuzzles, "tried puzzles are not served again")
+}
+
+func (suite *PuzzleServiceTestSuite) TestAttemptErrors() {
+	_, err := suite.service.Attempt(suite.ctx, "missing", "alice", 3)
+	assert.ErrorIs(suite.T(), err, ErrPuzzleNotFound)
+
+	_, err = suite.service.Attempt(suite.ctx, "missing", "alice", 7)
+	assert.ErrorIs(suite.T(), err, models.ErrInvalidMove)
+}
+
+func (suite *PuzzleServiceTestSuite) TestNextPuzzleMatchesRating() {
+	for i, rating := range []float64{900, 1300, 1700} {
+		_, err := suite.puzzleRepo.Create(suite.ctx, &models.Puzzle{
+			GameID: "game", Ply: i, Moves: models.Columns{}, Player: models.PlayerColorRed,
+			WinIn: 2, Solutions: models.Columns{3}, Rating: rating,
+		})
+		suite.Require().NoError(err)
+	}
+
+	challenge, err := suite.service.NextPuzzle(suite.ctx, "alice")
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 1300.0, challenge.Puzzle.Rating)
+}
+
+func (suite *PuzzleServiceTestSuite) TestWorkerMinesCompletedGames() {
+	gameID := suite.playGame(foundWin, true)
+
+	suite.service.Start(suite.ctx)
+	defer suite.service.Stop()
+
+	suite.service.OnGameEvent(suite.ctx, models.NewGameStartedEvent(gameID, "alice", "bobby"))
+	suite.service.OnGameEvent(suite.ctx, models.NewGameCompletedEvent(gameID, "alice", "bobby", 30))
+	suite.Eventually(func() bool {
+		_, err := suite.service.NextPuzzle(suite.ctx, "carol")
+		return err == nil
+	}, 10*time.Second, 20*time.Millisecond)
+}
+
+func TestPuzzleServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PuzzleServiceTestSuite))
+}
+
+func TestInitialRating(t *testing.T) {
+	easy := &bot.ForcedWin{Moves: 2, Solutions: []int{2, 4}}
+	assert.Equal(t, baseRating, initialRating(easy, false))
+
+	longer := &bot.ForcedWin{Moves: 3, Solutions: []int{2, 4}}
+	assert.Greater(t, initialRating(longer, false), initialRating(easy, false))
+
+	hidden := &bot.ForcedWin{Moves: 2, Solutions: []int{2, 4}, Hidden: true}
+	assert.Greater(t, initialRating(hidden, false), initialRating(easy, false))
+	assert.Greater(t, initialRating(easy, true), initialRating(easy, false))
+}
+
+func TestRatingChanges(t *testing.T) {
+	assert.InDelta(t, 0.5, expectedSolve(1200, 1200), 1e-9)
+	assert.Greater(t, expectedSolve(1600, 1200), 0.9)
+
+	player, puzzle := ratingChanges(1200, 1200, 0, true)
+	assert.InDelta(t, playerK/2, player, 1e-9)
+	assert.InDelta(t, -provisionalPuzzleK/2, puzzle, 1e-9)
+
+	player, puzzle = ratingChanges(1200, 1200, provisionalAttempts, false)
+	assert.InDelta(t, -playerK/2, player, 1e-9)
+	assert.InDelta(t, establishedPuzzleK/2, puzzle, 1e-9)
+
+	// An expected solve barely moves either rating
+	player, _ = ratingChanges(2000, 1000, 0, true)
+	assert.Less(t, player, 1.0)
+}
@@ -0,0 +1,109 @@
+package review
+
+import (
+	"math"
+
+	"connect4-multiplayer/internal/bot"
+	"connect4-multiplayer/pkg/models"
+)
+
+const (
+	// evalScale converts minimax scores into expected scores; a score of
+	// evalScale gives the mover about a 73% expectation
+	evalScale = 250.0
+
+	// Expected score a move may lose before it is classified as worse
+	inaccuracyThreshold = 0.10
+	mistakeThreshold    = 0.20
+	blunderThreshold    = 0.30
+)
+
+// expectedScore converts a column's analysis into the mover's chances,
+// using the proven outcome when there is one
+func expectedScore(column bot.ColumnAnalysis) float64 {
+	switch column.Outcome {
+	case bot.OutcomeWin:
+		return 1
+	case bot.OutcomeDraw:
+		return 0.5
+	case bot.OutcomeLoss:
+		return 0
+	}
+	return 1 / (1 + math.Exp(-float64(column.Score)/evalScale))
+}
+
+// classify grades a move by the expected score it gave away
+func classify(loss float64, best bool) models.MoveClassification {
+	switch {
+	case best || loss <= 0:
+		return models.MoveBest
+	case loss < inaccuracyThreshold:
+		return models.MoveGood
+	case loss < mistakeThreshold:
+		return models.MoveInaccuracy
+	case loss < blunderThreshold:
+		return models.MoveMistake
+	default:
+		return models.MoveBlunder
+	}
+}
+
+// moveAccuracy maps the expected score lost by a move to 0-100, where the
+// best move scores 100 and accuracy falls off exponentially with the loss
+func moveAccuracy(loss float64) float64 {
+	accuracy := 103.1668*math.Exp(-4.354*math.Max(loss, 0)) - 3.1669
+	return math.Max(0, math.Min(100, accuracy))
+}
+
+// annotate reviews the move in column played from a position analysis
+func annotate(ply int, player models.PlayerColor, column int, analysis *bot.PositionAnalysis) models.MoveAnnotation {
+	bestColumn := analysis.BestMove
+	bestExpected := -1.0
+	for _, candidate := range analysis.Columns {
+		if !candidate.Playable {
+			continue
+		}
+		expected := expectedScore(candidate)
+		if expected > bestExpected || (expected == bestExpected && candidate.Column == analysis.BestMove) {
+			bestExpected, bestColumn = expected, candidate.Column
+		}
+	}
+
+	played := expectedScore(analysis.Columns[column])
+	loss := math.Max(0, bestExpected-played)
+
+	return models.MoveAnnotation{
+		Ply:            ply,
+		Player:         player,
+		Column:         column,
+		BestColumn:     bestColumn,
+		Expected:       played,
+		BestExpected:   bestExpected,
+		Loss:           loss,
+		Classification: classify(loss, column == bestColumn),
+		Solved:         analysis.Solved,
+	}
+}
+
+// accuracies averages move accuracy per player; a player without moves
+// scores 0
+func accuracies(moves models.MoveAnnotations) (red, yellow float64) {
+	var redTotal, yellowTotal float64
+	var redMoves, yellowMoves int
+	for _, move := range moves {
+		if move.Player == models.PlayerColorRed {
+			redTotal += moveAccuracy(move.Loss)
+			redMoves++
+		} else {
+			yellowTotal += moveAccuracy(move.Loss)
+			yellowMoves++
+		}
+	}
+	if redMoves > 0 {
+		red = redTotal / float64(redMoves)
+	}
+	if yellowMoves > 0 {
+		yellow = yellowTotal / float64(yellowMoves)
+	}
+	return red, yellow
+}
@@ -0,0 +1,333 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"connect4-multiplayer/internal/bot"
+	"connect4-multiplayer/internal/database/repositories"
+	"connect4-multiplayer/pkg/models"
+)
+
+// ErrGameNotCompleted is returned when asked to review a game still in progress
+var ErrGameNotCompleted = errors.New("game is not completed")
+
+// ReviewService annotates completed games in the background
+type ReviewService interface {
+	// OnGameEvent queues a review when a game completes
+	OnGameEvent(ctx context.Context, event *models.GameEvent)
+	// Enqueue records a pending review and queues it unless the game already
+	// has one
+	Enqueue(ctx context.Context, gameID string) error
+	// GetReview returns a game's review, queueing one for completed games
+	// that have none or whose pending review is not queued
+	GetReview(ctx context.Context, gameID string) (*models.GameReview, error)
+	// ReviewGame analyzes a game now and stores the result
+	ReviewGame(ctx context.Context, gameID string) (*models.GameReview, error)
+
+	// Start runs the workers and resumes reviews left pending by a restart
+	Start(ctx context.Context)
+	// Stop waits for the workers to finish their current review
+	Stop()
+}
+
+// reviewService implements ReviewService interface
+type reviewService struct {
+	reviewRepo repositories.ReviewRepository
+	gameRepo   repositories.GameSessionRepository
+	moveRepo   repositories.MoveRepository
+	analyzer   bot.PositionAnalyzer
+	config     *ServiceConfig
+	logger     *slog.Logger
+
+	queue  chan string
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// scheduled holds the games queued or under review, so that reviews
+	// left pending by a failure or a full queue can be queued again
+	scheduledMu sync.Mutex
+	scheduled   map[string]bool
+}
+
+// ServiceConfig holds configuration for the review service
+type ServiceConfig struct {
+	// Workers is the number of games reviewed at once. Reviews search on a
+	// single goroutine each, so this bounds the CPUs they take from live games.
+	Workers int
+	// QueueSize is how many games may wait for a worker; games beyond it
+	// stay pending until their review is requested or the next start
+	QueueSize int
+	// PositionTimeout bounds the analysis of each position
+	PositionTimeout time.Duration
+	// MaxDepth is the deepest minimax search per position
+	MaxDepth int
+	Logger   *slog.Logger
+}
+
+// DefaultServiceConfig returns default service configuration
+func DefaultServiceConfig() *ServiceConfig {
+	return &ServiceConfig{
+		Workers:         1,
+		QueueSize:       100,
+		PositionTimeout: 250 * time.Millisecond,
+		MaxDepth:        10,
+		Logger:          slog.Default(),
+	}
+}
+
+// NewReviewService creates a new ReviewService instance
+func NewReviewService(
+	reviewRepo repositories.ReviewRepository,
+	gameRepo repositories.GameSessionRepository,
+	moveRepo repositories.MoveRepository,
+	config *ServiceConfig,
+) ReviewService {
+	if config == nil {
+		config = DefaultServiceConfig()
+	}
+	if config.Workers <= 0 {
+		config.Workers = 1
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = 1
+	}
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+
+	analysisConfig := bot.AnalysisConfig{
+		Timeout:       config.PositionTimeout,
+		MaxDepth:      config.MaxDepth,
+		MaxConcurrent: config.Workers,
+		Background:    true,
+	}
+
+	return &reviewService{
+		reviewRepo: reviewRepo,
+		gameRepo:   gameRepo,
+		moveRepo:   moveRepo,
+		analyzer:   bot.NewPositionAnalyzer(analysisConfig),
+		config:     config,
+		logger:     config.Logger.With("component", "reviews"),
+		queue:      make(chan string, config.QueueSize),
+		scheduled:  make(map[string]bool),
+	}
+}
+
+// OnGameEvent queues a review when a game completes
+func (s *reviewService) OnGameEvent(ctx context.Context, event *models.GameEvent) {
+	if event == nil || event.EventType != models.EventGameCompleted {
+		return
+	}
+	if err := s.Enqueue(ctx, event.GameID); err != nil {
+		s.logger.Warn("failed to queue game review",
+			"gameID", event.GameID,
+			"error", err,
+		)
+	}
+}
+
+// Enqueue records a pending review and queues it unless the game already has one
+func (s *reviewService) Enqueue(ctx context.Context, gameID string) error {
+	created, err := s.reviewRepo.CreatePending(ctx, gameID)
+	if err != nil {
+		return err
+	}
+	if created {
+		s.schedule(gameID)
+	}
+	return nil
+}
+
+// schedule hands a game to the workers without blocking the caller, unless
+// it is already queued or under review
+func (s *reviewService) schedule(gameID string) {
+	s.scheduledMu.Lock()
+	defer s.scheduledMu.Unlock()
+
+	if s.scheduled[gameID] {
+		return
+	}
+	select {
+	case s.queue <- gameID:
+		s.scheduled[gameID] = true
+	default:
+		s.logger.Warn("review queue full, review stays pending until requested again", "gameID", gameID)
+	}
+}
+
+// unschedule lets a game be queued again once a worker is done with it
+func (s *reviewService) unschedule(gameID string) {
+	s.scheduledMu.Lock()
+	defer s.scheduledMu.Unlock()
+
+	delete(s.scheduled, gameID)
+}
+
+// GetReview returns a game's review, queueing one for completed games that
+// have none. A pending review that no worker holds, because its last attempt
+// failed or the queue was full, is queued again.
+func (s *reviewService) GetReview(ctx context.Context, gameID string) (*models.GameReview, error) {
+	if gameID == "" {
+		return nil, fmt.Errorf("game ID cannot be empty")
+	}
+
+	review, err := s.reviewRepo.GetByGameID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if review != nil {
+		if review.Status == models.ReviewPending {
+			s.schedule(gameID)
+		}
+		return review, nil
+	}
+
+	// Games that finished before reviews existed, or whose review was lost
+	session, err := s.gameRepo.GetByID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsCompleted() {
+		return nil, ErrGameNotCompleted
+	}
+	if err := s.Enqueue(ctx, gameID); err != nil {
+		return nil, err
+	}
+	return s.reviewRepo.GetByGameID(ctx, gameID)
+}
+
+// ReviewGame analyzes every move of a completed game and stores the review.
+// A game whose moves cannot be replayed is stored as a failed review.
+func (s *reviewService) ReviewGame(ctx context.Context, gameID string) (*models.GameReview, error) {
+	session, err := s.gameRepo.GetByID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsCompleted() {
+		return nil, ErrGameNotCompleted
+	}
+
+	moves, err := s.moveRepo.GetByGameID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	review := &models.GameReview{GameID: gameID, Status: models.ReviewCompleted}
+	annotations, err := s.annotateMoves(ctx, session, moves)
+	if ctx.Err() != nil {
+		// Shutting down; the review stays pending and resumes on restart
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		review.Status = models.ReviewFailed
+		review.Error = err.Error()
+	} else {
+		review.Moves = annotations
+		review.RedAccuracy, review.YellowAccuracy = accuracies(annotations)
+	}
+
+	now := time.Now()
+	review.CompletedAt = &now
+	if err := s.reviewRepo.Save(ctx, review); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// annotateMoves replays a game from its initial board and annotates each
+// move, after checking that the history holds every move of the game
+func (s *reviewService) annotateMoves(ctx context.Context, session *models.GameSession, moves []*models.Move) (models.MoveAnnotations, error) {
+	board, err := session.InitialBoard()
+	if err != nil {
+		return nil, err
+	}
+
+	// A game whose moves were not all recorded would be reviewed as a
+	// shorter game, so it is failed instead
+	if played := session.Board.DiscCount() - board.DiscCount(); played != len(moves) {
+		return nil, fmt.Errorf("move history has %d moves but %d were played", len(moves), played)
+	}
+	annotations := make(models.MoveAnnotations, 0, len(moves))
+
+	for i, move := range moves {
+		if !board.IsValidMove(move.Column) || board.Height[move.Column] != move.Row {
+			return nil, fmt.Errorf("move %d in column %d does not replay", i+1, move.Column)
+		}
+
+		analysis, err := s.analyzer.Analyze(ctx, &board, move.Player)
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze move %d: %w", i+1, err)
+		}
+		annotations = append(annotations, annotate(i+1, move.Player, move.Column, analysis))
+
+		if err := board.MakeMove(move.Column, move.Player); err != nil {
+			return nil, fmt.Errorf("move %d in column %d does not replay", i+1, move.Column)
+		}
+	}
+
+	return annotations, nil
+}
+
+// Start runs the workers and resumes reviews left pending by a restart
+func (s *reviewService) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	for i := 0; i < s.config.Workers; i++ {
+		s.wg.Add(1)
+		go s.work(ctx)
+	}
+
+	pending, err := s.reviewRepo.ListPending(ctx, s.config.QueueSize)
+	if err != nil {
+		s.logger.Warn("failed to resume pending reviews", "error", err)
+		return
+	}
+	for _, review := range pending {
+		s.schedule(review.GameID)
+	}
+	if len(pending) > 0 {
+		s.logger.Info("resumed pending reviews", "count", len(pending))
+	}
+}
+
+// Stop waits for the workers to finish their current review
+func (s *reviewService) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+// work reviews queued games until ctx is cancelled
+func (s *reviewService) work(ctx context.Context) {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case gameID := <-s.queue:
+			start := time.Now()
+			review, err := s.ReviewGame(ctx, gameID)
+			s.unschedule(gameID)
+			if err != nil {
+				if ctx.Err() == nil {
+					// The review stays pending and is queued again when requested
+					s.logger.Warn("failed to review game", "gameID", gameID, "error", err)
+				}
+				continue
+			}
+			s.logger.Info("game reviewed",
+				"gameID", gameID,
+				"status", review.Status,
+				"moves", len(review.Moves),
+				"duration", time.Since(start).String(),
+			)
+		}
+	}
+}
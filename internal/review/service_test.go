@@ -0,0 +1,323 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"connect4-multiplayer/internal/database/repositories"
+	"connect4-multiplayer/pkg/models"
+)
+
+type ReviewServiceTestSuite struct {
+	suite.Suite
+	db         *gorm.DB
+	gameRepo   repositories.GameSessionRepository
+	moveRepo   repositories.MoveRepository
+	reviewRepo repositories.ReviewRepository
+	service    ReviewService
+	ctx        context.Context
+}
+
+func (suite *ReviewServiceTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	suite.Require().NoError(err)
+	suite.Require().NoError(db.AutoMigrate(&models.GameSession{}, &models.Move{}, &models.GameReview{}))
+
+	suite.db = db
+	suite.gameRepo = repositories.NewGameSessionRepository(db)
+	suite.moveRepo = repositories.NewMoveRepository(db)
+	suite.reviewRepo = repositories.NewReviewRepository(db)
+
+	config := DefaultServiceConfig()
+	config.PositionTimeout = 50 * time.Millisecond
+	config.MaxDepth = 6
+	suite.service = NewReviewService(suite.reviewRepo, suite.gameRepo, suite.moveRepo, config)
+	suite.ctx = context.Background()
+}
+
+// playGame stores a game with the given columns, red first, and marks it
+// completed unless the game is still running
+func (suite *ReviewServiceTestSuite) playGame(columns []int, completed bool) string {
+	session := &models.GameSession{Player1: "alice", Player2: "bobby", Status: models.StatusInProgress}
+	suite.Require().NoError(suite.gameRepo.Create(suite.ctx, session))
+
+	board := models.NewBoard()
+	player := models.PlayerColorRed
+	start := time.Now().Add(-time.Hour)
+	for i, col := range columns {
+		row := board.Height[col]
+		suite.Require().NoError(board.MakeMove(col, player))
+		suite.Require().NoError(suite.moveRepo.Create(suite.ctx, &models.Move{
+			GameID:    session.ID,
+			Player:    player,
+			Column:    col,
+			Row:       row,
+			Timestamp: start.Add(time.Duration(i) * time.Second),
+		}))
+		if player == models.PlayerColorRed {
+			player = models.PlayerColorYellow
+		} else {
+			player = models.PlayerColorRed
+		}
+	}
+
+	session.Board = board
+	if completed {
+		session.Status = models.StatusCompleted
+		session.Winner = board.CheckWin()
+		suite.Require().NoError(suite.db.Save(session).Error)
+	}
+	return session.ID
+}
+
+func (suite *ReviewServiceTestSuite) TestReviewClassifiesMoves() {
+	// Red builds three on the bottom row; yellow ignores it and red wins
+	gameID := suite.playGame([]int{0, 6, 1, 6, 2, 5, 3}, true)
+
+	review, err := suite.service.ReviewGame(suite.ctx, gameID)
+	suite.Require().NoError(err)
+
+	assert.Equal(suite.T(), models.ReviewCompleted, review.Status)
+	suite.Require().Len(review.Moves, 7)
+	for i, move := range review.Moves {
+		assert.Equal(suite.T(), i+1, move.Ply)
+	}
+
+	// Yellow's last move leaves an open three on the board
+	missedBlock := review.Moves[5]
+	assert.Equal(suite.T(), models.PlayerColorYellow, missedBlock.Player)
+	assert.Equal(suite.T(), models.MoveBlunder, missedBlock.Classification)
+	assert.Zero(suite.T(), missedBlock.Expected)
+
+	winningMove := review.Moves[6]
+	assert.Equal(suite.T(), models.MoveBest, winningMove.Classification)
+	assert.Equal(suite.T(), 1.0, winningMove.Expected)
+
+	// Time-bound searches vary, but the blunder alone caps yellow's average
+	red, yellow := accuracies(review.Moves)
+	assert.Equal(suite.T(), red, review.RedAccuracy)
+	assert.Equal(suite.T(), yellow, review.YellowAccuracy)
+	assert.Less(suite.T(), review.YellowAccuracy, 70.0)
+	assert.NotNil(suite.T(), review.CompletedAt)
+
+	stored, err := suite.service.GetReview(suite.ctx, gameID)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), review.Moves, stored.Moves)
+}
+
+func (suite *ReviewServiceTestSuite) TestUnreplayableHistoryFails() {
+	gameID := suite.playGame([]int{3, 3}, true)
+	suite.Require().NoError(suite.db.Model(&models.Move{}).Where("game_id = ?", gameID).Update("row", 4).Error)
+
+	review, err := suite.service.ReviewGame(suite.ctx, gameID)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), models.ReviewFailed, review.Status)
+	assert.NotEmpty(suite.T(), review.Error)
+}
+
+func (suite *ReviewServiceTestSuite) TestHandicapGamesReplayFromPreplacedDiscs() {
+	// Red starts with a disc in the center column and moves first
+	session := &models.GameSession{
+		Player1:  "alice",
+		Player2:  "bobby",
+		Status:   models.StatusCompleted,
+		Handicap: &models.Handicap{Player: models.PlayerColorRed, Discs: []int{3}},
+	}
+	suite.Require().NoError(suite.gameRepo.Create(suite.ctx, session))
+	for i, move := range []struct {
+		player      models.PlayerColor
+		column, row int
+	}{
+		{models.PlayerColorRed, 3, 1},
+		{models.PlayerColorYellow, 3, 2},
+	} {
+		suite.Require().NoError(suite.moveRepo.Create(suite.ctx, &models.Move{
+			GameID:    session.ID,
+			Player:    move.player,
+			Column:    move.column,
+			Row:       move.row,
+			Timestamp: time.Now().Add(time.Duration(i) * time.Second),
+		}))
+	}
+
+	suite.Require().NoError(session.Board.MakeMove(3, models.PlayerColorRed))
+	suite.Require().NoError(session.Board.MakeMove(3, models.PlayerColorYellow))
+	suite.Require().NoError(suite.db.Save(session).Error)
+
+	review, err := suite.service.ReviewGame(suite.ctx, session.ID)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), models.ReviewCompleted, review.Status, review.Error)
+	assert.Len(suite.T(), review.Moves, 2)
+}
+
+func (suite *ReviewServiceTestSuite) TestIncompleteHistoryFails() {
+	gameID := suite.playGame([]int{0, 6, 1, 6, 2, 5, 3}, true)
+	suite.Require().NoError(suite.db.Where("game_id = ? AND \"column\" = ?", gameID, 5).Delete(&models.Move{}).Error)
+
+	review, err := suite.service.ReviewGame(suite.ctx, gameID)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), models.ReviewFailed, review.Status)
+	assert.Contains(suite.T(), review.Error, "6 moves but 7 were played")
+	assert.Empty(suite.T(), review.Moves)
+}
+
+func (suite *ReviewServiceTestSuite) TestGetReviewQueuesMissingReviews() {
+	gameID := suite.playGame([]int{3, 2, 3, 2}, true)
+
+	pending, err := suite.service.GetReview(suite.ctx, gameID)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), models.ReviewPending, pending.Status)
+	assert.False(suite.T(), pending.IsDone())
+
+	// Asking again does not queue the game twice
+	_, err = suite.service.GetReview(suite.ctx, gameID)
+	suite.Require().NoError(err)
+	assert.Len(suite.T(), suite.service.(*reviewService).queue, 1)
+
+	suite.service.Start(suite.ctx)
+	defer suite.service.Stop()
+	suite.Eventually(func() bool {
+		review, err := suite.service.GetReview(suite.ctx, gameID)
+		return err == nil && review.Status == models.ReviewCompleted
+	}, 10*time.Second, 20*time.Millisecond)
+}
+
+func (suite *ReviewServiceTestSuite) TestGetReviewErrors() {
+	_, err := suite.service.GetReview(suite.ctx, "missing")
+	assert.ErrorIs(suite.T(), err, models.ErrGameNotFound)
+
+	active := suite.playGame([]int{3}, false)
+	_, err = suite.service.GetReview(suite.ctx, active)
+	assert.ErrorIs(suite.T(), err, ErrGameNotCompleted)
+}
+
+func (suite *ReviewServiceTestSuite) TestOnGameEventQueuesCompletedGames() {
+	gameID := suite.playGame([]int{3, 3}, true)
+
+	suite.service.OnGameEvent(suite.ctx, models.NewGameStartedEvent(gameID, "alice", "bobby"))
+	review, err := suite.reviewRepo.GetByGameID(suite.ctx, gameID)
+	suite.Require().NoError(err)
+	assert.Nil(suite.T(), review)
+
+	suite.service.OnGameEvent(suite.ctx, models.NewGameCompletedEvent(gameID, "alice", "bobby", 30))
+	review, err = suite.reviewRepo.GetByGameID(suite.ctx, gameID)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(review)
+	assert.Equal(suite.T(), models.ReviewPending, review.Status)
+}
+
+func (suite *ReviewServiceTestSuite) TestStartResumesPendingReviews() {
+	gameID := suite.playGame([]int{3, 3, 4}, true)
+	created, err := suite.reviewRepo.CreatePending(suite.ctx, gameID)
+	suite.Require().NoError(err)
+	suite.Require().True(created)
+
+	suite.service.Start(suite.ctx)
+	defer suite.service.Stop()
+	suite.Eventually(func() bool {
+		review, err := suite.reviewRepo.GetByGameID(suite.ctx, gameID)
+		return err == nil && review.Status == models.ReviewCompleted
+	}, 10*time.Second, 20*time.Millisecond)
+}
+
+// offlineMoveRepository fails to load moves while offline is set
+type offlineMoveRepository struct {
+	repositories.MoveRepository
+	offline atomic.Bool
+}
+
+func (r *offlineMoveRepository) GetByGameID(ctx context.Context, gameID string) ([]*models.Move, error) {
+	if r.offline.Load() {
+		return nil, errors.New("database unavailable")
+	}
+	return r.MoveRepository.GetByGameID(ctx, gameID)
+}
+
+func (suite *ReviewServiceTestSuite) TestGetReviewRequeuesFailedAttempts() {
+	moveRepo := &offlineMoveRepository{MoveRepository: suite.moveRepo}
+	moveRepo.offline.Store(true)
+	config := DefaultServiceConfig()
+	config.PositionTimeout = 50 * time.Millisecond
+	config.MaxDepth = 6
+	service := NewReviewService(suite.reviewRepo, suite.gameRepo, moveRepo, config).(*reviewService)
+
+	gameID := suite.playGame([]int{3, 3, 4}, true)
+	service.Start(suite.ctx)
+	defer service.Stop()
+	suite.Require().NoError(service.Enqueue(suite.ctx, gameID))
+
+	// The worker gives up and the review stays pending
+	suite.Eventually(func() bool {
+		service.scheduledMu.Lock()
+		defer service.scheduledMu.Unlock()
+		return len(service.scheduled) == 0
+	}, 10*time.Second, 20*time.Millisecond)
+	review, err := suite.reviewRepo.GetByGameID(suite.ctx, gameID)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), models.ReviewPending, review.Status)
+
+	moveRepo.offline.Store(false)
+	suite.Eventually(func() bool {
+		review, err := service.GetReview(suite.ctx, gameID)
+		return err == nil && review.Status == models.ReviewCompleted
+	}, 10*time.Second, 20*time.Millisecond)
+}
+
+func (suite *ReviewServiceTestSuite) TestGetReviewQueuesReviewsDroppedByFullQueue() {
+	config := DefaultServiceConfig()
+	config.QueueSize = 1
+	config.PositionTimeout = 50 * time.Millisecond
+	config.MaxDepth = 6
+	suite.service = NewReviewService(suite.reviewRepo, suite.gameRepo, suite.moveRepo, config)
+
+	first := suite.playGame([]int{3, 3}, true)
+	second := suite.playGame([]int{2, 4}, true)
+	suite.Require().NoError(suite.service.Enqueue(suite.ctx, first))
+	suite.Require().NoError(suite.service.Enqueue(suite.ctx, second))
+	assert.Len(suite.T(), suite.service.(*reviewService).queue, 1)
+
+	suite.service.Start(suite.ctx)
+	defer suite.service.Stop()
+	suite.Eventually(func() bool {
+		review, err := suite.service.GetReview(suite.ctx, second)
+		return err == nil && review.Status == models.ReviewCompleted
+	}, 10*time.Second, 20*time.Millisecond)
+}
+
+func TestReviewServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ReviewServiceTestSuite))
+}
+
+func TestClassify(t *testing.T) {
+	assert.Equal(t, models.MoveBest, classify(0.5, true), "the engine's move is best whatever the estimate")
+	assert.Equal(t, models.MoveBest, classify(0, false))
+	assert.Equal(t, models.MoveGood, classify(0.05, false))
+	assert.Equal(t, models.MoveInaccuracy, classify(0.15, false))
+	assert.Equal(t, models.MoveMistake, classify(0.25, false))
+	assert.Equal(t, models.MoveBlunder, classify(0.5, false))
+}
+
+func TestAccuracies(t *testing.T) {
+	assert.InDelta(t, 100, moveAccuracy(0), 0.01)
+	assert.Zero(t, moveAccuracy(1))
+	assert.Less(t, moveAccuracy(0.2), moveAccuracy(0.1))
+
+	red, yellow := accuracies(models.MoveAnnotations{
+		{Player: models.PlayerColorRed, Loss: 0},
+		{Player: models.PlayerColorYellow, Loss: 0.5},
+		{Player: models.PlayerColorRed, Loss: 0},
+	})
+	assert.InDelta(t, 100, red, 0.01)
+	assert.Less(t, yellow, 20.0)
+}
@@ -0,0 +1,112 @@
+package social
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"connect4-multiplayer/internal/database/repositories"
+	"connect4-multiplayer/pkg/models"
+)
+
+// BlockChecker reports whether either of two players has blocked the other
+type BlockChecker interface {
+	IsBlocked(ctx context.Context, playerA, playerB string) (bool, error)
+}
+
+// BlockService defines the interface for managing player block lists
+type BlockService interface {
+	BlockChecker
+
+	Block(ctx context.Context, blocker, blocked string) (*models.PlayerBlock, error)
+	Unblock(ctx context.Context, blocker, blocked string) error
+	ListBlocked(ctx context.Context, blocker string) ([]*models.PlayerBlock, error)
+	// BlocksAmong returns every block between two of the given players
+	BlocksAmong(ctx context.Context, usernames []string) ([]*models.PlayerBlock, error)
+}
+
+// blockService implements BlockService interface
+type blockService struct {
+	blockRepo      repositories.BlockRepository
+	friendshipRepo repositories.FriendshipRepository
+	logger         *slog.Logger
+}
+
+// NewBlockService creates a new BlockService instance
+func NewBlockService(
+	blockRepo repositories.BlockRepository,
+	friendshipRepo repositories.FriendshipRepository,
+	logger *slog.Logger,
+) BlockService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &blockService{
+		blockRepo:      blockRepo,
+		friendshipRepo: friendshipRepo,
+		logger:         logger.With("component", "blocks"),
+	}
+}
+
+// Block adds a player to the blocker's list and ends any friendship or
+// pending friend request between them
+func (s *blockService) Block(ctx context.Context, blocker, blocked string) (*models.PlayerBlock, error) {
+	if blocker == "" || blocked == "" {
+		return nil, fmt.Errorf("usernames cannot be empty")
+	}
+	if blocker == blocked {
+		return nil, ErrSelfRelation
+	}
+
+	block := &models.PlayerBlock{
+		Blocker: blocker,
+		Blocked: blocked,
+	}
+	if err := s.blockRepo.Create(ctx, block); err != nil {
+		return nil, err
+	}
+
+	friendship, err := s.friendshipRepo.GetBetween(ctx, blocker, blocked)
+	if err != nil {
+		return nil, err
+	}
+	if friendship != nil {
+		if err := s.friendshipRepo.Delete(ctx, friendship.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	s.logger.Info("player blocked", "blocker", blocker, "blocked", blocked)
+
+	return block, nil
+}
+
+// Unblock removes a player from the blocker's list
+func (s *blockService) Unblock(ctx context.Context, blocker, blocked string) error {
+	if err := s.blockRepo.Delete(ctx, blocker, blocked); err != nil {
+		return err
+	}
+
+	s.logger.Info("player unblocked", "blocker", blocker, "blocked", blocked)
+
+	return nil
+}
+
+// ListBlocked returns the players blocked by a player
+func (s *blockService) ListBlocked(ctx context.Context, blocker string) ([]*models.PlayerBlock, error) {
+	return s.blockRepo.ListByBlocker(ctx, blocker)
+}
+
+// IsBlocked reports whether either player has blocked the other
+func (s *blockService) IsBlocked(ctx context.Context, playerA, playerB string) (bool, error) {
+	if playerA == "" || playerB == "" || playerA == playerB {
+		return false, nil
+	}
+	return s.blockRepo.ExistsBetween(ctx, playerA, playerB)
+}
+
+// BlocksAmong returns every block between two of the given players
+func (s *blockService) BlocksAmong(ctx context.Context, usernames []string) ([]*models.PlayerBlock, error) {
+	return s.blockRepo.ListAmong(ctx, usernames)
+}
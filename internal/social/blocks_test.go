@@ -0,0 +1,144 @@
+package social
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"connect4-multiplayer/internal/database/repositories"
+	"connect4-multiplayer/pkg/models"
+)
+
+type BlockServiceTestSuite struct {
+	suite.Suite
+	blocks  BlockService
+	friends FriendService
+	ctx     context.Context
+}
+
+func (suite *BlockServiceTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	suite.Require().NoError(err)
+	suite.Require().NoError(db.AutoMigrate(&models.Friendship{}, &models.PlayerBlock{}))
+
+	friendshipRepo := repositories.NewFriendshipRepository(db)
+	suite.blocks = NewBlockService(repositories.NewBlockRepository(db), friendshipRepo, nil)
+	suite.friends = NewFriendService(friendshipRepo, nil)
+	suite.friends.SetBlockChecker(suite.blocks)
+	suite.ctx = context.Background()
+}
+
+func (suite *BlockServiceTestSuite) TestBlockIsSymmetric() {
+	_, err := suite.blocks.Block(suite.ctx, "alice", "bobby")
+	suite.Require().NoError(err)
+
+	blocked, err := suite.blocks.IsBlocked(suite.ctx, "bobby", "alice")
+	suite.Require().NoError(err)
+	assert.True(suite.T(), blocked)
+
+	// Blocking twice is a no-op
+	_, err = suite.blocks.Block(suite.ctx, "alice", "bobby")
+	suite.Require().NoError(err)
+
+	list, err := suite.blocks.ListBlocked(suite.ctx, "alice")
+	suite.Require().NoError(err)
+	assert.Len(suite.T(), list, 1)
+
+	suite.Require().NoError(suite.blocks.Unblock(suite.ctx, "alice", "bobby"))
+
+	blocked, err = suite.blocks.IsBlocked(suite.ctx, "alice", "bobby")
+	suite.Require().NoError(err)
+	assert.False(suite.T(), blocked)
+}
+
+func (suite *BlockServiceTestSuite) TestBlocksAmong() {
+	_, err := suite.blocks.Block(suite.ctx, "alice", "bobby")
+	suite.Require().NoError(err)
+	_, err = suite.blocks.Block(suite.ctx, "carol", "alice")
+	suite.Require().NoError(err)
+	_, err = suite.blocks.Block(suite.ctx, "alice", "dave")
+	suite.Require().NoError(err)
+
+	blocks, err := suite.blocks.BlocksAmong(suite.ctx, []string{"alice", "bobby", "carol"})
+	suite.Require().NoError(err)
+	pairs := make([][2]string, len(blocks))
+	for i, block := range blocks {
+		pairs[i] = [2]string{block.Blocker, block.Blocked}
+	}
+	assert.ElementsMatch(suite.T(), [][2]string{{"alice", "bobby"}, {"carol", "alice"}}, pairs,
+		"blocks of players outside the list are left out")
+
+	blocks, err = suite.blocks.BlocksAmong(suite.ctx, []string{"bobby"})
+	suite.Require().NoError(err)
+	assert.Empty(suite.T(), blocks)
+}
+
+func (suite *BlockServiceTestSuite) TestBlockSelf() {
+	_, err := suite.blocks.Block(suite.ctx, "alice", "alice")
+	assert.ErrorIs(suite.T(), err, ErrSelfRelation)
+}
+
+func (suite *BlockServiceTestSuite) TestBlockEndsFriendship() {
+	request, err := suite.friends.SendRequest(suite.ctx, "alice", "bobby")
+	suite.Require().NoError(err)
+	_, err = suite.friends.AcceptRequest(suite.ctx, "bobby", request.ID)
+	suite.Require().NoError(err)
+
+	_, err = suite.blocks.Block(suite.ctx, "bobby", "alice")
+	suite.Require().NoError(err)
+
+	friends, err := suite.friends.AreFriends(suite.ctx, "alice", "bobby")
+	suite.Require().NoError(err)
+	assert.False(suite.T(), friends)
+
+	// Neither side can send a new request while the block stands
+	_, err = suite.friends.SendRequest(suite.ctx, "alice", "bobby")
+	assert.ErrorIs(suite.T(), err, ErrPlayerBlocked)
+	_, err = suite.friends.SendRequest(suite.ctx, "bobby", "alice")
+	assert.ErrorIs(suite.T(), err, ErrPlayerBlocked)
+}
+
+func (suite *BlockServiceTestSuite) TestBlockPreventsChallenges() {
+	_, err := suite.blocks.Block(suite.ctx, "alice", "bobby")
+	suite.Require().NoError(err)
+
+	challenges := NewChallengeService(&fakeGameStarter{}, &ChallengeConfig{
+		ChallengeTTL: time.Minute,
+		BlockChecker: suite.blocks,
+	})
+	defer challenges.Stop()
+
+	_, err = challenges.CreateChallenge(suite.ctx, "bobby", "alice")
+	assert.ErrorIs(suite.T(), err, ErrPlayerBlocked)
+}
+
+func (suite *BlockServiceTestSuite) TestBlockAfterChallengePreventsGame() {
+	starter := &fakeGameStarter{}
+	challenges := NewChallengeService(starter, &ChallengeConfig{
+		ChallengeTTL: time.Minute,
+		BlockChecker: suite.blocks,
+	})
+	defer challenges.Stop()
+
+	challenge, err := challenges.CreateChallenge(suite.ctx, "bobby", "alice")
+	suite.Require().NoError(err)
+	_, err = suite.blocks.Block(suite.ctx, "bobby", "alice")
+	suite.Require().NoError(err)
+
+	_, session, err := challenges.AcceptChallenge(suite.ctx, challenge.ID, "alice")
+	assert.ErrorIs(suite.T(), err, ErrPlayerBlocked)
+	assert.Nil(suite.T(), session)
+	assert.Empty(suite.T(), starter.created)
+}
+
+func TestBlockServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(BlockServiceTestSuite))
+}
@@ -0,0 +1,339 @@
+package social
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+// ChallengeStatus represents the lifecycle state of a challenge
+type ChallengeStatus string
+
+const (
+	ChallengePending  ChallengeStatus = "pending"
+	ChallengeAccepted ChallengeStatus = "accepted"
+	ChallengeDeclined ChallengeStatus = "declined"
+	ChallengeExpired  ChallengeStatus = "expired"
+)
+
+// Challenge is a direct game invitation from one player to another
+type Challenge struct {
+	ID         string          `json:"id"`
+	Challenger string          `json:"challenger"`
+	Target     string          `json:"target"`
+	Status     ChallengeStatus `json:"status"`
+	GameID     string          `json:"gameId,omitempty"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	ExpiresAt  time.Time       `json:"expiresAt"`
+}
+
+// GameStarter is the subset of game.GameService needed to start challenge games
+type GameStarter interface {
+	CreateSession(ctx context.Context, player1, player2 string) (*models.GameSession, error)
+	GetActiveSessionByPlayer(ctx context.Context, username string) (*models.GameSession, error)
+}
+
+// ChallengeExpiredCallback is called when a pending challenge times out
+type ChallengeExpiredCallback func(challenge *Challenge)
+
+// ChallengeService defines the interface for direct challenges between players
+type ChallengeService interface {
+	CreateChallenge(ctx context.Context, challenger, target string) (*Challenge, error)
+	AcceptChallenge(ctx context.Context, challengeID, username string) (*Challenge, *models.GameSession, error)
+	DeclineChallenge(ctx context.Context, challengeID, username string) (*Challenge, error)
+	GetPendingChallenges(username string) []*Challenge
+	// CheckBlocked returns ErrPlayerBlocked if either player has blocked the other
+	CheckBlocked(ctx context.Context, playerA, playerB string) error
+
+	SetExpiredCallback(callback ChallengeExpiredCallback)
+	Stop()
+}
+
+// challengeService implements ChallengeService interface
+type challengeService struct {
+	gameService GameStarter
+	blocks      BlockChecker
+	notifier    Notifier
+	ttl         time.Duration
+	logger      *slog.Logger
+
+	challenges map[string]*Challenge
+	timers     map[string]*time.Timer
+	mutex      sync.Mutex
+
+	expiredCallback ChallengeExpiredCallback
+}
+
+// ChallengeConfig holds configuration for the challenge service
+type ChallengeConfig struct {
+	ChallengeTTL time.Duration
+	Logger       *slog.Logger
+	BlockChecker BlockChecker // Optional: reject challenges between blocked players
+	Notifier     Notifier     // Optional: leave an inbox notification for the target
+}
+
+// DefaultChallengeConfig returns default challenge configuration
+func DefaultChallengeConfig() *ChallengeConfig {
+	return &ChallengeConfig{
+		ChallengeTTL: 60 * time.Second,
+		Logger:       slog.Default(),
+	}
+}
+
+// NewChallengeService creates a new ChallengeService instance
+func NewChallengeService(gameService GameStarter, config *ChallengeConfig) ChallengeService {
+	if config == nil {
+		config = DefaultChallengeConfig()
+	}
+
+	logger := config.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &challengeService{
+		gameService: gameService,
+		blocks:      config.BlockChecker,
+		notifier:    config.Notifier,
+		ttl:         config.ChallengeTTL,
+		logger:      logger.With("component", "challenges"),
+		challenges:  make(map[string]*Challenge),
+		timers:      make(map[string]*time.Timer),
+	}
+}
+
+// SetExpiredCallback sets the callback for expired challenges
+func (s *challengeService) SetExpiredCallback(callback ChallengeExpiredCallback) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.expiredCallback = callback
+}
+
+// CreateChallenge invites target to a game. Only one pending challenge may
+// exist between the same two players at a time.
+func (s *challengeService) CreateChallenge(ctx context.Context, challenger, target string) (*Challenge, error) {
+	if challenger == "" || target == "" {
+		return nil, fmt.Errorf("usernames cannot be empty")
+	}
+	if challenger == target {
+		return nil, ErrSelfRelation
+	}
+
+	if err := s.CheckBlocked(ctx, challenger, target); err != nil {
+		return nil, err
+	}
+
+	if s.isBusy(ctx, challenger) || s.isBusy(ctx, target) {
+		return nil, ErrPlayerBusy
+	}
+
+	id, err := generateChallengeID()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+
+	for _, existing := range s.challenges {
+		if existing.Status != ChallengePending {
+			continue
+		}
+		if (existing.Challenger == challenger && existing.Target == target) ||
+			(existing.Challenger == target && existing.Target == challenger) {
+			s.mutex.Unlock()
+			return nil, ErrChallengePending
+		}
+	}
+
+	now := time.Now()
+	challenge := &Challenge{
+		ID:         id,
+		Challenger: challenger,
+		Target:     target,
+		Status:     ChallengePending,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(s.ttl),
+	}
+	s.challenges[id] = challenge
+	s.timers[id] = time.AfterFunc(s.ttl, func() { s.expire(id) })
+	copied := *challenge
+	s.mutex.Unlock()
+
+	s.logger.Info("challenge created",
+		"challengeID", id,
+		"challenger", challenger,
+		"target", target,
+	)
+
+	if s.notifier != nil {
+		_, err := s.notifier.Notify(ctx, target, models.NotificationChallenge,
+			"New challenge",
+			fmt.Sprintf("%s challenged you to a game", challenger),
+			models.NotificationData{
+				"challengeId": id,
+				"from":        challenger,
+				"expiresAt":   copied.ExpiresAt,
+			})
+		if err != nil {
+			s.logger.Warn("failed to send challenge notification", "challengeID", id, "error", err)
+		}
+	}
+
+	return &copied, nil
+}
+
+// AcceptChallenge accepts a pending challenge addressed to username and
+// starts a game with the challenger as player 1. A block added since the
+// challenge was sent stops the game.
+func (s *challengeService) AcceptChallenge(ctx context.Context, challengeID, username string) (*Challenge, *models.GameSession, error) {
+	challenge, err := s.resolve(challengeID, username, ChallengeAccepted)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.CheckBlocked(ctx, challenge.Challenger, challenge.Target); err != nil {
+		return challenge, nil, err
+	}
+
+	if s.isBusy(ctx, challenge.Challenger) || s.isBusy(ctx, challenge.Target) {
+		return challenge, nil, ErrPlayerBusy
+	}
+
+	session, err := s.gameService.CreateSession(ctx, challenge.Challenger, challenge.Target)
+	if err != nil {
+		return challenge, nil, fmt.Errorf("failed to create challenge game: %w", err)
+	}
+
+	challenge.GameID = session.ID
+
+	s.logger.Info("challenge accepted",
+		"challengeID", challengeID,
+		"gameID", session.ID,
+	)
+
+	return challenge, session, nil
+}
+
+// DeclineChallenge declines a pending challenge addressed to username
+func (s *challengeService) DeclineChallenge(ctx context.Context, challengeID, username string) (*Challenge, error) {
+	challenge, err := s.resolve(challengeID, username, ChallengeDeclined)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("challenge declined", "challengeID", challengeID)
+
+	return challenge, nil
+}
+
+// GetPendingChallenges returns pending challenges sent to or by a player
+func (s *challengeService) GetPendingChallenges(username string) []*Challenge {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var pending []*Challenge
+	for _, challenge := range s.challenges {
+		if challenge.Status == ChallengePending && (challenge.Challenger == username || challenge.Target == username) {
+			copied := *challenge
+			pending = append(pending, &copied)
+		}
+	}
+	return pending
+}
+
+// Stop cancels all expiry timers
+func (s *challengeService) Stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for id, timer := range s.timers {
+		timer.Stop()
+		delete(s.timers, id)
+	}
+}
+
+// resolve moves a pending challenge addressed to username into a final state
+func (s *challengeService) resolve(challengeID, username string, status ChallengeStatus) (*Challenge, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	challenge, exists := s.challenges[challengeID]
+	if !exists || challenge.Target != username {
+		return nil, ErrChallengeNotFound
+	}
+	if challenge.Status != ChallengePending {
+		return nil, ErrChallengeNotActive
+	}
+
+	challenge.Status = status
+	s.release(challengeID)
+
+	copied := *challenge
+	return &copied, nil
+}
+
+// expire marks a challenge as expired and notifies the callback
+func (s *challengeService) expire(challengeID string) {
+	s.mutex.Lock()
+	challenge, exists := s.challenges[challengeID]
+	if !exists || challenge.Status != ChallengePending {
+		s.mutex.Unlock()
+		return
+	}
+	challenge.Status = ChallengeExpired
+	s.release(challengeID)
+	callback := s.expiredCallback
+	copied := *challenge
+	s.mutex.Unlock()
+
+	s.logger.Info("challenge expired", "challengeID", challengeID)
+
+	if callback != nil {
+		callback(&copied)
+	}
+}
+
+// release drops a resolved challenge and its timer. Must be called with the mutex held.
+func (s *challengeService) release(challengeID string) {
+	if timer, exists := s.timers[challengeID]; exists {
+		timer.Stop()
+		delete(s.timers, challengeID)
+	}
+	delete(s.challenges, challengeID)
+}
+
+// CheckBlocked returns ErrPlayerBlocked if either player has blocked the other
+func (s *challengeService) CheckBlocked(ctx context.Context, playerA, playerB string) error {
+	if s.blocks == nil {
+		return nil
+	}
+	blocked, err := s.blocks.IsBlocked(ctx, playerA, playerB)
+	if err != nil {
+		return fmt.Errorf("failed to check block list: %w", err)
+	}
+	if blocked {
+		return ErrPlayerBlocked
+	}
+	return nil
+}
+
+// isBusy reports whether a player is already in an active game
+func (s *challengeService) isBusy(ctx context.Context, username string) bool {
+	session, err := s.gameService.GetActiveSessionByPlayer(ctx, username)
+	return err == nil && session != nil
+}
+
+// generateChallengeID generates a random challenge identifier
+func generateChallengeID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate challenge ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,132 @@
+package social
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+// fakeGameStarter records created sessions and reports configured players as busy
+type fakeGameStarter struct {
+	mu      sync.Mutex
+	busy    map[string]bool
+	created []*models.GameSession
+}
+
+func (f *fakeGameStarter) CreateSession(ctx context.Context, player1, player2 string) (*models.GameSession, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	session := &models.GameSession{ID: "game-" + player1 + "-" + player2, Player1: player1, Player2: player2, Status: models.StatusInProgress}
+	f.created = append(f.created, session)
+	return session, nil
+}
+
+func (f *fakeGameStarter) GetActiveSessionByPlayer(ctx context.Context, username string) (*models.GameSession, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.busy[username] {
+		return &models.GameSession{ID: "active", Status: models.StatusInProgress}, nil
+	}
+	return nil, errors.New("no active session")
+}
+
+func newTestChallengeService(starter *fakeGameStarter, ttl time.Duration) ChallengeService {
+	return NewChallengeService(starter, &ChallengeConfig{ChallengeTTL: ttl})
+}
+
+func TestChallenge_Accept(t *testing.T) {
+	starter := &fakeGameStarter{}
+	service := newTestChallengeService(starter, time.Minute)
+	defer service.Stop()
+	ctx := context.Background()
+
+	challenge, err := service.CreateChallenge(ctx, "alice", "bobby")
+	require.NoError(t, err)
+	assert.Equal(t, ChallengePending, challenge.Status)
+	assert.Len(t, service.GetPendingChallenges("bobby"), 1)
+
+	// Only the target may answer
+	_, _, err = service.AcceptChallenge(ctx, challenge.ID, "alice")
+	assert.ErrorIs(t, err, ErrChallengeNotFound)
+
+	accepted, session, err := service.AcceptChallenge(ctx, challenge.ID, "bobby")
+	require.NoError(t, err)
+	assert.Equal(t, ChallengeAccepted, accepted.Status)
+	assert.Equal(t, "alice", session.Player1)
+	assert.Equal(t, "bobby", session.Player2)
+	assert.Equal(t, session.ID, accepted.GameID)
+	assert.Empty(t, service.GetPendingChallenges("bobby"))
+
+	_, _, err = service.AcceptChallenge(ctx, challenge.ID, "bobby")
+	assert.Error(t, err)
+}
+
+func TestChallenge_Decline(t *testing.T) {
+	starter := &fakeGameStarter{}
+	service := newTestChallengeService(starter, time.Minute)
+	defer service.Stop()
+	ctx := context.Background()
+
+	challenge, err := service.CreateChallenge(ctx, "alice", "bobby")
+	require.NoError(t, err)
+
+	declined, err := service.DeclineChallenge(ctx, challenge.ID, "bobby")
+	require.NoError(t, err)
+	assert.Equal(t, ChallengeDeclined, declined.Status)
+	assert.Empty(t, starter.created)
+
+	// A new challenge can be sent once the previous one is resolved
+	_, err = service.CreateChallenge(ctx, "alice", "bobby")
+	assert.NoError(t, err)
+}
+
+func TestChallenge_Validation(t *testing.T) {
+	starter := &fakeGameStarter{busy: map[string]bool{"carol": true}}
+	service := newTestChallengeService(starter, time.Minute)
+	defer service.Stop()
+	ctx := context.Background()
+
+	_, err := service.CreateChallenge(ctx, "alice", "alice")
+	assert.ErrorIs(t, err, ErrSelfRelation)
+
+	_, err = service.CreateChallenge(ctx, "alice", "carol")
+	assert.ErrorIs(t, err, ErrPlayerBusy)
+
+	_, err = service.CreateChallenge(ctx, "alice", "bobby")
+	require.NoError(t, err)
+	_, err = service.CreateChallenge(ctx, "bobby", "alice")
+	assert.ErrorIs(t, err, ErrChallengePending)
+}
+
+func TestChallenge_Expiry(t *testing.T) {
+	starter := &fakeGameStarter{}
+	service := newTestChallengeService(starter, 50*time.Millisecond)
+	defer service.Stop()
+	ctx := context.Background()
+
+	expired := make(chan *Challenge, 1)
+	service.SetExpiredCallback(func(challenge *Challenge) {
+		expired <- challenge
+	})
+
+	challenge, err := service.CreateChallenge(ctx, "alice", "bobby")
+	require.NoError(t, err)
+
+	select {
+	case got := <-expired:
+		assert.Equal(t, challenge.ID, got.ID)
+		assert.Equal(t, ChallengeExpired, got.Status)
+	case <-time.After(time.Second):
+		t.Fatal("challenge did not expire")
+	}
+
+	_, _, err = service.AcceptChallenge(ctx, challenge.ID, "bobby")
+	assert.ErrorIs(t, err, ErrChallengeNotFound)
+}
@@ -0,0 +1,17 @@
+package social
+
+import "errors"
+
+// Social graph errors
+var (
+	ErrSelfRelation       = errors.New("cannot target yourself")
+	ErrAlreadyFriends     = errors.New("players are already friends")
+	ErrRequestPending     = errors.New("friend request already pending")
+	ErrRequestNotFound    = errors.New("friend request not found")
+	ErrNotFriends         = errors.New("players are not friends")
+	ErrChallengeNotFound  = errors.New("challenge not found")
+	ErrChallengePending   = errors.New("challenge already pending")
+	ErrChallengeNotActive = errors.New("challenge is no longer pending")
+	ErrPlayerBusy         = errors.New("player is already in an active game")
+	ErrPlayerBlocked      = errors.New("player is not available")
+)
@@ -0,0 +1,259 @@
+package social
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"connect4-multiplayer/internal/database/repositories"
+	"connect4-multiplayer/pkg/models"
+)
+
+// PresenceChecker reports whether a player currently has a live connection
+type PresenceChecker interface {
+	IsUserConnected(userID string) bool
+}
+
+// Notifier stores and delivers player notifications
+type Notifier interface {
+	Notify(ctx context.Context, username string, notificationType models.NotificationType, title, body string, data models.NotificationData) (*models.Notification, error)
+}
+
+// Friend is an accepted friendship seen from one player's side
+type Friend struct {
+	Username     string     `json:"username"`
+	Online       bool       `json:"online"`
+	FriendshipID string     `json:"friendshipId"`
+	Since        *time.Time `json:"since,omitempty"`
+}
+
+// FriendService defines the interface for managing friendships
+type FriendService interface {
+	SendRequest(ctx context.Context, from, to string) (*models.Friendship, error)
+	AcceptRequest(ctx context.Context, username, requestID string) (*models.Friendship, error)
+	// RemoveFriend removes a friend, or declines/cancels a pending request
+	RemoveFriend(ctx context.Context, username, other string) error
+
+	ListFriends(ctx context.Context, username string) ([]*Friend, error)
+	ListPendingRequests(ctx context.Context, username string) ([]*models.Friendship, error)
+	AreFriends(ctx context.Context, playerA, playerB string) (bool, error)
+
+	SetPresenceChecker(presence PresenceChecker)
+	SetBlockChecker(blocks BlockChecker)
+	SetNotifier(notifier Notifier)
+}
+
+// friendService implements FriendService interface
+type friendService struct {
+	friendshipRepo repositories.FriendshipRepository
+	logger         *slog.Logger
+
+	presence  PresenceChecker
+	blocks    BlockChecker
+	notifier  Notifier
+	depsMutex sync.RWMutex
+}
+
+// NewFriendService creates a new FriendService instance
+func NewFriendService(friendshipRepo repositories.FriendshipRepository, logger *slog.Logger) FriendService {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &friendService{
+		friendshipRepo: friendshipRepo,
+		logger:         logger.With("component", "friends"),
+	}
+}
+
+// SetPresenceChecker sets the source of online presence for friend lists
+func (s *friendService) SetPresenceChecker(presence PresenceChecker) {
+	s.depsMutex.Lock()
+	defer s.depsMutex.Unlock()
+	s.presence = presence
+}
+
+// SetBlockChecker makes friend requests respect player block lists
+func (s *friendService) SetBlockChecker(blocks BlockChecker) {
+	s.depsMutex.Lock()
+	defer s.depsMutex.Unlock()
+	s.blocks = blocks
+}
+
+// SetNotifier enables inbox notifications for friend requests
+func (s *friendService) SetNotifier(notifier Notifier) {
+	s.depsMutex.Lock()
+	defer s.depsMutex.Unlock()
+	s.notifier = notifier
+}
+
+// SendRequest sends a friend request. If the other player already asked to be
+// friends, the pending request is accepted instead.
+func (s *friendService) SendRequest(ctx context.Context, from, to string) (*models.Friendship, error) {
+	if from == "" || to == "" {
+		return nil, fmt.Errorf("usernames cannot be empty")
+	}
+	if from == to {
+		return nil, ErrSelfRelation
+	}
+
+	s.depsMutex.RLock()
+	blocks := s.blocks
+	s.depsMutex.RUnlock()
+	if blocks != nil {
+		blocked, err := blocks.IsBlocked(ctx, from, to)
+		if err != nil {
+			return nil, err
+		}
+		if blocked {
+			return nil, ErrPlayerBlocked
+		}
+	}
+
+	friendship, changed, err := s.friendshipRepo.Request(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case !changed && friendship.Status == models.FriendshipAccepted:
+		return nil, ErrAlreadyFriends
+	case !changed:
+		return nil, ErrRequestPending
+	case friendship.Status == models.FriendshipAccepted:
+		// Crossed requests: the other player asked first
+		s.announceAccepted(ctx, friendship)
+		return friendship, nil
+	}
+
+	s.logger.Info("friend request sent", "from", from, "to", to)
+
+	s.notify(ctx, to, "Friend request", fmt.Sprintf("%s wants to be your friend", from), models.NotificationData{
+		"friendshipId": friendship.ID,
+		"from":         from,
+	})
+
+	return friendship, nil
+}
+
+// AcceptRequest accepts a pending request addressed to username
+func (s *friendService) AcceptRequest(ctx context.Context, username, requestID string) (*models.Friendship, error) {
+	friendship, err := s.friendshipRepo.GetByID(ctx, requestID)
+	if err != nil {
+		return nil, ErrRequestNotFound
+	}
+
+	if friendship.Addressee != username || friendship.Status != models.FriendshipPending {
+		return nil, ErrRequestNotFound
+	}
+
+	return s.accept(ctx, friendship)
+}
+
+// accept marks a friendship as accepted
+func (s *friendService) accept(ctx context.Context, friendship *models.Friendship) (*models.Friendship, error) {
+	now := time.Now()
+	friendship.Status = models.FriendshipAccepted
+	friendship.AcceptedAt = &now
+
+	if err := s.friendshipRepo.Update(ctx, friendship); err != nil {
+		return nil, err
+	}
+
+	s.announceAccepted(ctx, friendship)
+	return friendship, nil
+}
+
+// announceAccepted logs an accepted friendship and tells the requester
+func (s *friendService) announceAccepted(ctx context.Context, friendship *models.Friendship) {
+	s.logger.Info("friend request accepted",
+		"requester", friendship.Requester,
+		"addressee", friendship.Addressee,
+	)
+
+	s.notify(ctx, friendship.Requester, "Friend request accepted", fmt.Sprintf("%s accepted your friend request", friendship.Addressee), models.NotificationData{
+		"friendshipId": friendship.ID,
+		"from":         friendship.Addressee,
+		"accepted":     true,
+	})
+}
+
+// notify sends a friend request notification; failures never undo the request
+func (s *friendService) notify(ctx context.Context, username, title, body string, data models.NotificationData) {
+	s.depsMutex.RLock()
+	notifier := s.notifier
+	s.depsMutex.RUnlock()
+	if notifier == nil {
+		return
+	}
+
+	if _, err := notifier.Notify(ctx, username, models.NotificationFriendRequest, title, body, data); err != nil {
+		s.logger.Warn("failed to send friend request notification", "username", username, "error", err)
+	}
+}
+
+// RemoveFriend removes a friend, or declines/cancels a pending request
+func (s *friendService) RemoveFriend(ctx context.Context, username, other string) error {
+	friendship, err := s.friendshipRepo.GetBetween(ctx, username, other)
+	if err != nil {
+		return err
+	}
+	if friendship == nil {
+		return ErrNotFriends
+	}
+
+	if err := s.friendshipRepo.Delete(ctx, friendship.ID); err != nil {
+		return err
+	}
+
+	s.logger.Info("friendship removed",
+		"player", username,
+		"other", other,
+		"status", friendship.Status,
+	)
+
+	return nil
+}
+
+// ListFriends returns a player's accepted friends with their online presence
+func (s *friendService) ListFriends(ctx context.Context, username string) ([]*Friend, error) {
+	friendships, err := s.friendshipRepo.ListFriends(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	s.depsMutex.RLock()
+	presence := s.presence
+	s.depsMutex.RUnlock()
+
+	friends := make([]*Friend, 0, len(friendships))
+	for _, friendship := range friendships {
+		friend := &Friend{
+			Username:     friendship.Other(username),
+			FriendshipID: friendship.ID,
+			Since:        friendship.AcceptedAt,
+		}
+		if presence != nil {
+			friend.Online = presence.IsUserConnected(friend.Username)
+		}
+		friends = append(friends, friend)
+	}
+
+	return friends, nil
+}
+
+// ListPendingRequests returns friend requests waiting for the player's answer
+func (s *friendService) ListPendingRequests(ctx context.Context, username string) ([]*models.Friendship, error) {
+	return s.friendshipRepo.ListPendingRequests(ctx, username)
+}
+
+// AreFriends reports whether two players have an accepted friendship
+func (s *friendService) AreFriends(ctx context.Context, playerA, playerB string) (bool, error) {
+	friendship, err := s.friendshipRepo.GetBetween(ctx, playerA, playerB)
+	if err != nil {
+		return false, err
+	}
+	return friendship != nil && friendship.Status == models.FriendshipAccepted, nil
+}
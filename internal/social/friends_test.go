@@ -0,0 +1,168 @@
+package social
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"connect4-multiplayer/internal/database/repositories"
+	"connect4-multiplayer/pkg/models"
+)
+
+// staticPresence reports a fixed set of players as online
+type staticPresence map[string]bool
+
+func (p staticPresence) IsUserConnected(userID string) bool {
+	return p[userID]
+}
+
+// recordingNotifier remembers who was notified of what
+type recordingNotifier struct {
+	notified []string
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, username string, notificationType models.NotificationType, title, body string, data models.NotificationData) (*models.Notification, error) {
+	n.notified = append(n.notified, username+":"+string(notificationType))
+	return &models.Notification{Username: username, Type: notificationType, Title: title, Body: body, Data: data}, nil
+}
+
+type FriendServiceTestSuite struct {
+	suite.Suite
+	service FriendService
+	repo    repositories.FriendshipRepository
+	ctx     context.Context
+}
+
+func (suite *FriendServiceTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	suite.Require().NoError(err)
+	suite.Require().NoError(db.AutoMigrate(&models.Friendship{}))
+	// The pair index of migration 013, in SQLite's words
+	suite.Require().NoError(db.Exec("CREATE UNIQUE INDEX idx_friendships_pair ON friendships(min(requester, addressee), max(requester, addressee))").Error)
+
+	suite.repo = repositories.NewFriendshipRepository(db)
+	suite.service = NewFriendService(suite.repo, nil)
+	suite.ctx = context.Background()
+}
+
+func (suite *FriendServiceTestSuite) TestRequestAndAccept() {
+	request, err := suite.service.SendRequest(suite.ctx, "alice", "bobby")
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), models.FriendshipPending, request.Status)
+
+	pending, err := suite.service.ListPendingRequests(suite.ctx, "bobby")
+	suite.Require().NoError(err)
+	assert.Len(suite.T(), pending, 1)
+
+	// Only the addressee can accept
+	_, err = suite.service.AcceptRequest(suite.ctx, "alice", request.ID)
+	assert.ErrorIs(suite.T(), err, ErrRequestNotFound)
+
+	accepted, err := suite.service.AcceptRequest(suite.ctx, "bobby", request.ID)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), models.FriendshipAccepted, accepted.Status)
+	assert.NotNil(suite.T(), accepted.AcceptedAt)
+
+	friends, err := suite.service.AreFriends(suite.ctx, "bobby", "alice")
+	suite.Require().NoError(err)
+	assert.True(suite.T(), friends)
+}
+
+func (suite *FriendServiceTestSuite) TestRequestNotifications() {
+	notifier := &recordingNotifier{}
+	suite.service.SetNotifier(notifier)
+
+	request, err := suite.service.SendRequest(suite.ctx, "alice", "bobby")
+	suite.Require().NoError(err)
+	_, err = suite.service.AcceptRequest(suite.ctx, "bobby", request.ID)
+	suite.Require().NoError(err)
+
+	assert.Equal(suite.T(), []string{"bobby:friend_request", "alice:friend_request"}, notifier.notified)
+}
+
+func (suite *FriendServiceTestSuite) TestDuplicateAndCrossedRequests() {
+	_, err := suite.service.SendRequest(suite.ctx, "alice", "bobby")
+	suite.Require().NoError(err)
+
+	_, err = suite.service.SendRequest(suite.ctx, "alice", "bobby")
+	assert.ErrorIs(suite.T(), err, ErrRequestPending)
+
+	// Bobby asking back accepts the pending request
+	friendship, err := suite.service.SendRequest(suite.ctx, "bobby", "alice")
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), models.FriendshipAccepted, friendship.Status)
+
+	_, err = suite.service.SendRequest(suite.ctx, "alice", "bobby")
+	assert.ErrorIs(suite.T(), err, ErrAlreadyFriends)
+
+	_, err = suite.service.SendRequest(suite.ctx, "alice", "alice")
+	assert.ErrorIs(suite.T(), err, ErrSelfRelation)
+}
+
+func (suite *FriendServiceTestSuite) TestCrossedRequestsMakeOneFriendship() {
+	notifier := &recordingNotifier{}
+	suite.service.SetNotifier(notifier)
+
+	request, err := suite.service.SendRequest(suite.ctx, "alice", "bobby")
+	suite.Require().NoError(err)
+	friendship, err := suite.service.SendRequest(suite.ctx, "bobby", "alice")
+	suite.Require().NoError(err)
+
+	assert.Equal(suite.T(), request.ID, friendship.ID, "bobby accepted alice's request")
+	assert.Equal(suite.T(), []string{"bobby:friend_request", "alice:friend_request"}, notifier.notified)
+
+	friends, err := suite.service.ListFriends(suite.ctx, "alice")
+	suite.Require().NoError(err)
+	assert.Len(suite.T(), friends, 1)
+
+	// The pair is unique whichever player asked
+	err = suite.repo.Create(suite.ctx, &models.Friendship{Requester: "bobby", Addressee: "alice", Status: models.FriendshipPending})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *FriendServiceTestSuite) TestListFriendsWithPresence() {
+	suite.service.SetPresenceChecker(staticPresence{"bobby": true})
+
+	for _, other := range []string{"bobby", "carol"} {
+		request, err := suite.service.SendRequest(suite.ctx, "alice", other)
+		suite.Require().NoError(err)
+		_, err = suite.service.AcceptRequest(suite.ctx, other, request.ID)
+		suite.Require().NoError(err)
+	}
+
+	friends, err := suite.service.ListFriends(suite.ctx, "alice")
+	suite.Require().NoError(err)
+	suite.Require().Len(friends, 2)
+
+	online := map[string]bool{}
+	for _, friend := range friends {
+		online[friend.Username] = friend.Online
+	}
+	assert.Equal(suite.T(), map[string]bool{"bobby": true, "carol": false}, online)
+}
+
+func (suite *FriendServiceTestSuite) TestRemoveFriend() {
+	request, err := suite.service.SendRequest(suite.ctx, "alice", "bobby")
+	suite.Require().NoError(err)
+	_, err = suite.service.AcceptRequest(suite.ctx, "bobby", request.ID)
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.service.RemoveFriend(suite.ctx, "bobby", "alice"))
+
+	friends, err := suite.service.ListFriends(suite.ctx, "alice")
+	suite.Require().NoError(err)
+	assert.Empty(suite.T(), friends)
+
+	assert.ErrorIs(suite.T(), suite.service.RemoveFriend(suite.ctx, "bobby", "alice"), ErrNotFriends)
+}
+
+func TestFriendServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(FriendServiceTestSuite))
+}
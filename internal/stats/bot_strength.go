@@ -0,0 +1,101 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connect4-multiplayer/internal/bot"
+	"connect4-multiplayer/internal/database/repositories"
+	"connect4-multiplayer/pkg/models"
+)
+
+// DefaultAdaptiveGamesLimit is the number of recent adaptive bot games that
+// refine a player's strength estimate
+const DefaultAdaptiveGamesLimit = 20
+
+// ComputeBotStrength estimates how strong an adaptive bot should play against
+// player. It starts from the player's overall record and replays their recent
+// completed games against adaptive bots, oldest first, raising the strength
+// after each win and lowering it after each loss. Handicap games say little
+// about the player's strength and are skipped. games must be most recent
+// first, as the repository returns them; playerStats may be nil.
+func ComputeBotStrength(player string, playerStats *models.PlayerStats, games []*models.GameSession, limit int) float64 {
+	strength := bot.InitialStrength(0, 0)
+	if playerStats != nil {
+		strength = bot.InitialStrength(playerStats.GamesPlayed, playerStats.WinRate)
+	}
+
+	var adaptive []*models.GameSession
+	for _, game := range games {
+		if len(adaptive) == limit {
+			break
+		}
+		if game.Status != models.StatusCompleted || game.BotDifficulty != bot.DifficultyAdaptive.String() || game.Handicap != nil {
+			continue
+		}
+		if game.Player1 != player && game.Player2 != player {
+			continue
+		}
+		adaptive = append(adaptive, game)
+	}
+
+	for i := len(adaptive) - 1; i >= 0; i-- {
+		game := adaptive[i]
+		color := models.PlayerColorRed
+		if game.Player2 == player {
+			color = models.PlayerColorYellow
+		}
+
+		score := 0.5
+		if game.Winner != nil {
+			score = 0
+			if *game.Winner == color {
+				score = 1
+			}
+		}
+		strength = bot.UpdateStrength(strength, score)
+	}
+	return strength
+}
+
+// BotStrengthService estimates how strong adaptive bots should play
+type BotStrengthService interface {
+	EstimateStrength(ctx context.Context, username string) (float64, error)
+}
+
+// botStrengthService implements BotStrengthService interface
+type botStrengthService struct {
+	gameRepo   repositories.GameSessionRepository
+	statsRepo  repositories.PlayerStatsRepository
+	gamesLimit int
+}
+
+// NewBotStrengthService creates a new BotStrengthService instance
+func NewBotStrengthService(gameRepo repositories.GameSessionRepository, statsRepo repositories.PlayerStatsRepository) BotStrengthService {
+	return &botStrengthService{
+		gameRepo:   gameRepo,
+		statsRepo:  statsRepo,
+		gamesLimit: DefaultAdaptiveGamesLimit,
+	}
+}
+
+// EstimateStrength estimates the strength for username from their stats and
+// stored games, so the estimate carries over between games and restarts
+func (s *botStrengthService) EstimateStrength(ctx context.Context, username string) (float64, error) {
+	if username == "" {
+		return 0, fmt.Errorf("username cannot be empty")
+	}
+
+	playerStats, err := s.statsRepo.GetByUsername(ctx, username)
+	if err != nil && !errors.Is(err, models.ErrPlayerNotFound) {
+		return 0, fmt.Errorf("failed to get player stats: %w", err)
+	}
+
+	games, err := s.gameRepo.GetGamesByPlayer(ctx, username)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get player games: %w", err)
+	}
+
+	return ComputeBotStrength(username, playerStats, games, s.gamesLimit), nil
+}
@@ -0,0 +1,71 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"connect4-multiplayer/internal/bot"
+	"connect4-multiplayer/pkg/models"
+)
+
+func adaptiveGame(id, player1, player2 string, winner *models.PlayerColor) *models.GameSession {
+	game := completedGame(id, player1, player2, winner, 20, time.Minute)
+	game.BotDifficulty = bot.DifficultyAdaptive.String()
+	return game
+}
+
+func TestComputeBotStrength(t *testing.T) {
+	red, yellow := models.PlayerColorRed, models.PlayerColorYellow
+
+	t.Run("NewPlayersStartGentle", func(t *testing.T) {
+		assert.Equal(t, bot.NewPlayerAdaptiveStrength, ComputeBotStrength("alice", nil, nil, DefaultAdaptiveGamesLimit))
+	})
+
+	t.Run("StartsFromRecord", func(t *testing.T) {
+		stats := &models.PlayerStats{Username: "alice", GamesPlayed: 50, GamesWon: 40, WinRate: 0.8}
+		assert.Equal(t, bot.InitialStrength(50, 0.8), ComputeBotStrength("alice", stats, nil, DefaultAdaptiveGamesLimit))
+	})
+
+	t.Run("FollowsAdaptiveResults", func(t *testing.T) {
+		wins := []*models.GameSession{
+			adaptiveGame("g2", "Bot_Adaptive_2", "alice", &yellow),
+			adaptiveGame("g1", "alice", "bot_1", &red),
+		}
+		losses := []*models.GameSession{
+			adaptiveGame("g2", "alice", "bot_2", &yellow),
+			adaptiveGame("g1", "bot_1", "alice", &red),
+		}
+		start := bot.NewPlayerAdaptiveStrength
+		assert.InDelta(t, bot.UpdateStrength(bot.UpdateStrength(start, 1), 1), ComputeBotStrength("alice", nil, wins, DefaultAdaptiveGamesLimit), 1e-9)
+		assert.Less(t, ComputeBotStrength("alice", nil, losses, DefaultAdaptiveGamesLimit), start)
+
+		draw := []*models.GameSession{adaptiveGame("g1", "alice", "bot_1", nil)}
+		assert.Equal(t, start, ComputeBotStrength("alice", nil, draw, DefaultAdaptiveGamesLimit))
+	})
+
+	t.Run("IgnoresOtherGames", func(t *testing.T) {
+		inProgress := adaptiveGame("g4", "alice", "bot_4", nil)
+		inProgress.Status = models.StatusInProgress
+		handicap := adaptiveGame("g3", "alice", "bot_3", &red)
+		handicap.Handicap = &models.Handicap{Player: red, Discs: []int{3}}
+		games := []*models.GameSession{
+			inProgress,
+			handicap,
+			completedGame("g2", "alice", "bobby", &red, 20, time.Minute),
+			completedGame("g1", "alice", "bot_1", &red, 20, time.Minute),
+		}
+		assert.Equal(t, bot.NewPlayerAdaptiveStrength, ComputeBotStrength("alice", nil, games, DefaultAdaptiveGamesLimit))
+	})
+
+	t.Run("OnlyRecentGamesCount", func(t *testing.T) {
+		// The latest win counts; the older losses fall outside the limit
+		games := []*models.GameSession{
+			adaptiveGame("g3", "alice", "bot_3", &red),
+			adaptiveGame("g2", "alice", "bot_2", &yellow),
+			adaptiveGame("g1", "alice", "bot_1", &yellow),
+		}
+		assert.Greater(t, ComputeBotStrength("alice", nil, games, 1), bot.NewPlayerAdaptiveStrength)
+	})
+}
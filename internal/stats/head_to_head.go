@@ -0,0 +1,201 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"connect4-multiplayer/internal/database/repositories"
+	"connect4-multiplayer/pkg/models"
+)
+
+// DefaultRecentGamesLimit is the number of recent games included in a head-to-head record
+const DefaultRecentGamesLimit = 10
+
+// Head-to-head game results from the player's point of view
+const (
+	ResultWin  = "win"
+	ResultLoss = "loss"
+	ResultDraw = "draw"
+)
+
+// ColorRecord holds results for games played with one color
+type ColorRecord struct {
+	Games  int `json:"games"`
+	Wins   int `json:"wins"`
+	Losses int `json:"losses"`
+	Draws  int `json:"draws"`
+}
+
+// HeadToHeadGame summarises a single game between the two players
+type HeadToHeadGame struct {
+	GameID          string             `json:"gameId"`
+	Color           models.PlayerColor `json:"color"`
+	Result          string             `json:"result"`
+	Moves           int                `json:"moves"`
+	DurationSeconds int                `json:"durationSeconds"`
+	PlayedAt        time.Time          `json:"playedAt"`
+}
+
+// HeadToHead is the record of a player against a specific opponent
+type HeadToHead struct {
+	Player                 string           `json:"player"`
+	Opponent               string           `json:"opponent"`
+	TotalGames             int              `json:"totalGames"`
+	Wins                   int              `json:"wins"`
+	Losses                 int              `json:"losses"`
+	Draws                  int              `json:"draws"`
+	AsRed                  ColorRecord      `json:"asRed"`
+	AsYellow               ColorRecord      `json:"asYellow"`
+	AverageMoves           float64          `json:"averageMoves"`
+	AverageDurationSeconds float64          `json:"averageDurationSeconds"`
+	RecentGames            []HeadToHeadGame `json:"recentGames"`
+}
+
+// HeadToHeadSummary is the compact form of a head-to-head record sent when a game starts
+type HeadToHeadSummary struct {
+	TotalGames int    `json:"totalGames"`
+	Wins       int    `json:"wins"`
+	Losses     int    `json:"losses"`
+	Draws      int    `json:"draws"`
+	LastResult string `json:"lastResult,omitempty"`
+}
+
+// Summary returns the compact form of the record
+func (h *HeadToHead) Summary() *HeadToHeadSummary {
+	summary := &HeadToHeadSummary{
+		TotalGames: h.TotalGames,
+		Wins:       h.Wins,
+		Losses:     h.Losses,
+		Draws:      h.Draws,
+	}
+	if len(h.RecentGames) > 0 {
+		summary.LastResult = h.RecentGames[0].Result
+	}
+	return summary
+}
+
+// ComputeHeadToHead builds the record of player against opponent from their
+// completed games. Games are expected most recent first; at most recentLimit
+// of them are listed individually.
+func ComputeHeadToHead(player, opponent string, games []*models.GameSession, recentLimit int) *HeadToHead {
+	record := &HeadToHead{
+		Player:      player,
+		Opponent:    opponent,
+		RecentGames: []HeadToHeadGame{},
+	}
+
+	totalMoves := 0
+	totalDuration := 0
+	timedGames := 0
+
+	for _, game := range games {
+		if !game.IsCompleted() {
+			continue
+		}
+		isPlayer1 := game.Player1 == player && game.Player2 == opponent
+		isPlayer2 := game.Player2 == player && game.Player1 == opponent
+		if !isPlayer1 && !isPlayer2 {
+			continue
+		}
+
+		color := models.PlayerColorRed
+		colorRecord := &record.AsRed
+		if isPlayer2 {
+			color = models.PlayerColorYellow
+			colorRecord = &record.AsYellow
+		}
+
+		result := ResultDraw
+		if game.Winner != nil {
+			if *game.Winner == color {
+				result = ResultWin
+			} else {
+				result = ResultLoss
+			}
+		}
+
+		record.TotalGames++
+		colorRecord.Games++
+		switch result {
+		case ResultWin:
+			record.Wins++
+			colorRecord.Wins++
+		case ResultLoss:
+			record.Losses++
+			colorRecord.Losses++
+		default:
+			record.Draws++
+			colorRecord.Draws++
+		}
+
+		moves := 0
+		for _, height := range game.Board.Height {
+			moves += height
+		}
+		totalMoves += moves
+
+		duration := 0
+		if game.EndTime != nil {
+			duration = int(game.EndTime.Sub(game.StartTime).Seconds())
+			totalDuration += duration
+			timedGames++
+		}
+
+		if len(record.RecentGames) < recentLimit {
+			record.RecentGames = append(record.RecentGames, HeadToHeadGame{
+				GameID:          game.ID,
+				Color:           color,
+				Result:          result,
+				Moves:           moves,
+				DurationSeconds: duration,
+				PlayedAt:        game.StartTime,
+			})
+		}
+	}
+
+	if record.TotalGames > 0 {
+		record.AverageMoves = float64(totalMoves) / float64(record.TotalGames)
+	}
+	if timedGames > 0 {
+		record.AverageDurationSeconds = float64(totalDuration) / float64(timedGames)
+	}
+
+	return record
+}
+
+// HeadToHeadService computes head-to-head records between players
+type HeadToHeadService interface {
+	GetHeadToHead(ctx context.Context, player, opponent string) (*HeadToHead, error)
+}
+
+// headToHeadService implements HeadToHeadService interface
+type headToHeadService struct {
+	gameRepo    repositories.GameSessionRepository
+	recentLimit int
+}
+
+// NewHeadToHeadService creates a new HeadToHeadService instance
+func NewHeadToHeadService(gameRepo repositories.GameSessionRepository) HeadToHeadService {
+	return &headToHeadService{
+		gameRepo:    gameRepo,
+		recentLimit: DefaultRecentGamesLimit,
+	}
+}
+
+// GetHeadToHead computes the record of player against opponent from game_sessions
+func (s *headToHeadService) GetHeadToHead(ctx context.Context, player, opponent string) (*HeadToHead, error) {
+	if player == "" || opponent == "" {
+		return nil, fmt.Errorf("both players are required")
+	}
+	if player == opponent {
+		return nil, fmt.Errorf("players must be different")
+	}
+
+	games, err := s.gameRepo.GetGamesBetweenPlayers(ctx, player, opponent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get head-to-head games: %w", err)
+	}
+
+	return ComputeHeadToHead(player, opponent, games, s.recentLimit), nil
+}
@@ -0,0 +1,79 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+func completedGame(id, player1, player2 string, winner *models.PlayerColor, moves int, duration time.Duration) *models.GameSession {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(duration)
+	board := models.NewBoard()
+	for i := 0; i < moves; i++ {
+		board.Height[i%7]++
+	}
+	return &models.GameSession{
+		ID:        id,
+		Player1:   player1,
+		Player2:   player2,
+		Board:     board,
+		Status:    models.StatusCompleted,
+		Winner:    winner,
+		StartTime: start,
+		EndTime:   &end,
+	}
+}
+
+func TestComputeHeadToHead(t *testing.T) {
+	red, yellow := models.PlayerColorRed, models.PlayerColorYellow
+	games := []*models.GameSession{
+		completedGame("g4", "bobby", "alice", &yellow, 12, 60*time.Second),
+		completedGame("g3", "alice", "bobby", nil, 42, 300*time.Second),
+		completedGame("g2", "alice", "bobby", &yellow, 20, 120*time.Second),
+		completedGame("g1", "alice", "bobby", &red, 10, 60*time.Second),
+		completedGame("other", "alice", "carol", &red, 7, 30*time.Second),
+	}
+
+	record := ComputeHeadToHead("alice", "bobby", games, 3)
+
+	assert.Equal(t, 4, record.TotalGames)
+	assert.Equal(t, 2, record.Wins)
+	assert.Equal(t, 1, record.Losses)
+	assert.Equal(t, 1, record.Draws)
+	assert.Equal(t, ColorRecord{Games: 3, Wins: 1, Losses: 1, Draws: 1}, record.AsRed)
+	assert.Equal(t, ColorRecord{Games: 1, Wins: 1}, record.AsYellow)
+	assert.InDelta(t, 21.0, record.AverageMoves, 0.001)
+	assert.InDelta(t, 135.0, record.AverageDurationSeconds, 0.001)
+
+	assert.Len(t, record.RecentGames, 3)
+	assert.Equal(t, "g4", record.RecentGames[0].GameID)
+	assert.Equal(t, ResultWin, record.RecentGames[0].Result)
+	assert.Equal(t, models.PlayerColorYellow, record.RecentGames[0].Color)
+
+	summary := record.Summary()
+	assert.Equal(t, 4, summary.TotalGames)
+	assert.Equal(t, ResultWin, summary.LastResult)
+}
+
+func TestComputeHeadToHead_Mirrored(t *testing.T) {
+	red := models.PlayerColorRed
+	games := []*models.GameSession{
+		completedGame("g1", "alice", "bobby", &red, 10, time.Minute),
+	}
+
+	record := ComputeHeadToHead("bobby", "alice", games, DefaultRecentGamesLimit)
+	assert.Equal(t, 1, record.Losses)
+	assert.Equal(t, 1, record.AsYellow.Losses)
+	assert.Equal(t, ResultLoss, record.Summary().LastResult)
+}
+
+func TestComputeHeadToHead_NoGames(t *testing.T) {
+	record := ComputeHeadToHead("alice", "bobby", nil, DefaultRecentGamesLimit)
+	assert.Equal(t, 0, record.TotalGames)
+	assert.Empty(t, record.RecentGames)
+	assert.Empty(t, record.Summary().LastResult)
+}
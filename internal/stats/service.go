@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"connect4-multiplayer/internal/bot"
 	"connect4-multiplayer/internal/database/repositories"
 	"connect4-multiplayer/pkg/models"
 )
@@ -174,11 +175,15 @@ func (s *playerStatsService) GetTopPlayers(ctx context.Context) ([]*models.Playe
 	return s.GetLeaderboard(ctx, 10)
 }
 
-// RecordGameResult records a game result for a single player
+// RecordGameResult records a game result for a single player. Bots played
+// by the server keep no stats, so they never reach the leaderboard.
 func (s *playerStatsService) RecordGameResult(ctx context.Context, username string, won bool, gameDuration int) error {
 	if username == "" {
 		return fmt.Errorf("username cannot be empty")
 	}
+	if bot.IsBotUsername(username) {
+		return nil
+	}
 
 	// Update stats in database
 	if err := s.statsRepo.UpdateGameStats(ctx, username, won, gameDuration); err != nil {
@@ -200,7 +205,8 @@ func (s *playerStatsService) RecordGameResult(ctx context.Context, username stri
 	return nil
 }
 
-// RecordGameCompletion records the result of a completed game for both players
+// RecordGameCompletion records the result of a completed game for both
+// players, leaving out bots played by the server
 func (s *playerStatsService) RecordGameCompletion(ctx context.Context, player1, player2 string, winner *models.PlayerColor, gameDuration int) error {
 	if player1 == "" || player2 == "" {
 		return fmt.Errorf("player usernames cannot be empty")
@@ -211,13 +217,17 @@ func (s *playerStatsService) RecordGameCompletion(ctx context.Context, player1,
 	player2Won := winner != nil && *winner == models.PlayerColorYellow
 
 	// Update player1 stats
-	if err := s.statsRepo.UpdateGameStats(ctx, player1, player1Won, gameDuration); err != nil {
-		return fmt.Errorf("failed to update player1 stats: %w", err)
+	if !bot.IsBotUsername(player1) {
+		if err := s.statsRepo.UpdateGameStats(ctx, player1, player1Won, gameDuration); err != nil {
+			return fmt.Errorf("failed to update player1 stats: %w", err)
+		}
 	}
 
 	// Update player2 stats
-	if err := s.statsRepo.UpdateGameStats(ctx, player2, player2Won, gameDuration); err != nil {
-		return fmt.Errorf("failed to update player2 stats: %w", err)
+	if !bot.IsBotUsername(player2) {
+		if err := s.statsRepo.UpdateGameStats(ctx, player2, player2Won, gameDuration); err != nil {
+			return fmt.Errorf("failed to update player2 stats: %w", err)
+		}
 	}
 
 	// Invalidate cache for both players
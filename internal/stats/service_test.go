@@ -57,6 +57,14 @@ func (m *MockPlayerStatsRepository) GetLeaderboard(ctx context.Context, limit in
 	return args.Get(0).([]*models.PlayerStats), args.Error(1)
 }
 
+func (m *MockPlayerStatsRepository) GetBotLeaderboard(ctx context.Context, limit int) ([]*models.PlayerStats, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PlayerStats), args.Error(1)
+}
+
 func (m *MockPlayerStatsRepository) UpdateGameStats(ctx context.Context, username string, won bool, gameDuration int) error {
 	args := m.Called(ctx, username, won, gameDuration)
 	return args.Error(0)
@@ -214,6 +222,20 @@ func (suite *PlayerStatsServiceTestSuite) TestRecordGameCompletion_Draw() {
 	suite.mockRepo.AssertCalled(suite.T(), "UpdateGameStats", suite.ctx, "player2", false, 200)
 }
 
+func (suite *PlayerStatsServiceTestSuite) TestRecordGameCompletion_LeavesOutBots() {
+	winner := models.PlayerColorYellow
+
+	suite.mockRepo.On("UpdateGameStats", suite.ctx, "player1", false, 90).Return(nil)
+	suite.mockRepo.On("GetLeaderboard", mock.Anything, 10).Return([]*models.PlayerStats{}, nil).Maybe()
+
+	err := suite.service.RecordGameCompletion(suite.ctx, "player1", "Bot_Ext_pyc4_7", &winner, 90)
+	assert.NoError(suite.T(), err)
+	err = suite.service.RecordGameResult(suite.ctx, "Bot_MCTS_Hard_3", true, 90)
+	assert.NoError(suite.T(), err)
+
+	suite.mockRepo.AssertNumberOfCalls(suite.T(), "UpdateGameStats", 1)
+}
+
 func (suite *PlayerStatsServiceTestSuite) TestCreatePlayerStats_Success() {
 	suite.mockRepo.On("Create", suite.ctx, mock.AnythingOfType("*models.PlayerStats")).Return(nil)
 
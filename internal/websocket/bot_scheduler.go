@@ -0,0 +1,280 @@
+package websocket
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"connect4-multiplayer/pkg/models"
+)
+
+// BotSchedulerConfig configures the worker pool that plays bot turns
+type BotSchedulerConfig struct {
+	// Workers is how many bot moves are computed at once
+	Workers int
+	// MoveDelay is the pause before a bot moves, so replies don't feel instant
+	MoveDelay time.Duration
+}
+
+// DefaultBotSchedulerConfig returns default bot scheduler configuration
+func DefaultBotSchedulerConfig() *BotSchedulerConfig {
+	return &BotSchedulerConfig{
+		Workers:   4,
+		MoveDelay: 500 * time.Millisecond,
+	}
+}
+
+// BotSchedulerStats is a snapshot of the bot scheduler's metrics
+type BotSchedulerStats struct {
+	Workers int `json:"workers"`
+	// QueueDepth counts bot turns waiting for a worker, including ones still
+	// in their move delay
+	QueueDepth int   `json:"queueDepth"`
+	InFlight   int   `json:"inFlight"`
+	Scheduled  int64 `json:"scheduled"`
+	Completed  int64 `json:"completed"`
+	Recovered  int64 `json:"recovered"`
+	// Latencies run from the turn being scheduled until the move is played,
+	// including the move delay
+	AvgLatencyMs  float64 `json:"avgLatencyMs"`
+	LastLatencyMs float64 `json:"lastLatencyMs"`
+	MaxLatencyMs  float64 `json:"maxLatencyMs"`
+}
+
+// botTurn is a game waiting for its bot to move
+type botTurn struct {
+	gameID    string
+	scheduled time.Time
+}
+
+// botScheduler plays bot turns on a bounded pool of workers. Each game is
+// queued at most once, and a game being played is not queued again until
+// its move is done, so duplicate triggers (moves, reconnects, recovery) are
+// harmless.
+type botScheduler struct {
+	play   func(ctx context.Context, gameID string)
+	config *BotSchedulerConfig
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queue   []botTurn
+	pending map[string]bool // queued, delayed or being played
+	delayed int
+	running int
+	stopped bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+
+	scheduled    int64
+	completed    int64
+	recovered    int64
+	totalLatency time.Duration
+	lastLatency  time.Duration
+	maxLatency   time.Duration
+}
+
+func newBotScheduler(play func(ctx context.Context, gameID string), config *BotSchedulerConfig) *botScheduler {
+	if config == nil {
+		config = DefaultBotSchedulerConfig()
+	}
+	if config.Workers <= 0 {
+		config.Workers = 1
+	}
+	if config.MoveDelay < 0 {
+		config.MoveDelay = 0
+	}
+
+	s := &botScheduler{
+		play:    play,
+		config:  config,
+		pending: make(map[string]bool),
+	}
+	s.cond = sync.NewCond(&s.mu)
+	return s
+}
+
+// Start launches the workers. Turns scheduled before Start wait in the queue.
+func (s *botScheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ctx != nil {
+		return
+	}
+
+	s.ctx, s.cancel = context.WithCancel(ctx)
+	for i := 0; i < s.config.Workers; i++ {
+		s.wg.Add(1)
+		go s.worker()
+	}
+}
+
+// Stop drops queued turns and waits for moves being played to finish
+func (s *botScheduler) Stop() {
+	s.mu.Lock()
+	s.stopped = true
+	s.queue = nil
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.cond.Broadcast()
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// Schedule queues a bot turn for a game unless one is already pending
+func (s *botScheduler) Schedule(gameID string) {
+	s.schedule(gameID, false)
+}
+
+// Recover queues a bot turn found pending at startup
+func (s *botScheduler) Recover(gameID string) {
+	s.schedule(gameID, true)
+}
+
+func (s *botScheduler) schedule(gameID string, recovered bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped || s.pending[gameID] {
+		return
+	}
+
+	s.pending[gameID] = true
+	s.scheduled++
+	if recovered {
+		s.recovered++
+	}
+
+	turn := botTurn{gameID: gameID, scheduled: time.Now()}
+	if s.config.MoveDelay == 0 {
+		s.enqueueLocked(turn)
+		return
+	}
+
+	s.delayed++
+	time.AfterFunc(s.config.MoveDelay, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.delayed--
+		if s.stopped {
+			return
+		}
+		s.enqueueLocked(turn)
+	})
+}
+
+func (s *botScheduler) enqueueLocked(turn botTurn) {
+	s.queue = append(s.queue, turn)
+	s.cond.Signal()
+}
+
+// worker plays queued turns until the scheduler stops
+func (s *botScheduler) worker() {
+	defer s.wg.Done()
+
+	for {
+		s.mu.Lock()
+		for len(s.queue) == 0 && !s.stopped {
+			s.cond.Wait()
+		}
+		if s.stopped {
+			s.mu.Unlock()
+			return
+		}
+		turn := s.queue[0]
+		s.queue = s.queue[1:]
+		s.running++
+		ctx := s.ctx
+		s.mu.Unlock()
+
+		s.playTurn(ctx, turn)
+
+		latency := time.Since(turn.scheduled)
+		s.mu.Lock()
+		delete(s.pending, turn.gameID)
+		s.running--
+		s.completed++
+		s.totalLatency += latency
+		s.lastLatency = latency
+		s.maxLatency = max(s.maxLatency, latency)
+		s.mu.Unlock()
+	}
+}
+
+// playTurn plays one turn, keeping a panicking move from killing the worker
+func (s *botScheduler) playTurn(ctx context.Context, turn botTurn) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Bot move for game %s panicked: %v", turn.gameID, r)
+		}
+	}()
+	s.play(ctx, turn.gameID)
+}
+
+// Stats returns a snapshot of the scheduler's metrics
+func (s *botScheduler) Stats() BotSchedulerStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := BotSchedulerStats{
+		Workers:       s.config.Workers,
+		QueueDepth:    len(s.queue) + s.delayed,
+		InFlight:      s.running,
+		Scheduled:     s.scheduled,
+		Completed:     s.completed,
+		Recovered:     s.recovered,
+		LastLatencyMs: durationMs(s.lastLatency),
+		MaxLatencyMs:  durationMs(s.maxLatency),
+	}
+	if s.completed > 0 {
+		stats.AvgLatencyMs = durationMs(s.totalLatency / time.Duration(s.completed))
+	}
+	return stats
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// recoverBotTurns schedules every in-progress game waiting on its bot. Bot
+// turns are only triggered by the move before them, so without this a game
+// whose bot turn was pending when the server stopped would never continue.
+func (h *GameMessageHandler) recoverBotTurns(ctx context.Context) error {
+	sessions, err := h.gameService.GetActiveSessions(ctx)
+	if err != nil {
+		return err
+	}
+
+	recovered := 0
+	for _, session := range sessions {
+		if session.Status == models.StatusInProgress && h.isBot(session.GetCurrentPlayer()) {
+			h.botScheduler.Recover(session.ID)
+			recovered++
+		}
+	}
+	if recovered > 0 {
+		log.Printf("Recovered %d pending bot turns", recovered)
+	}
+	return nil
+}
+
+// HandleMetrics reports connection counts, bot scheduler metrics and bot
+// search statistics per difficulty
+func (h *WebSocketHandler) HandleMetrics(c *gin.Context) {
+	response := gin.H{
+		"connections": h.hub.GetConnectionCount(),
+		"activeGames": len(h.hub.GetActiveGames()),
+	}
+	if h.botScheduler != nil {
+		response["botScheduler"] = h.botScheduler.Stats()
+	}
+	if h.botService != nil {
+		response["botSearch"] = h.botService.SearchMetrics()
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,180 @@
+package websocket
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"connect4-multiplayer/internal/game"
+	"connect4-multiplayer/pkg/models"
+)
+
+func TestBotScheduler(t *testing.T) {
+	t.Run("PlaysScheduledTurns", func(t *testing.T) {
+		played := make(chan string, 4)
+		scheduler := newBotScheduler(func(ctx context.Context, gameID string) {
+			played <- gameID
+		}, &BotSchedulerConfig{Workers: 2})
+		scheduler.Start(context.Background())
+		defer scheduler.Stop()
+
+		scheduler.Schedule("game-1")
+		scheduler.Schedule("game-2")
+
+		got := []string{<-played, <-played}
+		assert.ElementsMatch(t, []string{"game-1", "game-2"}, got)
+
+		require.Eventually(t, func() bool { return scheduler.Stats().Completed == 2 }, time.Second, 5*time.Millisecond)
+		stats := scheduler.Stats()
+		assert.Equal(t, int64(2), stats.Scheduled)
+		assert.Equal(t, 0, stats.QueueDepth)
+		assert.Equal(t, 0, stats.InFlight)
+	})
+
+	t.Run("DeduplicatesPendingTurns", func(t *testing.T) {
+		release := make(chan struct{})
+		var plays atomic.Int32
+		scheduler := newBotScheduler(func(ctx context.Context, gameID string) {
+			plays.Add(1)
+			<-release
+		}, &BotSchedulerConfig{Workers: 1})
+		scheduler.Start(context.Background())
+		defer scheduler.Stop()
+
+		scheduler.Schedule("game-1")
+		require.Eventually(t, func() bool { return scheduler.Stats().InFlight == 1 }, time.Second, 5*time.Millisecond)
+
+		// A reconnect while the move is being played must not queue another
+		scheduler.Schedule("game-1")
+		assert.Equal(t, 0, scheduler.Stats().QueueDepth)
+
+		close(release)
+		require.Eventually(t, func() bool { return scheduler.Stats().Completed == 1 }, time.Second, 5*time.Millisecond)
+
+		// Once the move is done the game can be scheduled again
+		scheduler.Schedule("game-1")
+		require.Eventually(t, func() bool { return scheduler.Stats().Completed == 2 }, time.Second, 5*time.Millisecond)
+		assert.Equal(t, int32(2), plays.Load())
+	})
+
+	t.Run("BoundsConcurrentMoves", func(t *testing.T) {
+		var mu sync.Mutex
+		running, peak := 0, 0
+		scheduler := newBotScheduler(func(ctx context.Context, gameID string) {
+			mu.Lock()
+			running++
+			peak = max(peak, running)
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}, &BotSchedulerConfig{Workers: 2})
+		scheduler.Start(context.Background())
+		defer scheduler.Stop()
+
+		for _, gameID := range []string{"a", "b", "c", "d", "e", "f"} {
+			scheduler.Schedule(gameID)
+		}
+		require.Eventually(t, func() bool { return scheduler.Stats().Completed == 6 }, time.Second, 5*time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.LessOrEqual(t, peak, 2)
+	})
+
+	t.Run("WaitsForMoveDelay", func(t *testing.T) {
+		played := make(chan time.Time, 1)
+		scheduler := newBotScheduler(func(ctx context.Context, gameID string) {
+			played <- time.Now()
+		}, &BotSchedulerConfig{Workers: 1, MoveDelay: 30 * time.Millisecond})
+		scheduler.Start(context.Background())
+		defer scheduler.Stop()
+
+		start := time.Now()
+		scheduler.Schedule("game-1")
+		assert.Equal(t, 1, scheduler.Stats().QueueDepth)
+
+		assert.GreaterOrEqual(t, (<-played).Sub(start), 30*time.Millisecond)
+		require.Eventually(t, func() bool { return scheduler.Stats().Completed == 1 }, time.Second, 5*time.Millisecond)
+		assert.GreaterOrEqual(t, scheduler.Stats().MaxLatencyMs, 30.0)
+	})
+
+	t.Run("SurvivesPanickingMoves", func(t *testing.T) {
+		played := make(chan string, 1)
+		scheduler := newBotScheduler(func(ctx context.Context, gameID string) {
+			if gameID == "bad" {
+				panic("engine exploded")
+			}
+			played <- gameID
+		}, &BotSchedulerConfig{Workers: 1})
+		scheduler.Start(context.Background())
+		defer scheduler.Stop()
+
+		scheduler.Schedule("bad")
+		scheduler.Schedule("good")
+		assert.Equal(t, "good", <-played)
+	})
+
+	t.Run("QueuesTurnsScheduledBeforeStart", func(t *testing.T) {
+		played := make(chan string, 1)
+		scheduler := newBotScheduler(func(ctx context.Context, gameID string) {
+			played <- gameID
+		}, &BotSchedulerConfig{Workers: 1})
+
+		scheduler.Recover("game-1")
+		assert.Equal(t, 1, scheduler.Stats().QueueDepth)
+
+		scheduler.Start(context.Background())
+		defer scheduler.Stop()
+		assert.Equal(t, "game-1", <-played)
+		assert.Equal(t, int64(1), scheduler.Stats().Recovered)
+	})
+}
+
+// activeSessionsGameService serves GetActiveSessions for recovery tests
+type activeSessionsGameService struct {
+	game.GameService
+	sessions []*models.GameSession
+}
+
+func (s *activeSessionsGameService) GetActiveSessions(ctx context.Context) ([]*models.GameSession, error) {
+	return s.sessions, nil
+}
+
+func TestRecoverBotTurns(t *testing.T) {
+	gameService := &activeSessionsGameService{sessions: []*models.GameSession{
+		// Waiting on the bot: recovered
+		{ID: "bot-turn", Player1: "alice", Player2: "Bot_Hard", Status: models.StatusInProgress, CurrentTurn: models.PlayerColorYellow},
+		// Waiting on the human: left alone
+		{ID: "human-turn", Player1: "alice", Player2: "Bot_Hard", Status: models.StatusInProgress, CurrentTurn: models.PlayerColorRed},
+		// Two humans
+		{ID: "pvp", Player1: "alice", Player2: "bobby", Status: models.StatusInProgress, CurrentTurn: models.PlayerColorYellow},
+		// Not started
+		{ID: "waiting", Player1: "Bot_Easy", Player2: "carol", Status: models.StatusWaiting, CurrentTurn: models.PlayerColorRed},
+	}}
+
+	var mu sync.Mutex
+	var played []string
+	handler := &GameMessageHandler{gameService: gameService}
+	handler.botScheduler = newBotScheduler(func(ctx context.Context, gameID string) {
+		mu.Lock()
+		played = append(played, gameID)
+		mu.Unlock()
+	}, &BotSchedulerConfig{Workers: 1})
+	handler.botScheduler.Start(context.Background())
+	defer handler.botScheduler.Stop()
+
+	require.NoError(t, handler.recoverBotTurns(context.Background()))
+	require.Eventually(t, func() bool { return handler.botScheduler.Stats().Completed == 1 }, time.Second, 5*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"bot-turn"}, played)
+	assert.Equal(t, int64(1), handler.botScheduler.Stats().Recovered)
+}
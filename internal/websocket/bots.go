@@ -0,0 +1,123 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"connect4-multiplayer/internal/botaccounts"
+	"connect4-multiplayer/pkg/models"
+)
+
+// BotAccounts authenticates bot account connections and keeps human
+// connections off bot usernames
+type BotAccounts interface {
+	Authenticate(ctx context.Context, token string) (*models.BotAccount, error)
+	IsBotAccount(ctx context.Context, username string) (bool, error)
+	Allow(username string) bool
+}
+
+// botMessageTypes are the messages bot accounts may send. Bots reach games
+// through challenges, never the matchmaking queue or custom rooms, so they
+// only meet players who chose to play them.
+var botMessageTypes = map[MessageType]bool{
+	MessageTypeMakeMove:         true,
+	MessageTypeReconnect:        true,
+	MessageTypeLeaveGame:        true,
+	MessageTypePing:             true,
+	MessageTypeChallengePlayer:  true,
+	MessageTypeRespondChallenge: true,
+}
+
+// HandleBotWebSocket upgrades a bot account's connection. Bots authenticate
+// with their API token in the Authorization header and always act as their
+// own account; after that they speak the same messages as the web client.
+func (h *WebSocketHandler) HandleBotWebSocket(c *gin.Context) {
+	if h.botAccounts == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Bot API is not enabled"})
+		return
+	}
+
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Missing bot token"})
+		return
+	}
+
+	account, err := h.botAccounts.Authenticate(c.Request.Context(), token)
+	if err != nil {
+		if errors.Is(err, botaccounts.ErrInvalidToken) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid bot token"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to authenticate bot"})
+		return
+	}
+
+	conn, err := Upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Printf("Failed to upgrade bot WebSocket: %v", err)
+		return
+	}
+
+	wsConn := NewConnection(conn, account.Username, "", h.hub)
+	wsConn.bot = true
+	h.hub.RegisterConnection(wsConn)
+	wsConn.Start(c.Request.Context(), h.config)
+
+	log.Printf("Bot connection established: bot=%s", account.Username)
+}
+
+// isBotAccount reports whether a human connection is claiming a bot's username
+func (h *WebSocketHandler) isBotAccount(ctx context.Context, username string) bool {
+	return isBotAccount(ctx, h.botAccounts, username)
+}
+
+// checkSender applies the bot API's rules before a message is handled. Bot
+// connections are rate limited, restricted to botMessageTypes and pinned to
+// their own username; human connections may not use a bot's username.
+func (h *GameMessageHandler) checkSender(ctx context.Context, conn *Connection, message *Message) error {
+	if h.botAccounts == nil {
+		return nil
+	}
+
+	if !conn.IsBot() {
+		if username := stringPayload(message, "username"); isBotAccount(ctx, h.botAccounts, username) {
+			return fmt.Errorf("%w: %s is a bot account", ErrUnauthorized, username)
+		}
+		return nil
+	}
+
+	username := conn.GetUserID()
+	if !h.botAccounts.Allow(username) {
+		return ErrRateLimitExceeded
+	}
+	if !botMessageTypes[message.Type] {
+		return fmt.Errorf("%s is not available to bot accounts", message.Type)
+	}
+
+	if message.Payload == nil {
+		message.Payload = make(map[string]interface{})
+	}
+	message.Payload["username"] = username
+	return nil
+}
+
+// isBotAccount reports whether username belongs to a bot account. Lookup
+// failures let the connection through rather than lock players out.
+func isBotAccount(ctx context.Context, accounts BotAccounts, username string) bool {
+	if accounts == nil || username == "" {
+		return false
+	}
+	isBot, err := accounts.IsBotAccount(ctx, username)
+	if err != nil {
+		log.Printf("Failed to check bot account %s: %v", username, err)
+		return false
+	}
+	return isBot
+}
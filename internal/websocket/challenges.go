@@ -0,0 +1,171 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"connect4-multiplayer/internal/social"
+	"connect4-multiplayer/pkg/models"
+)
+
+// setChallengeService enables direct challenges and wires expiry notifications
+func (h *GameMessageHandler) setChallengeService(challengeService social.ChallengeService) {
+	h.challengeService = challengeService
+	challengeService.SetExpiredCallback(h.onChallengeExpired)
+}
+
+// handleChallengePlayer delivers a game invitation to another connected player
+func (h *GameMessageHandler) handleChallengePlayer(ctx context.Context, conn *Connection, message *Message) error {
+	if h.challengeService == nil {
+		return fmt.Errorf("challenges are not enabled")
+	}
+
+	// Challenges are sent as the connected player, whatever the payload says
+	username := conn.GetUserID()
+	if username == "" {
+		return fmt.Errorf("invalid username")
+	}
+
+	target, ok := message.Payload["target"].(string)
+	if !ok || target == "" {
+		return fmt.Errorf("invalid target")
+	}
+
+	// Check the block list before presence, so a blocked challenger learns
+	// nothing about the target
+	if err := h.challengeService.CheckBlocked(ctx, username, target); err != nil {
+		h.sendError(conn, challengeErrorCode(err), "Failed to send challenge", err.Error())
+		return nil
+	}
+
+	targetConn, exists := h.hub.GetConnection(target)
+	if !exists {
+		h.sendError(conn, "player_offline", "Player is not online", target)
+		return nil
+	}
+
+	challenge, err := h.challengeService.CreateChallenge(ctx, username, target)
+	if err != nil {
+		h.sendError(conn, challengeErrorCode(err), "Failed to send challenge", err.Error())
+		return nil
+	}
+
+	log.Printf("Player %s challenged %s (challenge %s)", username, target, challenge.ID)
+
+	h.sendTo(targetConn, CreateChallengeReceivedMessage(challenge.ID, username, challenge.ExpiresAt))
+	h.sendTo(conn, CreateChallengeSentMessage(challenge.ID, target, challenge.ExpiresAt))
+
+	return nil
+}
+
+// handleRespondChallenge accepts or declines a challenge
+func (h *GameMessageHandler) handleRespondChallenge(ctx context.Context, conn *Connection, message *Message) error {
+	if h.challengeService == nil {
+		return fmt.Errorf("challenges are not enabled")
+	}
+
+	// Only the connected player can answer their challenges, whatever the
+	// payload says
+	username := conn.GetUserID()
+	if username == "" {
+		return fmt.Errorf("invalid username")
+	}
+
+	challengeID, ok := message.Payload["challengeId"].(string)
+	if !ok || challengeID == "" {
+		return fmt.Errorf("invalid challenge ID")
+	}
+
+	accept, _ := message.Payload["accept"].(bool)
+
+	if !accept {
+		challenge, err := h.challengeService.DeclineChallenge(ctx, challengeID, username)
+		if err != nil {
+			h.sendError(conn, challengeErrorCode(err), "Failed to decline challenge", err.Error())
+			return nil
+		}
+
+		if challengerConn, exists := h.hub.GetConnection(challenge.Challenger); exists {
+			h.sendTo(challengerConn, CreateChallengeDeclinedMessage(challenge.ID, username))
+		}
+		return nil
+	}
+
+	challenge, session, err := h.challengeService.AcceptChallenge(ctx, challengeID, username)
+	if err != nil {
+		h.sendError(conn, challengeErrorCode(err), "Failed to accept challenge", err.Error())
+		if challenge != nil {
+			if challengerConn, exists := h.hub.GetConnection(challenge.Challenger); exists {
+				h.sendError(challengerConn, challengeErrorCode(err), "Challenge could not start", err.Error())
+			}
+		}
+		return nil
+	}
+
+	log.Printf("Challenge %s accepted, game %s started", challenge.ID, session.ID)
+
+	h.joinPlayersToGame(session)
+	h.notifyMatchFound(session.Player1, session.ID, session.Player2, false)
+	h.notifyMatchFound(session.Player2, session.ID, session.Player1, false)
+	h.notifyGameStarted(session)
+
+	return nil
+}
+
+// onChallengeExpired notifies both players that a challenge timed out
+func (h *GameMessageHandler) onChallengeExpired(challenge *social.Challenge) {
+	msg := CreateChallengeExpiredMessage(challenge.ID, challenge.Challenger, challenge.Target)
+	for _, username := range []string{challenge.Challenger, challenge.Target} {
+		if conn, exists := h.hub.GetConnection(username); exists {
+			h.sendTo(conn, msg)
+		}
+	}
+}
+
+// joinPlayersToGame attaches both players' connections to a new game room
+func (h *GameMessageHandler) joinPlayersToGame(session *models.GameSession) {
+	for _, username := range []string{session.Player1, session.Player2} {
+		if conn, exists := h.hub.GetConnection(username); exists {
+			conn.SetGameID(session.ID)
+			h.hub.mu.Lock()
+			h.hub.addToGameRoom(conn)
+			h.hub.mu.Unlock()
+		}
+	}
+}
+
+// sendTo serializes and sends a message to a connection
+func (h *GameMessageHandler) sendTo(conn *Connection, msg *Message) {
+	data, err := msg.ToJSON()
+	if err != nil {
+		log.Printf("Failed to serialize %s message: %v", msg.Type, err)
+		return
+	}
+	conn.SendMessage(data)
+}
+
+// sendError sends an error message to a connection
+func (h *GameMessageHandler) sendError(conn *Connection, code, message, details string) {
+	h.sendTo(conn, CreateErrorMessage(code, message, details))
+}
+
+// challengeErrorCode maps challenge errors to client error codes
+func challengeErrorCode(err error) string {
+	switch {
+	case errors.Is(err, social.ErrChallengeNotFound), errors.Is(err, social.ErrChallengeNotActive):
+		return "challenge_not_found"
+	case errors.Is(err, social.ErrChallengePending):
+		return "challenge_pending"
+	case errors.Is(err, social.ErrPlayerBusy):
+		return "player_busy"
+	case errors.Is(err, social.ErrSelfRelation):
+		return "invalid_target"
+	case errors.Is(err, social.ErrPlayerBlocked):
+		// The same neutral code whoever blocked whom
+		return "player_unavailable"
+	default:
+		return "challenge_failed"
+	}
+}
@@ -21,6 +21,8 @@ type Connection struct {
 	mu       sync.RWMutex
 	lastSeen time.Time
 	closed   bool
+	bot      bool // authenticated as a bot account
+	training bool // bot moves come with explanations
 }
 
 // ConnectionConfig holds configuration for WebSocket connections
@@ -81,6 +83,27 @@ func (c *Connection) SetUserID(userID string) {
 	c.userID = userID
 }
 
+// IsBot reports whether the connection is a bot account's
+func (c *Connection) IsBot() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.bot
+}
+
+// SetTraining turns training mode on or off for this connection
+func (c *Connection) SetTraining(training bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.training = training
+}
+
+// IsTraining reports whether bot moves are explained to this connection
+func (c *Connection) IsTraining() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.training
+}
+
 // IsClosed returns whether the connection is closed
 func (c *Connection) IsClosed() bool {
 	c.mu.RLock()
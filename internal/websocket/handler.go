@@ -2,10 +2,10 @@ package websocket
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -13,6 +13,8 @@ import (
 	"connect4-multiplayer/internal/bot"
 	"connect4-multiplayer/internal/game"
 	"connect4-multiplayer/internal/matchmaking"
+	"connect4-multiplayer/internal/social"
+	"connect4-multiplayer/internal/stats"
 	"connect4-multiplayer/pkg/models"
 )
 
@@ -22,6 +24,11 @@ type GameMessageHandler struct {
 	matchmakingService matchmaking.MatchmakingService
 	hub                *Hub
 	botService         bot.BotPlayerService
+	headToHead         stats.HeadToHeadService
+	botStrength        stats.BotStrengthService
+	challengeService   social.ChallengeService
+	botAccounts        BotAccounts
+	botScheduler       *botScheduler
 }
 
 // NewGameMessageHandler creates a new game message handler
@@ -32,6 +39,7 @@ func NewGameMessageHandler(gameService game.GameService, matchmakingService matc
 		hub:                hub,
 		botService:         bot.NewBotPlayerService(),
 	}
+	handler.botScheduler = newBotScheduler(handler.makeBotMove, nil)
 
 	// Set up matchmaking callbacks
 	matchmakingService.SetGameCreatedCallback(handler.onGameCreated)
@@ -42,6 +50,10 @@ func NewGameMessageHandler(gameService game.GameService, matchmakingService matc
 
 // HandleMessage processes incoming WebSocket messages
 func (h *GameMessageHandler) HandleMessage(ctx context.Context, conn *Connection, message *Message) error {
+	if err := h.checkSender(ctx, conn, message); err != nil {
+		return err
+	}
+
 	switch message.Type {
 	case MessageTypeJoinQueue:
 		return h.handleJoinQueue(ctx, conn, message)
@@ -65,6 +77,10 @@ func (h *GameMessageHandler) HandleMessage(ctx context.Context, conn *Connection
 		return h.handleLeaveGame(ctx, conn, message)
 	case MessageTypePing:
 		return h.handlePing(ctx, conn, message)
+	case MessageTypeChallengePlayer:
+		return h.handleChallengePlayer(ctx, conn, message)
+	case MessageTypeRespondChallenge:
+		return h.handleRespondChallenge(ctx, conn, message)
 	default:
 		return fmt.Errorf("unknown message type: %s", message.Type)
 	}
@@ -77,6 +93,16 @@ func (h *GameMessageHandler) handleJoinQueue(ctx context.Context, conn *Connecti
 		return fmt.Errorf("invalid username")
 	}
 
+	// Without a choice, matchmaking falls back to an adaptive bot
+	var difficulty bot.Difficulty
+	if name := stringPayload(message, "botDifficulty"); name != "" {
+		var err error
+		difficulty, err = bot.ParseDifficulty(name)
+		if err != nil {
+			return fmt.Errorf("invalid bot difficulty: %w", err)
+		}
+	}
+
 	log.Printf("Player %s joining matchmaking queue", username)
 
 	// Update connection with username and re-register in hub
@@ -85,7 +111,9 @@ func (h *GameMessageHandler) handleJoinQueue(ctx context.Context, conn *Connecti
 	h.hub.UpdateConnectionUserID(conn, oldUserID, username)
 
 	// Join matchmaking queue
-	entry, err := h.matchmakingService.JoinQueue(ctx, username)
+	entry, err := h.matchmakingService.JoinQueueWithPreferences(ctx, username, matchmaking.QueuePreferences{
+		BotDifficulty: difficulty,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to join queue: %w", err)
 	}
@@ -140,15 +168,54 @@ func (h *GameMessageHandler) handlePlayWithBot(ctx context.Context, conn *Connec
 		return fmt.Errorf("invalid username")
 	}
 
-	log.Printf("Player %s requesting bot game", username)
+	difficulty, err := bot.ParseDifficulty(stringPayload(message, "difficulty"))
+	if err != nil {
+		return fmt.Errorf("invalid bot difficulty: %w", err)
+	}
+
+	algorithm, err := bot.ParseAlgorithm(stringPayload(message, "algorithm"))
+	if err != nil {
+		return fmt.Errorf("invalid bot algorithm: %w", err)
+	}
+
+	handicap, err := handicapPayload(message)
+	if err != nil {
+		return err
+	}
+
+	var engineBot *bot.BotPlayer
+	engine := stringPayload(message, "engine")
+	if engine != "" {
+		if engineBot, err = h.botService.CreateEngineBot(engine); err != nil {
+			return fmt.Errorf("unknown bot engine: %s", engine)
+		}
+		log.Printf("Player %s requesting game against engine %s", username, engine)
+	} else {
+		log.Printf("Player %s requesting %s %s bot game", username, difficulty, algorithm)
+	}
 
 	// Update connection with username and re-register in hub
 	oldUserID := conn.GetUserID()
 	conn.SetUserID(username)
 	h.hub.UpdateConnectionUserID(conn, oldUserID, username)
 
-	// Create bot game directly via matchmaking service
-	gameSession, err := h.matchmakingService.CreateBotGame(ctx, username)
+	// Engine, MCTS and handicap games skip matchmaking; other bot games go
+	// through it. Every game gets a bot with its own username.
+	var gameSession *models.GameSession
+	if handicap != nil {
+		if engineBot != nil {
+			gameSession, err = h.gameService.CreateHandicapBotSession(ctx, username, engineBot.Username, bot.DifficultyEngine, *handicap)
+		} else {
+			botUsername := h.botService.CreateBotWithAlgorithm(difficulty, algorithm).Username
+			gameSession, err = h.gameService.CreateHandicapBotSession(ctx, username, botUsername, difficulty, *handicap)
+		}
+	} else if engineBot != nil {
+		gameSession, err = h.gameService.CreateBotSession(ctx, username, engineBot.Username, bot.DifficultyEngine)
+	} else if algorithm != bot.AlgorithmMinimax {
+		gameSession, err = h.gameService.CreateBotSession(ctx, username, h.botService.CreateBotWithAlgorithm(difficulty, algorithm).Username, difficulty)
+	} else {
+		gameSession, err = h.matchmakingService.CreateBotGame(ctx, username, difficulty)
+	}
 	if err != nil {
 		log.Printf("Failed to create bot game: %v", err)
 		return fmt.Errorf("failed to create bot game: %w", err)
@@ -156,6 +223,10 @@ func (h *GameMessageHandler) handlePlayWithBot(ctx context.Context, conn *Connec
 
 	log.Printf("Bot game created: %s vs %s (Game ID: %s)", username, gameSession.Player2, gameSession.ID)
 
+	// Training mode explains every bot move
+	training, _ := message.Payload["training"].(bool)
+	conn.SetTraining(training)
+
 	// Set the game ID on the connection and add to game room BEFORE notifications
 	conn.SetGameID(gameSession.ID)
 	h.hub.addToGameRoom(conn)
@@ -348,6 +419,22 @@ func (h *GameMessageHandler) notifyGameStarted(session *models.GameSession) {
 		session.Board,
 	)
 
+	// Include each player's history against the other
+	h.attachHeadToHead(msg1, session.Player1, session.Player2)
+	h.attachHeadToHead(msg2, session.Player2, session.Player1)
+
+	// Let bot game clients show the difficulty they are playing against
+	if session.BotDifficulty != "" {
+		msg1.Payload["botDifficulty"] = session.BotDifficulty
+		msg2.Payload["botDifficulty"] = session.BotDifficulty
+	}
+
+	// Let clients show the head start and enforce the opening rule
+	if session.Handicap != nil {
+		msg1.Payload["handicap"] = session.Handicap
+		msg2.Payload["handicap"] = session.Handicap
+	}
+
 	// Send to both players
 	data1, _ := msg1.ToJSON()
 	data2, _ := msg2.ToJSON()
@@ -363,6 +450,24 @@ func (h *GameMessageHandler) notifyGameStarted(session *models.GameSession) {
 	log.Printf("Game started notifications sent to %s and %s", session.Player1, session.Player2)
 }
 
+// attachHeadToHead adds a short head-to-head summary to a game started message
+func (h *GameMessageHandler) attachHeadToHead(msg *Message, player, opponent string) {
+	if h.headToHead == nil || h.isBot(player) || h.isBot(opponent) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	record, err := h.headToHead.GetHeadToHead(ctx, player, opponent)
+	if err != nil {
+		log.Printf("Failed to load head-to-head for %s vs %s: %v", player, opponent, err)
+		return
+	}
+
+	msg.Payload["headToHead"] = record.Summary()
+}
+
 // sendQueueStatusUpdates sends periodic queue status updates to a player
 func (h *GameMessageHandler) sendQueueStatusUpdates(ctx context.Context, conn *Connection, username string) {
 	ticker := time.NewTicker(2 * time.Second) // Update every 2 seconds
@@ -433,9 +538,9 @@ func (h *GameMessageHandler) onBotGameCreated(ctx context.Context, player string
 
 	// If bot goes first (player is yellow), make bot move
 	if gameSession.CurrentTurn == models.PlayerColorRed && h.isBot(gameSession.Player1) {
-		go h.makeBotMove(ctx, gameSession.ID)
+		h.botScheduler.Schedule(gameSession.ID)
 	} else if gameSession.CurrentTurn == models.PlayerColorYellow && h.isBot(gameSession.Player2) {
-		go h.makeBotMove(ctx, gameSession.ID)
+		h.botScheduler.Schedule(gameSession.ID)
 	}
 
 	return nil
@@ -443,14 +548,99 @@ func (h *GameMessageHandler) onBotGameCreated(ctx context.Context, player string
 
 // isBot checks if a username belongs to a bot
 func (h *GameMessageHandler) isBot(username string) bool {
-	return strings.HasPrefix(username, "bot_") || strings.HasPrefix(username, "Bot_") || username == "Bot"
+	return bot.IsBotUsername(username)
 }
 
-// makeBotMove makes a move for the bot player
-func (h *GameMessageHandler) makeBotMove(ctx context.Context, gameID string) {
-	// Add a small delay to make it feel more natural
-	time.Sleep(500 * time.Millisecond)
+// stringPayload returns an optional string field from a message payload
+func stringPayload(message *Message, key string) string {
+	value, _ := message.Payload[key].(string)
+	return value
+}
+
+// handicapPayload returns the optional handicap of a bot game request, or
+// nil for an even game. The handicap must name the color it goes to.
+func handicapPayload(message *Message) (*models.Handicap, error) {
+	raw, ok := message.Payload["handicap"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid handicap: %w", err)
+	}
+	var handicap models.Handicap
+	if err := json.Unmarshal(data, &handicap); err != nil {
+		return nil, fmt.Errorf("invalid handicap: %w", err)
+	}
+	if err := handicap.Validate(); err != nil {
+		return nil, err
+	}
+	return &handicap, nil
+}
 
+// handicapScoreTimeout bounds the search scoring the columns a handicap lets
+// the bot play
+const handicapScoreTimeout = 200 * time.Millisecond
+
+// bestAllowedMove returns the highest scoring column the game's handicap
+// lets the bot play, scoring the columns afresh when the bot's last move left
+// no scores. Bots that cannot score columns play the first allowed one, and
+// column stands when no column is allowed
+func bestAllowedMove(ctx context.Context, botPlayer *bot.BotPlayer, session *models.GameSession, botColor models.PlayerColor, column int) int {
+	scores := botPlayer.Scores
+	if scorer, ok := botPlayer.AI.(bot.MoveScorer); ok && len(scores) == 0 {
+		var err error
+		scores, err = scorer.ScoreMoves(ctx, &session.Board, botColor, handicapScoreTimeout)
+		if err != nil && err != context.DeadlineExceeded {
+			log.Printf("Failed to score bot moves: %v", err)
+		}
+	}
+
+	allowed := func(col int) bool {
+		return session.Board.IsValidMove(col) && session.Handicap.CheckMove(&session.Board, botColor, col) == nil
+	}
+	best := -1
+	bestScore := 0
+	for _, score := range scores {
+		if score.Playable && allowed(score.Column) && (best == -1 || score.Score > bestScore) {
+			best, bestScore = score.Column, score.Score
+		}
+	}
+	if best != -1 {
+		return best
+	}
+	for col := 0; col < 7; col++ {
+		if allowed(col) {
+			return col
+		}
+	}
+	return column
+}
+
+// adaptiveStrength returns how strong an adaptive bot should play its next
+// move: the human's estimated strength, adjusted to the position on board
+func (h *GameMessageHandler) adaptiveStrength(ctx context.Context, session *models.GameSession, botColor models.PlayerColor) float64 {
+	human := session.Player1
+	if botColor == models.PlayerColorRed {
+		human = session.Player2
+	}
+
+	strength := bot.DefaultAdaptiveStrength
+	if h.botStrength != nil {
+		estimate, err := h.botStrength.EstimateStrength(ctx, human)
+		if err != nil {
+			log.Printf("Failed to estimate strength of %s: %v", human, err)
+		} else {
+			strength = estimate
+		}
+	}
+	return bot.AdaptiveStrength(strength, &session.Board, botColor)
+}
+
+// makeBotMove makes a move for the bot player. It runs on the bot scheduler;
+// use botScheduler.Schedule rather than calling it directly.
+func (h *GameMessageHandler) makeBotMove(ctx context.Context, gameID string) {
 	session, err := h.gameService.GetSession(ctx, gameID)
 	if err != nil {
 		log.Printf("Failed to get session for bot move: %v", err)
@@ -481,8 +671,26 @@ func (h *GameMessageHandler) makeBotMove(ctx context.Context, gameID string) {
 		return
 	}
 
-	// Create a bot player and get the best move
-	botPlayer := h.botService.CreateBot(bot.DifficultyMedium)
+	// Play with the game's engine, or with its algorithm at the difficulty
+	// persisted with it
+	var botPlayer *bot.BotPlayer
+	difficulty := bot.SessionDifficulty(session)
+	if engine, ok := bot.EngineFromUsername(botUsername); ok {
+		botPlayer, err = h.botService.CreateEngineBot(engine)
+		if err != nil {
+			log.Printf("Engine %s unavailable, playing as %s bot: %v", engine, bot.DefaultDifficulty, err)
+		}
+	}
+	// A bot at the default difficulty stands in for an engine that is down
+	if difficulty == bot.DifficultyEngine {
+		difficulty = bot.DefaultDifficulty
+	}
+	if botPlayer == nil && difficulty == bot.DifficultyAdaptive && bot.AlgorithmFromUsername(botUsername) == bot.AlgorithmMinimax {
+		botPlayer = h.botService.CreateAdaptiveBot(h.adaptiveStrength(ctx, session, botColor))
+	}
+	if botPlayer == nil {
+		botPlayer = h.botService.CreateBotWithAlgorithm(difficulty, bot.AlgorithmFromUsername(botUsername))
+	}
 	board := &session.Board
 	column, err := h.botService.GetBotMove(ctx, botPlayer, board, botColor)
 	if err != nil {
@@ -496,15 +704,34 @@ func (h *GameMessageHandler) makeBotMove(ctx context.Context, gameID string) {
 		}
 	}
 
-	log.Printf("Bot %s making move in column %d", botUsername, column)
+	// The bot's search does not know the handicap, so a move the opening
+	// rule forbids is replaced by the best allowed one
+	if session.Handicap != nil && session.Handicap.CheckMove(&session.Board, botColor, column) != nil {
+		column = bestAllowedMove(ctx, botPlayer, session, botColor, column)
+	}
+
+	if provider, ok := botPlayer.AI.(bot.SearchStatsProvider); ok {
+		stats := provider.LastSearchStats()
+		log.Printf("Bot %s making move in column %d (depth=%d, nodes=%d, tt hit rate=%.2f, time=%s, pv=%v, cutoff=%t, book hits=%d, book misses=%d)",
+			botUsername, column, stats.Depth, stats.Nodes, stats.TTHitRate(), stats.Duration, stats.PrincipalVariation, stats.Cutoff, stats.BookHits, stats.BookMisses)
+	} else {
+		log.Printf("Bot %s making move in column %d", botUsername, column)
+	}
 
 	// Make the move
+	before := session.Board
 	row := session.Board.Height[column]
 	if err := session.Board.MakeMove(column, botColor); err != nil {
 		log.Printf("Failed to make bot move: %v", err)
 		return
 	}
 
+	// Record the move before the game can complete, so that reviews and
+	// puzzle mining see the whole game
+	if err := h.gameService.RecordMove(ctx, gameID, botColor, column, row); err != nil {
+		log.Printf("Failed to record bot move: %v", err)
+	}
+
 	// Check for win or draw
 	winner := session.Board.CheckWin()
 	if winner != nil {
@@ -550,6 +777,16 @@ func (h *GameMessageHandler) makeBotMove(ctx context.Context, gameID string) {
 		moveCount,
 	)
 
+	// Explain the move to a human in training mode
+	human := session.Player1
+	if botColor == models.PlayerColorRed {
+		human = session.Player2
+	}
+	if conn, ok := h.hub.GetConnection(human); ok && conn.IsTraining() {
+		explanation := bot.ExplainMove(&before, botColor, column, botPlayer.Scores)
+		moveMadeMsg.Payload["explanation"] = explanation
+	}
+
 	data, err := moveMadeMsg.ToJSON()
 	if err != nil {
 		log.Printf("Failed to serialize move made message: %v", err)
@@ -703,14 +940,27 @@ func (h *GameMessageHandler) handleMakeMove(ctx context.Context, conn *Connectio
 		return fmt.Errorf("invalid move: column is full or out of bounds")
 	}
 
-	// Make the move
+	// Handicap games may restrict the stronger player's opening
 	playerColor := session.GetPlayerColor(username)
+	if session.Handicap != nil {
+		if err := session.Handicap.CheckMove(&session.Board, playerColor, column); err != nil {
+			return err
+		}
+	}
+
+	// Make the move
 	row := session.Board.Height[column] // Get row before making move
 
 	if err := session.Board.MakeMove(column, playerColor); err != nil {
 		return fmt.Errorf("failed to make move: %w", err)
 	}
 
+	// Record the move before the game can complete, so that reviews and
+	// puzzle mining see the whole game
+	if err := h.gameService.RecordMove(ctx, gameID, playerColor, column, row); err != nil {
+		log.Printf("Failed to record move: %v", err)
+	}
+
 	// Check for win or draw
 	winner := session.Board.CheckWin()
 	if winner != nil {
@@ -795,7 +1045,7 @@ func (h *GameMessageHandler) handleMakeMove(ctx context.Context, conn *Connectio
 		if h.isBot(updatedSession.Player1) || h.isBot(updatedSession.Player2) {
 			currentPlayer := updatedSession.GetCurrentPlayer()
 			if h.isBot(currentPlayer) {
-				go h.makeBotMove(ctx, gameID)
+				h.botScheduler.Schedule(gameID)
 			}
 		}
 	}
@@ -836,7 +1086,16 @@ func (h *GameMessageHandler) handleReconnect(ctx context.Context, conn *Connecti
 	h.hub.mu.Unlock()
 
 	// Send current game state
-	return h.sendGameState(ctx, conn, gameID)
+	if err := h.sendGameState(ctx, conn, gameID); err != nil {
+		return err
+	}
+
+	// Resume a bot game whose bot move was lost, e.g. across a server restart
+	if session.Status == models.StatusInProgress && h.isBot(session.GetCurrentPlayer()) {
+		h.botScheduler.Schedule(gameID)
+	}
+
+	return nil
 }
 
 // handleLeaveGame processes leave game requests
@@ -930,8 +1189,11 @@ func (h *GameMessageHandler) sendGameState(ctx context.Context, conn *Connection
 
 // WebSocketHandler handles WebSocket upgrade requests
 type WebSocketHandler struct {
-	hub    *Hub
-	config ConnectionConfig
+	hub          *Hub
+	config       ConnectionConfig
+	botAccounts  BotAccounts
+	botScheduler *botScheduler
+	botService   bot.BotPlayerService
 }
 
 // NewWebSocketHandler creates a new WebSocket handler
@@ -953,6 +1215,10 @@ func (h *WebSocketHandler) HandleWebSocket(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "User ID is required"})
 		return
 	}
+	if h.isBotAccount(c.Request.Context(), userID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Bot accounts must connect through the bot API"})
+		return
+	}
 
 	// Optional game ID for reconnection
 	gameID := c.Query("gameId")
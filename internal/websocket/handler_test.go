@@ -1,12 +1,21 @@
 package websocket
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 
+	"connect4-multiplayer/internal/bot"
+	"connect4-multiplayer/internal/database/repositories"
+	"connect4-multiplayer/internal/game"
+	"connect4-multiplayer/internal/puzzles"
+	"connect4-multiplayer/internal/social"
 	"connect4-multiplayer/pkg/models"
 )
 
@@ -77,6 +86,55 @@ func TestMessageCreation(t *testing.T) {
 		assert.Equal(t, "Column is full", msg.Payload["details"])
 	})
 
+	t.Run("CreateChallengeMessages", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Minute)
+
+		msg := CreateChallengePlayerMessage("alice", "bobby")
+		assert.Equal(t, MessageTypeChallengePlayer, msg.Type)
+		assert.Equal(t, "bobby", msg.Payload["target"])
+
+		msg = CreateRespondChallengeMessage("bobby", "ch1", true)
+		assert.Equal(t, MessageTypeRespondChallenge, msg.Type)
+		assert.Equal(t, true, msg.Payload["accept"])
+
+		msg = CreateChallengeReceivedMessage("ch1", "alice", expiresAt)
+		assert.Equal(t, MessageTypeChallengeReceived, msg.Type)
+		assert.Equal(t, "alice", msg.Payload["challenger"])
+		assert.Equal(t, expiresAt, msg.Payload["expiresAt"])
+
+		msg = CreateChallengeDeclinedMessage("ch1", "bobby")
+		assert.Equal(t, MessageTypeChallengeDeclined, msg.Type)
+
+		msg = CreateChallengeExpiredMessage("ch1", "alice", "bobby")
+		assert.Equal(t, MessageTypeChallengeExpired, msg.Type)
+		assert.Equal(t, "ch1", msg.Payload["challengeId"])
+	})
+
+	t.Run("CreateNotificationMessages", func(t *testing.T) {
+		notification := &models.Notification{
+			ID:       "n1",
+			Username: "bobby",
+			Type:     models.NotificationFriendRequest,
+			Title:    "Friend request",
+			Data:     models.NotificationData{"from": "alice"},
+		}
+
+		msg := CreateNotificationMessage(notification)
+		assert.Equal(t, MessageTypeNotification, msg.Type)
+		assert.Equal(t, "n1", msg.Payload["id"])
+		assert.Equal(t, models.NotificationFriendRequest, msg.Payload["type"])
+
+		msg = CreateNotificationsPendingMessage(1, []*models.Notification{notification})
+		assert.Equal(t, MessageTypeNotificationsPending, msg.Type)
+		assert.Equal(t, int64(1), msg.Payload["unreadCount"])
+	})
+
+	t.Run("CreatePlayWithBotMessage", func(t *testing.T) {
+		msg := CreatePlayWithBotMessage("testuser", "hard")
+		assert.Equal(t, MessageTypePlayWithBot, msg.Type)
+		assert.Equal(t, "hard", msg.Payload["difficulty"])
+	})
+
 	t.Run("CreatePongMessage", func(t *testing.T) {
 		msg := CreatePongMessage()
 		assert.Equal(t, MessageTypePong, msg.Type)
@@ -137,3 +195,276 @@ func TestWebSocketErrors(t *testing.T) {
 		assert.NotEmpty(t, ErrGameAlreadyEnded.Error())
 	})
 }
+
+// fakeBotAccounts knows one bot account and allows a fixed number of messages
+type fakeBotAccounts struct {
+	username string
+	allowed  int
+}
+
+func (f *fakeBotAccounts) Authenticate(ctx context.Context, token string) (*models.BotAccount, error) {
+	return &models.BotAccount{Username: f.username}, nil
+}
+
+func (f *fakeBotAccounts) IsBotAccount(ctx context.Context, username string) (bool, error) {
+	return username == f.username, nil
+}
+
+func (f *fakeBotAccounts) Allow(username string) bool {
+	f.allowed--
+	return f.allowed >= 0
+}
+
+func TestCheckSender(t *testing.T) {
+	ctx := context.Background()
+	accounts := &fakeBotAccounts{username: "deepdrop", allowed: 3}
+	handler := &GameMessageHandler{botAccounts: accounts}
+
+	t.Run("HumanCannotClaimBotUsername", func(t *testing.T) {
+		human := NewConnection(nil, "alice", "", nil)
+		err := handler.checkSender(ctx, human, &Message{Type: MessageTypeJoinQueue, Payload: map[string]interface{}{"username": "deepdrop"}})
+		assert.ErrorIs(t, err, ErrUnauthorized)
+
+		err = handler.checkSender(ctx, human, &Message{Type: MessageTypeJoinQueue, Payload: map[string]interface{}{"username": "alice"}})
+		assert.NoError(t, err)
+	})
+
+	botConn := NewConnection(nil, "deepdrop", "", nil)
+	botConn.bot = true
+
+	t.Run("BotIsPinnedToItsUsername", func(t *testing.T) {
+		msg := &Message{Type: MessageTypeMakeMove, Payload: map[string]interface{}{"username": "alice", "column": 3}}
+		require.NoError(t, handler.checkSender(ctx, botConn, msg))
+		assert.Equal(t, "deepdrop", msg.Payload["username"])
+	})
+
+	t.Run("BotCannotJoinQueue", func(t *testing.T) {
+		err := handler.checkSender(ctx, botConn, &Message{Type: MessageTypeJoinQueue})
+		assert.Error(t, err)
+	})
+
+	t.Run("BotIsRateLimited", func(t *testing.T) {
+		require.NoError(t, handler.checkSender(ctx, botConn, &Message{Type: MessageTypePing}))
+		err := handler.checkSender(ctx, botConn, &Message{Type: MessageTypePing})
+		assert.ErrorIs(t, err, ErrRateLimitExceeded)
+	})
+}
+
+func TestHandicapPayload(t *testing.T) {
+	handicap, err := handicapPayload(&Message{Payload: map[string]interface{}{}})
+	require.NoError(t, err)
+	assert.Nil(t, handicap, "no handicap is an even game")
+
+	handicap, err = handicapPayload(&Message{Payload: map[string]interface{}{
+		"handicap": map[string]interface{}{"player": "red", "discs": []interface{}{3.0}, "edgeOpening": true},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, &models.Handicap{Player: models.PlayerColorRed, Discs: []int{3}, EdgeOpening: true}, handicap)
+
+	_, err = handicapPayload(&Message{Payload: map[string]interface{}{
+		"handicap": map[string]interface{}{"discs": []interface{}{3.0}},
+	}})
+	assert.ErrorIs(t, err, models.ErrInvalidHandicap, "the handicap names its player")
+
+	_, err = handicapPayload(&Message{Payload: map[string]interface{}{
+		"handicap": map[string]interface{}{"player": "yellow"},
+	}})
+	assert.ErrorIs(t, err, models.ErrInvalidHandicap)
+
+	_, err = handicapPayload(&Message{Payload: map[string]interface{}{"handicap": "three discs"}})
+	assert.Error(t, err)
+}
+
+// recordingChallenges records who answers challenges
+type recordingChallenges struct {
+	social.ChallengeService
+	answeredBy []string
+}
+
+func (c *recordingChallenges) DeclineChallenge(ctx context.Context, challengeID, username string) (*social.Challenge, error) {
+	c.answeredBy = append(c.answeredBy, username)
+	return &social.Challenge{ID: challengeID, Challenger: "alice", Target: "bobby"}, nil
+}
+
+func TestHandleRespondChallenge_AnswersAsTheConnectedPlayer(t *testing.T) {
+	hub := NewHub(nil, DefaultConnectionConfig())
+	go hub.Run()
+	defer hub.Shutdown()
+
+	challenges := &recordingChallenges{}
+	handler := &GameMessageHandler{hub: hub, challengeService: challenges}
+	conn := NewConnection(nil, "bobby", "", nil)
+
+	err := handler.handleRespondChallenge(context.Background(), conn, CreateRespondChallengeMessage("carol", "ch1", false))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bobby"}, challenges.answeredBy, "the payload cannot answer for another player")
+	assert.Equal(t, "bobby", conn.GetUserID())
+}
+
+// handicapGames serves a single in-memory game session
+type handicapGames struct {
+	game.GameService
+	session *models.GameSession
+}
+
+func (g *handicapGames) GetSession(ctx context.Context, gameID string) (*models.GameSession, error) {
+	return g.session, nil
+}
+
+func (g *handicapGames) SwitchTurn(ctx context.Context, gameID string) error {
+	if g.session.CurrentTurn == models.PlayerColorRed {
+		g.session.CurrentTurn = models.PlayerColorYellow
+	} else {
+		g.session.CurrentTurn = models.PlayerColorRed
+	}
+	return nil
+}
+
+func (g *handicapGames) RecordMove(ctx context.Context, gameID string, player models.PlayerColor, column, row int) error {
+	return nil
+}
+
+func (g *handicapGames) CompleteGame(ctx context.Context, gameID string, winner *models.PlayerColor) error {
+	g.session.Status = models.StatusCompleted
+	g.session.Winner = winner
+	return nil
+}
+
+// newHandicapGame returns a game where red starts with discs in the given
+// columns and yellow, to move, must open on an edge column
+func newHandicapGame(t *testing.T, player2 string, discs ...int) *models.GameSession {
+	session := &models.GameSession{
+		ID:          "game-1",
+		Player1:     "alice",
+		Player2:     player2,
+		Status:      models.StatusInProgress,
+		CurrentTurn: models.PlayerColorYellow,
+		Handicap:    &models.Handicap{Player: models.PlayerColorRed, Discs: discs, EdgeOpening: true},
+	}
+	board, err := session.InitialBoard()
+	require.NoError(t, err)
+	session.Board = board
+	return session
+}
+
+func TestHandleMakeMove_EnforcesHandicapOpening(t *testing.T) {
+	ctx := context.Background()
+	hub := NewHub(nil, DefaultConnectionConfig())
+	go hub.Run()
+	defer hub.Shutdown()
+
+	games := &handicapGames{session: newHandicapGame(t, "bobby", 3)}
+	handler := &GameMessageHandler{gameService: games, hub: hub}
+	conn := NewConnection(nil, "bobby", "game-1", nil)
+
+	err := handler.handleMakeMove(ctx, conn, &Message{Payload: map[string]interface{}{"gameId": "game-1", "column": 3.0}})
+	assert.ErrorIs(t, err, models.ErrInvalidMove)
+	assert.Equal(t, 1, games.session.Board.Height[3], "the move was not made")
+
+	err = handler.handleMakeMove(ctx, conn, &Message{Payload: map[string]interface{}{"gameId": "game-1", "column": 6.0}})
+	require.NoError(t, err)
+	assert.Equal(t, models.PlayerColorYellow, games.session.Board.Grid[0][6])
+	assert.Equal(t, models.PlayerColorRed, games.session.CurrentTurn)
+}
+
+func TestMakeBotMove_FollowsHandicapOpening(t *testing.T) {
+	hub := NewHub(nil, DefaultConnectionConfig())
+	go hub.Run()
+	defer hub.Shutdown()
+
+	// The bot would block red's open three in column 1 or 5, which the
+	// opening rule forbids
+	games := &handicapGames{session: newHandicapGame(t, "Bot_Easy_1", 2, 3, 4)}
+	handler := &GameMessageHandler{gameService: games, hub: hub, botService: bot.NewBotPlayerServiceWithSeed(1)}
+
+	handler.makeBotMove(context.Background(), "game-1")
+
+	board := games.session.Board
+	assert.Equal(t, 1, board.Height[0]+board.Height[6], "the bot opened on an edge column")
+	assert.Zero(t, board.Height[1]+board.Height[5])
+	assert.Equal(t, models.PlayerColorRed, games.session.CurrentTurn)
+}
+
+// scoringBots plays a fixed move and scores columns by a fixed table
+type scoringBots struct {
+	bot.BotPlayerService
+	move   int
+	scores []int
+}
+
+func (s *scoringBots) CreateBotWithAlgorithm(difficulty bot.Difficulty, algorithm bot.Algorithm) *bot.BotPlayer {
+	return &bot.BotPlayer{Username: "Bot_Easy_1", Difficulty: difficulty, AI: &tableScorer{scores: s.scores}}
+}
+
+func (s *scoringBots) GetBotMove(ctx context.Context, player *bot.BotPlayer, board *models.Board, color models.PlayerColor) (int, error) {
+	return s.move, nil
+}
+
+type tableScorer struct {
+	bot.BotAI
+	scores []int
+}
+
+func (a *tableScorer) ScoreMoves(ctx context.Context, board *models.Board, player models.PlayerColor, timeout time.Duration) ([]bot.ColumnScore, error) {
+	scores := make([]bot.ColumnScore, len(a.scores))
+	for col, score := range a.scores {
+		scores[col] = bot.ColumnScore{Column: col, Playable: board.IsValidMove(col), Score: score}
+	}
+	return scores, nil
+}
+
+func TestMakeBotMove_PlaysTheBestAllowedColumn(t *testing.T) {
+	hub := NewHub(nil, DefaultConnectionConfig())
+	go hub.Run()
+	defer hub.Shutdown()
+
+	games := &handicapGames{session: newHandicapGame(t, "Bot_Easy_1", 3)}
+	bots := &scoringBots{move: 3, scores: []int{-2, 0, 0, 5, 0, 0, 1}}
+	handler := &GameMessageHandler{gameService: games, hub: hub, botService: bots}
+
+	handler.makeBotMove(context.Background(), "game-1")
+
+	assert.Equal(t, models.PlayerColorYellow, games.session.Board.Grid[0][6], "the bot played its better edge column")
+	assert.Zero(t, games.session.Board.Height[0])
+}
+
+func TestHandleMakeMove_LiveGameCanBeMined(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.GameSession{}, &models.Move{}, &models.PlayerStats{},
+		&models.GameEvent{}, &models.Puzzle{}, &models.PuzzleAttempt{}))
+
+	gameRepo := repositories.NewGameSessionRepository(db)
+	moveRepo := repositories.NewMoveRepository(db)
+	games := game.NewGameService(gameRepo, repositories.NewPlayerStatsRepository(db), moveRepo,
+		repositories.NewGameEventRepository(db), game.DefaultServiceConfig())
+
+	hub := NewHub(nil, DefaultConnectionConfig())
+	go hub.Run()
+	defer hub.Shutdown()
+
+	session, err := games.CreateSession(ctx, "alice", "bobby")
+	require.NoError(t, err)
+	handler := &GameMessageHandler{gameService: games, hub: hub}
+	players := []*Connection{
+		NewConnection(nil, "alice", session.ID, nil),
+		NewConnection(nil, "bobby", session.ID, nil),
+	}
+
+	// Red sets up an open three on the bottom row with its fifth move and wins
+	for i, column := range []int{1, 1, 2, 2, 3, 6, 4} {
+		err := handler.handleMakeMove(ctx, players[i%2], &Message{Payload: map[string]interface{}{
+			"gameId": session.ID,
+			"column": float64(column),
+		}})
+		require.NoError(t, err)
+	}
+
+	mined, err := puzzles.NewPuzzleService(repositories.NewPuzzleRepository(db), gameRepo, moveRepo,
+		puzzles.DefaultServiceConfig()).MineGame(ctx, session.ID)
+	require.NoError(t, err)
+	assert.NotEmpty(t, mined, "the forced win was mined from the recorded moves")
+}
@@ -37,6 +37,9 @@ type Hub struct {
 
 	// Configuration
 	config ConnectionConfig
+
+	// Called when a connection is registered or identified under a new user ID
+	identifyCallback func(userID string)
 }
 
 // BroadcastMessage represents a message to be broadcast to a game room
@@ -90,6 +93,13 @@ func (h *Hub) Run() {
 	}
 }
 
+// SetIdentifyCallback sets a callback run whenever a user ID becomes bound to a connection
+func (h *Hub) SetIdentifyCallback(callback func(userID string)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.identifyCallback = callback
+}
+
 // RegisterConnection registers a new connection
 func (h *Hub) RegisterConnection(conn *Connection) {
 	h.register <- conn
@@ -142,6 +152,10 @@ func (h *Hub) UpdateConnectionUserID(conn *Connection, oldUserID, newUserID stri
 
 	log.Printf("Connection userID updated: %s -> %s, total_connections=%d",
 		oldUserID, newUserID, len(h.connections))
+
+	if h.identifyCallback != nil && oldUserID != newUserID {
+		go h.identifyCallback(newUserID)
+	}
 }
 
 // GetGameConnections returns all connections for a specific game
@@ -214,6 +228,10 @@ func (h *Hub) registerConnection(conn *Connection) {
 
 	log.Printf("Connection registered: user=%s, game=%s, total_connections=%d",
 		userID, gameID, len(h.connections))
+
+	if h.identifyCallback != nil {
+		go h.identifyCallback(userID)
+	}
 }
 
 // unregisterConnection handles unregistering a connection
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"connect4-multiplayer/internal/bot"
 	"connect4-multiplayer/internal/matchmaking"
 	"connect4-multiplayer/internal/websocket"
 	"connect4-multiplayer/pkg/models"
@@ -24,6 +25,16 @@ func (m *MockGameServiceIntegration) CreateSession(ctx context.Context, player1,
 	return args.Get(0).(*models.GameSession), args.Error(1)
 }
 
+func (m *MockGameServiceIntegration) CreateBotSession(ctx context.Context, player, botUsername string, difficulty bot.Difficulty) (*models.GameSession, error) {
+	args := m.Called(ctx, player, botUsername, difficulty)
+	return args.Get(0).(*models.GameSession), args.Error(1)
+}
+
+func (m *MockGameServiceIntegration) CreateHandicapBotSession(ctx context.Context, player, botUsername string, difficulty bot.Difficulty, handicap models.Handicap) (*models.GameSession, error) {
+	args := m.Called(ctx, player, botUsername, difficulty, handicap)
+	return args.Get(0).(*models.GameSession), args.Error(1)
+}
+
 func (m *MockGameServiceIntegration) GetActiveSessionByPlayer(ctx context.Context, username string) (*models.GameSession, error) {
 	args := m.Called(ctx, username)
 	if args.Get(0) == nil {
@@ -53,6 +64,11 @@ func (m *MockGameServiceIntegration) SwitchTurn(ctx context.Context, gameID stri
 	return args.Error(0)
 }
 
+func (m *MockGameServiceIntegration) RecordMove(ctx context.Context, gameID string, player models.PlayerColor, column, row int) error {
+	args := m.Called(ctx, gameID, player, column, row)
+	return args.Error(0)
+}
+
 func (m *MockGameServiceIntegration) AssignPlayerColors(ctx context.Context, gameID string) (map[string]models.PlayerColor, error) {
 	args := m.Called(ctx, gameID)
 	return args.Get(0).(map[string]models.PlayerColor), args.Error(1)
@@ -175,6 +191,7 @@ func (m *MockGameServiceIntegration) RematchCustomRoom(ctx context.Context, game
 func TestWebSocketMatchmakingIntegration(t *testing.T) {
 	// Create mock game service
 	mockGameService := new(MockGameServiceIntegration)
+	mockGameService.On("GetActiveSessions", mock.Anything).Return([]*models.GameSession{}, nil)
 
 	// Create matchmaking service
 	matchmakingConfig := &matchmaking.ServiceConfig{
@@ -208,6 +225,7 @@ func TestWebSocketMatchmakingIntegration(t *testing.T) {
 func TestWebSocketServiceLifecycle(t *testing.T) {
 	// Create mock game service
 	mockGameService := new(MockGameServiceIntegration)
+	mockGameService.On("GetActiveSessions", mock.Anything).Return([]*models.GameSession{}, nil)
 
 	// Create matchmaking service
 	matchmakingService := matchmaking.NewMatchmakingService(mockGameService, matchmaking.DefaultServiceConfig())
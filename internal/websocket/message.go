@@ -3,6 +3,10 @@ package websocket
 import (
 	"encoding/json"
 	"time"
+
+	"connect4-multiplayer/internal/bot"
+	"connect4-multiplayer/internal/stats"
+	"connect4-multiplayer/pkg/models"
 )
 
 // MessageType represents the type of WebSocket message
@@ -21,21 +25,29 @@ const (
 	MessageTypeReconnect         MessageType = "reconnect"
 	MessageTypeLeaveGame         MessageType = "leave_game"
 	MessageTypePing              MessageType = "ping"
+	MessageTypeChallengePlayer   MessageType = "challenge_player"  // Invite a specific player to a game
+	MessageTypeRespondChallenge  MessageType = "respond_challenge" // Accept or decline a challenge
 
 	// Server to Client messages
-	MessageTypeQueueJoined        MessageType = "queue_joined"         // New: Joined matchmaking queue
-	MessageTypeQueueStatus        MessageType = "queue_status"         // New: Queue status update
-	MessageTypeMatchFound         MessageType = "match_found"          // New: Match found notification
-	MessageTypeRoomCreated        MessageType = "room_created"         // New: Custom room created successfully
-	MessageTypeWaitingForOpponent MessageType = "waiting_for_opponent" // New: Waiting in custom room
-	MessageTypeGameStarted        MessageType = "game_started"
-	MessageTypeMoveMade           MessageType = "move_made"
-	MessageTypeGameEnded          MessageType = "game_ended"
-	MessageTypeGameState          MessageType = "game_state"
-	MessageTypePlayerJoined       MessageType = "player_joined"
-	MessageTypePlayerLeft         MessageType = "player_left"
-	MessageTypeError              MessageType = "error"
-	MessageTypePong               MessageType = "pong"
+	MessageTypeQueueJoined          MessageType = "queue_joined"         // New: Joined matchmaking queue
+	MessageTypeQueueStatus          MessageType = "queue_status"         // New: Queue status update
+	MessageTypeMatchFound           MessageType = "match_found"          // New: Match found notification
+	MessageTypeRoomCreated          MessageType = "room_created"         // New: Custom room created successfully
+	MessageTypeWaitingForOpponent   MessageType = "waiting_for_opponent" // New: Waiting in custom room
+	MessageTypeGameStarted          MessageType = "game_started"
+	MessageTypeMoveMade             MessageType = "move_made"
+	MessageTypeGameEnded            MessageType = "game_ended"
+	MessageTypeGameState            MessageType = "game_state"
+	MessageTypePlayerJoined         MessageType = "player_joined"
+	MessageTypePlayerLeft           MessageType = "player_left"
+	MessageTypeError                MessageType = "error"
+	MessageTypePong                 MessageType = "pong"
+	MessageTypeChallengeSent        MessageType = "challenge_sent"
+	MessageTypeChallengeReceived    MessageType = "challenge_received"
+	MessageTypeChallengeDeclined    MessageType = "challenge_declined"
+	MessageTypeChallengeExpired     MessageType = "challenge_expired"
+	MessageTypeNotification         MessageType = "notification"          // New inbox notification
+	MessageTypeNotificationsPending MessageType = "notifications_pending" // Unread inbox summary sent on login
 )
 
 // Message represents a WebSocket message
@@ -68,7 +80,15 @@ func FromJSON(data []byte) (*Message, error) {
 
 // JoinQueuePayload represents the payload for joining matchmaking queue
 type JoinQueuePayload struct {
-	Username string `json:"username"`
+	Username      string `json:"username"`
+	BotDifficulty string `json:"botDifficulty,omitempty"` // Difficulty of the bot fallback: easy, medium, hard, impossible or adaptive
+}
+
+// PlayWithBotPayload represents the payload for starting a bot game
+type PlayWithBotPayload struct {
+	Username   string `json:"username"`
+	Difficulty string `json:"difficulty,omitempty"` // easy, medium, hard, impossible or adaptive; defaults to medium
+	Training   bool   `json:"training,omitempty"`   // explain every bot move in move_made
 }
 
 // QueueJoinedPayload represents the payload when successfully joined queue
@@ -140,6 +160,19 @@ type ReconnectPayload struct {
 	Username string `json:"username"`
 }
 
+// ChallengePlayerPayload represents the payload for challenging a player
+type ChallengePlayerPayload struct {
+	Username string `json:"username"`
+	Target   string `json:"target"`
+}
+
+// RespondChallengePayload represents the payload for answering a challenge
+type RespondChallengePayload struct {
+	Username    string `json:"username"`
+	ChallengeID string `json:"challengeId"`
+	Accept      bool   `json:"accept"`
+}
+
 // GameStartedPayload represents the payload when a game starts
 type GameStartedPayload struct {
 	GameID      string `json:"gameId"`
@@ -147,6 +180,8 @@ type GameStartedPayload struct {
 	YourColor   string `json:"yourColor"`
 	CurrentTurn string `json:"currentTurn"`
 	IsBot       bool   `json:"isBot,omitempty"`
+	// HeadToHead is the player's record against this opponent, omitted for bot games
+	HeadToHead *stats.HeadToHeadSummary `json:"headToHead,omitempty"`
 }
 
 // MoveMadePayload represents the payload when a move is made
@@ -158,6 +193,8 @@ type MoveMadePayload struct {
 	Board     interface{} `json:"board"`
 	NextTurn  string      `json:"nextTurn"`
 	MoveCount int         `json:"moveCount"`
+	// Explanation says why the bot played the move, for players in training mode
+	Explanation *bot.MoveExplanation `json:"explanation,omitempty"`
 }
 
 // GameEndedPayload represents the payload when a game ends
@@ -211,6 +248,14 @@ func CreateJoinQueueMessage(username string) *Message {
 	})
 }
 
+// CreatePlayWithBotMessage creates a play with bot message
+func CreatePlayWithBotMessage(username, difficulty string) *Message {
+	return NewMessage(MessageTypePlayWithBot, map[string]interface{}{
+		"username":   username,
+		"difficulty": difficulty,
+	})
+}
+
 // CreateLeaveQueueMessage creates a leave queue message
 func CreateLeaveQueueMessage() *Message {
 	return NewMessage(MessageTypeLeaveQueue, map[string]interface{}{})
@@ -358,6 +403,78 @@ func CreateErrorMessage(code, message, details string) *Message {
 	})
 }
 
+// CreateChallengePlayerMessage creates a challenge player message
+func CreateChallengePlayerMessage(username, target string) *Message {
+	return NewMessage(MessageTypeChallengePlayer, map[string]interface{}{
+		"username": username,
+		"target":   target,
+	})
+}
+
+// CreateRespondChallengeMessage creates a challenge response message
+func CreateRespondChallengeMessage(username, challengeID string, accept bool) *Message {
+	return NewMessage(MessageTypeRespondChallenge, map[string]interface{}{
+		"username":    username,
+		"challengeId": challengeID,
+		"accept":      accept,
+	})
+}
+
+// CreateChallengeSentMessage confirms a challenge to the challenger
+func CreateChallengeSentMessage(challengeID, target string, expiresAt time.Time) *Message {
+	return NewMessage(MessageTypeChallengeSent, map[string]interface{}{
+		"challengeId": challengeID,
+		"target":      target,
+		"expiresAt":   expiresAt,
+	})
+}
+
+// CreateChallengeReceivedMessage delivers a challenge to its target
+func CreateChallengeReceivedMessage(challengeID, challenger string, expiresAt time.Time) *Message {
+	return NewMessage(MessageTypeChallengeReceived, map[string]interface{}{
+		"challengeId": challengeID,
+		"challenger":  challenger,
+		"expiresAt":   expiresAt,
+	})
+}
+
+// CreateChallengeDeclinedMessage tells the challenger their challenge was declined
+func CreateChallengeDeclinedMessage(challengeID, target string) *Message {
+	return NewMessage(MessageTypeChallengeDeclined, map[string]interface{}{
+		"challengeId": challengeID,
+		"target":      target,
+	})
+}
+
+// CreateChallengeExpiredMessage tells both players a challenge timed out
+func CreateChallengeExpiredMessage(challengeID, challenger, target string) *Message {
+	return NewMessage(MessageTypeChallengeExpired, map[string]interface{}{
+		"challengeId": challengeID,
+		"challenger":  challenger,
+		"target":      target,
+	})
+}
+
+// CreateNotificationMessage pushes a new inbox notification to its player
+func CreateNotificationMessage(notification *models.Notification) *Message {
+	return NewMessage(MessageTypeNotification, map[string]interface{}{
+		"id":        notification.ID,
+		"type":      notification.Type,
+		"title":     notification.Title,
+		"body":      notification.Body,
+		"data":      notification.Data,
+		"createdAt": notification.CreatedAt,
+	})
+}
+
+// CreateNotificationsPendingMessage summarises unread notifications for a player who just logged in
+func CreateNotificationsPendingMessage(unreadCount int64, notifications []*models.Notification) *Message {
+	return NewMessage(MessageTypeNotificationsPending, map[string]interface{}{
+		"unreadCount":   unreadCount,
+		"notifications": notifications,
+	})
+}
+
 // CreatePongMessage creates a pong message
 func CreatePongMessage() *Message {
 	return NewMessage(MessageTypePong, map[string]interface{}{})
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"connect4-multiplayer/internal/bot"
 	"connect4-multiplayer/internal/matchmaking"
 	"connect4-multiplayer/pkg/models"
 )
@@ -16,10 +17,15 @@ import (
 type MockMatchmakingService struct{}
 
 func (m *MockMatchmakingService) JoinQueue(ctx context.Context, username string) (*matchmaking.QueueEntry, error) {
+	return m.JoinQueueWithPreferences(ctx, username, matchmaking.QueuePreferences{})
+}
+
+func (m *MockMatchmakingService) JoinQueueWithPreferences(ctx context.Context, username string, preferences matchmaking.QueuePreferences) (*matchmaking.QueueEntry, error) {
 	return &matchmaking.QueueEntry{
-		Username: username,
-		JoinedAt: time.Now(),
-		Timeout:  time.Now().Add(10 * time.Second),
+		Username:      username,
+		JoinedAt:      time.Now(),
+		Timeout:       time.Now().Add(10 * time.Second),
+		BotDifficulty: preferences.BotDifficulty,
 	}, nil
 }
 
@@ -53,15 +59,16 @@ func (m *MockMatchmakingService) SetGameCreatedCallback(callback matchmaking.Gam
 func (m *MockMatchmakingService) SetBotGameCallback(callback matchmaking.BotGameCallback) {
 }
 
-func (m *MockMatchmakingService) CreateBotGame(ctx context.Context, player string) (*models.GameSession, error) {
+func (m *MockMatchmakingService) CreateBotGame(ctx context.Context, player string, difficulty bot.Difficulty) (*models.GameSession, error) {
 	return &models.GameSession{
-		ID:          "bot-game-" + player,
-		Player1:     player,
-		Player2:     "bot_12345",
-		Board:       models.NewBoard(),
-		CurrentTurn: models.PlayerColorRed,
-		Status:      models.StatusInProgress,
-		StartTime:   time.Now(),
+		ID:            "bot-game-" + player,
+		BotDifficulty: difficulty.String(),
+		Player1:       player,
+		Player2:       "bot_12345",
+		Board:         models.NewBoard(),
+		CurrentTurn:   models.PlayerColorRed,
+		Status:        models.StatusInProgress,
+		StartTime:     time.Now(),
 	}, nil
 }
 
@@ -95,6 +102,32 @@ func (m *MockGameService) CreateSession(ctx context.Context, player1, player2 st
 	return session, nil
 }
 
+func (m *MockGameService) CreateBotSession(ctx context.Context, player, botUsername string, difficulty bot.Difficulty) (*models.GameSession, error) {
+	session, err := m.CreateSession(ctx, player, botUsername)
+	if err != nil {
+		return nil, err
+	}
+	session.BotDifficulty = difficulty.String()
+	return session, nil
+}
+
+func (m *MockGameService) CreateHandicapBotSession(ctx context.Context, player, botUsername string, difficulty bot.Difficulty, handicap models.Handicap) (*models.GameSession, error) {
+	if err := handicap.Validate(); err != nil {
+		return nil, err
+	}
+	session, err := m.CreateBotSession(ctx, player, botUsername, difficulty)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	session.Handicap = &handicap
+	session.CurrentTurn = handicap.FirstTurn()
+	session.Board, err = session.InitialBoard()
+	return session, err
+}
+
 func (m *MockGameService) GetSession(ctx context.Context, gameID string) (*models.GameSession, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -136,6 +169,10 @@ func (m *MockGameService) SwitchTurn(ctx context.Context, gameID string) error {
 	return nil
 }
 
+func (m *MockGameService) RecordMove(ctx context.Context, gameID string, player models.PlayerColor, column, row int) error {
+	return nil
+}
+
 func (m *MockGameService) AssignPlayerColors(ctx context.Context, gameID string) (map[string]models.PlayerColor, error) {
 	session, err := m.GetSession(ctx, gameID)
 	if err != nil {
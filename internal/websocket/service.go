@@ -4,9 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"connect4-multiplayer/internal/game"
 	"connect4-multiplayer/internal/matchmaking"
+	"connect4-multiplayer/internal/notifications"
+	"connect4-multiplayer/internal/social"
+	"connect4-multiplayer/internal/stats"
+	"connect4-multiplayer/pkg/models"
 )
 
 // Service represents the WebSocket service
@@ -33,6 +38,8 @@ func NewService(gameService game.GameService, matchmakingService matchmaking.Mat
 	
 	// Create WebSocket handler
 	wsHandler := NewWebSocketHandler(hub, config)
+	wsHandler.botScheduler = messageHandler.botScheduler
+	wsHandler.botService = messageHandler.botService
 	
 	return &Service{
 		hub:                hub,
@@ -43,6 +50,76 @@ func NewService(gameService game.GameService, matchmakingService matchmaking.Mat
 	}
 }
 
+// SetHeadToHeadService enables head-to-head summaries in game started messages
+func (s *Service) SetHeadToHeadService(headToHead stats.HeadToHeadService) {
+	s.messageHandler.headToHead = headToHead
+}
+
+// SetBotStrengthService lets adaptive bots match the strength of the
+// players they face
+func (s *Service) SetBotStrengthService(botStrength stats.BotStrengthService) {
+	s.messageHandler.botStrength = botStrength
+}
+
+// SetChallengeService enables direct challenges between connected players
+func (s *Service) SetChallengeService(challengeService social.ChallengeService) {
+	s.messageHandler.setChallengeService(challengeService)
+}
+
+// SetBotAccounts lets bot accounts connect through the bot API and keeps
+// human connections off their usernames
+func (s *Service) SetBotAccounts(botAccounts BotAccounts) {
+	s.messageHandler.botAccounts = botAccounts
+	s.wsHandler.botAccounts = botAccounts
+}
+
+// SetBotSchedulerConfig sizes the worker pool that plays bot turns. It must
+// be called before Start.
+func (s *Service) SetBotSchedulerConfig(config *BotSchedulerConfig) {
+	scheduler := newBotScheduler(s.messageHandler.makeBotMove, config)
+	s.messageHandler.botScheduler = scheduler
+	s.wsHandler.botScheduler = scheduler
+}
+
+// SetNotificationService sends players a summary of unread notifications
+// whenever they connect or identify themselves
+func (s *Service) SetNotificationService(notificationService notifications.NotificationService) {
+	s.hub.SetIdentifyCallback(func(userID string) {
+		s.sendPendingNotifications(notificationService, userID)
+	})
+}
+
+// PublishNotification pushes a new notification to a connected player
+func (s *Service) PublishNotification(username string, notification *models.Notification) error {
+	data, err := CreateNotificationMessage(notification).ToJSON()
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+	return s.SendMessageToUser(username, data)
+}
+
+// sendPendingNotifications tells a player what they missed while offline
+func (s *Service) sendPendingNotifications(notificationService notifications.NotificationService, userID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	inbox, err := notificationService.GetInbox(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to load notifications for %s: %v", userID, err)
+		return
+	}
+	if inbox.UnreadCount == 0 {
+		return
+	}
+
+	data, err := CreateNotificationsPendingMessage(inbox.UnreadCount, inbox.Notifications).ToJSON()
+	if err != nil {
+		log.Printf("Failed to encode pending notifications for %s: %v", userID, err)
+		return
+	}
+	_ = s.SendMessageToUser(userID, data)
+}
+
 // Start starts the WebSocket service
 func (s *Service) Start(ctx context.Context) error {
 	log.Println("Starting WebSocket service...")
@@ -54,6 +131,12 @@ func (s *Service) Start(ctx context.Context) error {
 	
 	// Start the hub in a goroutine
 	go s.hub.Run()
+
+	// Play bot turns, including any left pending by the last shutdown
+	s.messageHandler.botScheduler.Start(ctx)
+	if err := s.messageHandler.recoverBotTurns(ctx); err != nil {
+		log.Printf("Failed to recover pending bot turns: %v", err)
+	}
 	
 	log.Println("WebSocket service started successfully")
 	return nil
@@ -65,6 +148,8 @@ func (s *Service) Stop() error {
 	
 	// Stop matchmaking service
 	s.matchmakingService.StopMatchmaking()
+
+	s.messageHandler.botScheduler.Stop()
 	
 	s.hub.Shutdown()
 	
@@ -87,6 +172,11 @@ func (s *Service) GetConnectionCount() int {
 	return s.hub.GetConnectionCount()
 }
 
+// BotSchedulerStats returns the bot scheduler's queue and latency metrics
+func (s *Service) BotSchedulerStats() BotSchedulerStats {
+	return s.messageHandler.botScheduler.Stats()
+}
+
 // GetActiveGames returns a list of active game IDs
 func (s *Service) GetActiveGames() []string {
 	return s.hub.GetActiveGames()
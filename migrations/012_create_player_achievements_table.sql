@@ -0,0 +1,13 @@
+-- Create player_achievements table for unlocked badges
+CREATE TABLE IF NOT EXISTS player_achievements (
+    id VARCHAR(255) PRIMARY KEY,
+    username VARCHAR(255) NOT NULL,
+    achievement_id VARCHAR(50) NOT NULL,
+    game_id VARCHAR(255),
+    unlocked_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Each achievement can only be unlocked once per player
+CREATE UNIQUE INDEX IF NOT EXISTS idx_player_achievements_username_achievement ON player_achievements(username, achievement_id);
+CREATE INDEX IF NOT EXISTS idx_player_achievements_game_id ON player_achievements(game_id);
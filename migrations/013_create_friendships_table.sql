@@ -0,0 +1,17 @@
+-- Create friendships table for the social graph
+CREATE TABLE IF NOT EXISTS friendships (
+    id VARCHAR(255) PRIMARY KEY,
+    requester VARCHAR(255) NOT NULL,
+    addressee VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT friendships_not_self CHECK (requester <> addressee)
+);
+
+-- One relationship per pair of players, whoever asked; lookups by either side
+CREATE UNIQUE INDEX IF NOT EXISTS idx_friendships_pair ON friendships(LEAST(requester, addressee), GREATEST(requester, addressee));
+CREATE INDEX IF NOT EXISTS idx_friendships_requester ON friendships(requester);
+CREATE INDEX IF NOT EXISTS idx_friendships_addressee ON friendships(addressee);
+CREATE INDEX IF NOT EXISTS idx_friendships_status ON friendships(status);
@@ -0,0 +1,12 @@
+-- Create player_blocks table for per-player block lists
+CREATE TABLE IF NOT EXISTS player_blocks (
+    id VARCHAR(255) PRIMARY KEY,
+    blocker VARCHAR(255) NOT NULL,
+    blocked VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT player_blocks_not_self CHECK (blocker <> blocked)
+);
+
+-- One block per ordered pair; reverse lookups for either-direction checks
+CREATE UNIQUE INDEX IF NOT EXISTS idx_player_blocks_pair ON player_blocks(blocker, blocked);
+CREATE INDEX IF NOT EXISTS idx_player_blocks_blocked ON player_blocks(blocked);
@@ -0,0 +1,15 @@
+-- Create notifications table for the player inbox
+CREATE TABLE IF NOT EXISTS notifications (
+    id VARCHAR(255) PRIMARY KEY,
+    username VARCHAR(255) NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    body TEXT,
+    data JSONB DEFAULT '{}',
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Inbox listing is newest first per player; unread lookups filter on read_at
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created ON notifications(username, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications(username) WHERE read_at IS NULL;
@@ -0,0 +1,3 @@
+-- Persist the chosen bot difficulty so bot games keep their strength across reconnects and restarts
+ALTER TABLE game_sessions
+ADD COLUMN IF NOT EXISTS bot_difficulty VARCHAR(20);
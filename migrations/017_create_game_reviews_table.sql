@@ -0,0 +1,14 @@
+-- Create game_reviews table for post-game engine annotations
+CREATE TABLE IF NOT EXISTS game_reviews (
+    game_id VARCHAR(255) PRIMARY KEY,
+    status VARCHAR(20) NOT NULL,
+    red_accuracy DOUBLE PRECISION DEFAULT 0,
+    yellow_accuracy DOUBLE PRECISION DEFAULT 0,
+    moves JSONB DEFAULT '[]',
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Pending reviews are resumed oldest first after a restart
+CREATE INDEX IF NOT EXISTS idx_game_reviews_pending ON game_reviews(created_at) WHERE status = 'pending';
@@ -0,0 +1,35 @@
+-- Create puzzles table for forced wins mined from completed games
+CREATE TABLE IF NOT EXISTS puzzles (
+    id VARCHAR(255) PRIMARY KEY,
+    game_id VARCHAR(255) NOT NULL,
+    ply INTEGER NOT NULL,
+    moves JSONB DEFAULT '[]',
+    player VARCHAR(10) NOT NULL,
+    win_in INTEGER NOT NULL,
+    solutions JSONB DEFAULT '[]',
+    missed BOOLEAN DEFAULT FALSE,
+    rating DOUBLE PRECISION NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    solves INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- A game position becomes at most one puzzle
+CREATE UNIQUE INDEX IF NOT EXISTS idx_puzzles_game_ply ON puzzles(game_id, ply);
+-- Puzzles are picked by closeness to the player's rating
+CREATE INDEX IF NOT EXISTS idx_puzzles_rating ON puzzles(rating);
+
+-- Create puzzle_attempts table for each player's rated try at a puzzle
+CREATE TABLE IF NOT EXISTS puzzle_attempts (
+    id VARCHAR(255) PRIMARY KEY,
+    username VARCHAR(255) NOT NULL,
+    puzzle_id VARCHAR(255) NOT NULL REFERENCES puzzles(id) ON DELETE CASCADE,
+    "column" INTEGER NOT NULL,
+    solved BOOLEAN NOT NULL,
+    player_rating DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_puzzle_attempts_user_puzzle ON puzzle_attempts(username, puzzle_id);
+-- The latest attempt carries the player's current rating
+CREATE INDEX IF NOT EXISTS idx_puzzle_attempts_user_created ON puzzle_attempts(username, created_at DESC);
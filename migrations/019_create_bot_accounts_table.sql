@@ -0,0 +1,24 @@
+-- Flag players and stats that belong to bot accounts, which have their own leaderboard
+ALTER TABLE players
+ADD COLUMN IF NOT EXISTS is_bot BOOLEAN DEFAULT FALSE;
+
+ALTER TABLE player_stats
+ADD COLUMN IF NOT EXISTS is_bot BOOLEAN DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_players_is_bot ON players(is_bot);
+CREATE INDEX IF NOT EXISTS idx_player_stats_is_bot ON player_stats(is_bot);
+
+-- Create bot_accounts table for programs that play over the bot API
+CREATE TABLE IF NOT EXISTS bot_accounts (
+    id VARCHAR(255) PRIMARY KEY,
+    username VARCHAR(255) NOT NULL,
+    owner_id VARCHAR(255) NOT NULL,
+    description TEXT,
+    token_hash VARCHAR(64) NOT NULL,
+    last_seen_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bot_accounts_username ON bot_accounts(username);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bot_accounts_token_hash ON bot_accounts(token_hash);
+CREATE INDEX IF NOT EXISTS idx_bot_accounts_owner_id ON bot_accounts(owner_id);
@@ -0,0 +1,3 @@
+-- Give the weaker player a head start; NULL for even games
+ALTER TABLE game_sessions
+ADD COLUMN IF NOT EXISTS handicap JSONB;
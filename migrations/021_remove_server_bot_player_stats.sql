@@ -0,0 +1,6 @@
+-- Bots played by the server no longer keep stats. Remove the rows written
+-- before, which were ranked on the human leaderboard. Bot accounts cannot
+-- take these usernames and keep their stats.
+DELETE FROM player_stats
+WHERE is_bot = FALSE
+  AND (username LIKE 'Bot\_%' OR username LIKE 'bot\_%' OR username = 'Bot');
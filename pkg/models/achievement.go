@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PlayerAchievement records a badge unlocked by a player
+type PlayerAchievement struct {
+	ID            string    `json:"id" gorm:"primaryKey"`
+	Username      string    `json:"username" gorm:"not null;uniqueIndex:idx_player_achievements_username_achievement" validate:"required,min=3,max=20"`
+	AchievementID string    `json:"achievementId" gorm:"type:varchar(50);not null;uniqueIndex:idx_player_achievements_username_achievement" validate:"required"`
+	GameID        string    `json:"gameId,omitempty" gorm:"index"`
+	UnlockedAt    time.Time `json:"unlockedAt" gorm:"autoCreateTime"`
+	CreatedAt     time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for GORM
+func (PlayerAchievement) TableName() string {
+	return "player_achievements"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a player achievement
+func (pa *PlayerAchievement) BeforeCreate(tx *gorm.DB) error {
+	if pa.ID == "" {
+		pa.ID = generateUUID()
+	}
+	return nil
+}
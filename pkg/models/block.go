@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PlayerBlock records that one player has blocked another. Blocks are
+// one-directional but are enforced in both directions.
+type PlayerBlock struct {
+	ID        string    `json:"id" gorm:"primaryKey"`
+	Blocker   string    `json:"blocker" gorm:"not null;uniqueIndex:idx_player_blocks_pair" validate:"required,min=3,max=20"`
+	Blocked   string    `json:"blocked" gorm:"not null;uniqueIndex:idx_player_blocks_pair;index" validate:"required,min=3,max=20"`
+	CreatedAt time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for GORM
+func (PlayerBlock) TableName() string {
+	return "player_blocks"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a player block
+func (pb *PlayerBlock) BeforeCreate(tx *gorm.DB) error {
+	if pb.ID == "" {
+		pb.ID = generateUUID()
+	}
+	return nil
+}
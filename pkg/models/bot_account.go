@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BotAccount is a player account played by a developer's program over the
+// bot API. The account's player and stats rows are flagged IsBot.
+type BotAccount struct {
+	ID          string `json:"id" gorm:"primaryKey"`
+	Username    string `json:"username" gorm:"uniqueIndex;not null"`
+	OwnerID     string `json:"ownerId" gorm:"index;not null"` // auth user ID of the developer
+	Description string `json:"description"`
+	// TokenHash is the SHA-256 of the account's API token, which is only
+	// shown when created or rotated
+	TokenHash  string     `json:"-" gorm:"uniqueIndex;not null"`
+	LastSeenAt *time.Time `json:"lastSeenAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for GORM
+func (BotAccount) TableName() string {
+	return "bot_accounts"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a bot account
+func (b *BotAccount) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == "" {
+		b.ID = generateUUID()
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ var (
 	ErrGameEnded        = errors.New("game has ended")
 	ErrInvalidBoardData = errors.New("invalid board data")
 	ErrInvalidEventData = errors.New("invalid event data")
+	ErrInvalidHandicap  = errors.New("invalid handicap")
 	ErrDuplicateUsername = errors.New("username already exists in active session")
 )
 
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FriendshipStatus represents the state of a friendship
+type FriendshipStatus string
+
+const (
+	FriendshipPending  FriendshipStatus = "pending"
+	FriendshipAccepted FriendshipStatus = "accepted"
+)
+
+// Friendship links two players. The requester sends the request and the
+// addressee accepts it; once accepted the relationship is symmetric.
+// At most one friendship links two players, whichever of them asked.
+type Friendship struct {
+	ID         string           `json:"id" gorm:"primaryKey"`
+	Requester  string           `json:"requester" gorm:"not null;index" validate:"required,min=3,max=20"`
+	Addressee  string           `json:"addressee" gorm:"not null;index" validate:"required,min=3,max=20"`
+	Status     FriendshipStatus `json:"status" gorm:"type:varchar(20);not null;index" validate:"required"`
+	AcceptedAt *time.Time       `json:"acceptedAt,omitempty"`
+	CreatedAt  time.Time        `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time        `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name for GORM
+func (Friendship) TableName() string {
+	return "friendships"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a friendship
+func (f *Friendship) BeforeCreate(tx *gorm.DB) error {
+	if f.ID == "" {
+		f.ID = generateUUID()
+	}
+	if f.Status == "" {
+		f.Status = FriendshipPending
+	}
+	return nil
+}
+
+// Other returns the other side of the friendship for a given player
+func (f *Friendship) Other(username string) string {
+	if f.Requester == username {
+		return f.Addressee
+	}
+	return f.Requester
+}
+
+// Involves reports whether a player is part of the friendship
+func (f *Friendship) Involves(username string) bool {
+	return f.Requester == username || f.Addressee == username
+}
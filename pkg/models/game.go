@@ -36,6 +36,10 @@ type GameSession struct {
 	RoomCode  *string `json:"roomCode,omitempty" gorm:"type:varchar(8);uniqueIndex:idx_game_sessions_room_code,where:room_code IS NOT NULL"`
 	IsCustom  bool    `json:"isCustom" gorm:"default:false;not null;index:idx_game_sessions_is_custom,where:is_custom = true"`
 	CreatedBy *string `json:"createdBy,omitempty" gorm:"type:varchar(255)"`
+	// Bot games: difficulty chosen by the human player ("easy", "medium", "hard")
+	BotDifficulty string `json:"botDifficulty,omitempty" gorm:"type:varchar(20)"`
+	// Handicap games give the weaker player a head start; nil for even games
+	Handicap *Handicap `json:"handicap,omitempty" gorm:"type:jsonb"`
 }
 
 // TableName returns the table name for GORM
@@ -54,11 +58,28 @@ func (gs *GameSession) BeforeCreate(tx *gorm.DB) error {
 	if gs.CurrentTurn == "" {
 		gs.CurrentTurn = PlayerColorRed
 	}
-	// Initialize empty board
-	gs.Board = NewBoard()
+	// Initialize the board, with any pre-placed handicap discs
+	board, err := gs.InitialBoard()
+	if err != nil {
+		return err
+	}
+	gs.Board = board
 	return nil
 }
 
+// InitialBoard returns the board before the first move: empty, apart from
+// any discs pre-placed by the handicap. Replays of the move history start
+// from it.
+func (gs *GameSession) InitialBoard() (Board, error) {
+	board := NewBoard()
+	if gs.Handicap != nil {
+		if err := gs.Handicap.Apply(&board); err != nil {
+			return board, err
+		}
+	}
+	return board, nil
+}
+
 // IsActive returns true if the game is currently active
 func (gs *GameSession) IsActive() bool {
 	return gs.Status == StatusInProgress
@@ -174,6 +195,15 @@ func (b *Board) CheckWin() *PlayerColor {
 	return nil
 }
 
+// DiscCount returns the number of discs on the board
+func (b *Board) DiscCount() int {
+	count := 0
+	for col := 0; col < 7; col++ {
+		count += b.Height[col]
+	}
+	return count
+}
+
 // IsFull checks if the board is full (draw condition)
 func (b *Board) IsFull() bool {
 	for col := 0; col < 7; col++ {
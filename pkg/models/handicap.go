@@ -0,0 +1,104 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// MaxHandicapDiscs is the most discs a handicap can pre-place. Three discs
+// can never make four in a row, so the game always starts undecided.
+const MaxHandicapDiscs = 3
+
+// Handicap gives the weaker player of a game a head start. Any combination
+// of pre-placed discs, the first move and an edge-only opening for the
+// stronger player may be set.
+type Handicap struct {
+	// Player is the color of the weaker player, who receives the handicap
+	Player PlayerColor `json:"player"`
+	// Discs are the columns where the weaker player starts with a disc,
+	// dropped in order before the first move
+	Discs []int `json:"discs,omitempty"`
+	// MovesFirst lets the weaker player make the first move
+	MovesFirst bool `json:"movesFirst,omitempty"`
+	// EdgeOpening restricts the stronger player's first move to the edge
+	// columns
+	EdgeOpening bool `json:"edgeOpening,omitempty"`
+}
+
+// Validate checks that the handicap names a player and gives them something
+func (h *Handicap) Validate() error {
+	if h.Player != PlayerColorRed && h.Player != PlayerColorYellow {
+		return fmt.Errorf("%w: unknown player %q", ErrInvalidHandicap, h.Player)
+	}
+	if len(h.Discs) == 0 && !h.MovesFirst && !h.EdgeOpening {
+		return fmt.Errorf("%w: no handicap is set", ErrInvalidHandicap)
+	}
+	if len(h.Discs) > MaxHandicapDiscs {
+		return fmt.Errorf("%w: at most %d discs can be pre-placed", ErrInvalidHandicap, MaxHandicapDiscs)
+	}
+	for _, column := range h.Discs {
+		if column < 0 || column >= 7 {
+			return fmt.Errorf("%w: invalid column %d (must be 0-6)", ErrInvalidHandicap, column)
+		}
+	}
+	return nil
+}
+
+// Apply drops the pre-placed discs on board
+func (h *Handicap) Apply(board *Board) error {
+	for _, column := range h.Discs {
+		if err := board.MakeMove(column, h.Player); err != nil {
+			return fmt.Errorf("%w: column %d is full", ErrInvalidHandicap, column)
+		}
+	}
+	return nil
+}
+
+// FirstTurn returns the color that makes the first move. Red moves first
+// unless the weaker player is given the first move.
+func (h *Handicap) FirstTurn() PlayerColor {
+	if h.MovesFirst {
+		return h.Player
+	}
+	return PlayerColorRed
+}
+
+// IsEdgeColumn reports whether column is at either side of the board
+func IsEdgeColumn(column int) bool {
+	return column == 0 || column == 6
+}
+
+// CheckMove returns an error if the handicap forbids player dropping a disc
+// in column on board. Pre-placed discs belong to the weaker player, so the
+// stronger player's first move is the one made while they have no discs.
+func (h *Handicap) CheckMove(board *Board, player PlayerColor, column int) error {
+	if !h.EdgeOpening || player == h.Player || IsEdgeColumn(column) {
+		return nil
+	}
+	for _, row := range board.Grid {
+		for _, cell := range row {
+			if cell == player {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%w: the first move of %s must be in an edge column", ErrInvalidMove, player)
+}
+
+// Scan implements the sql.Scanner interface for GORM
+func (h *Handicap) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, h)
+	case string:
+		return json.Unmarshal([]byte(v), h)
+	default:
+		return ErrInvalidHandicap
+	}
+}
+
+// Value implements the driver.Valuer interface for GORM
+func (h Handicap) Value() (driver.Value, error) {
+	return json.Marshal(h)
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NotificationType represents the kind of notification
+type NotificationType string
+
+const (
+	NotificationChallenge          NotificationType = "challenge"
+	NotificationFriendRequest      NotificationType = "friend_request"
+	NotificationAchievement        NotificationType = "achievement"
+	NotificationTournamentRound    NotificationType = "tournament_round"
+	NotificationCorrespondenceTurn NotificationType = "correspondence_turn"
+)
+
+// NotificationData carries type specific details such as a challenge or game ID
+type NotificationData map[string]interface{}
+
+// Scan implements the sql.Scanner interface for GORM
+func (nd *NotificationData) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*nd = make(NotificationData)
+		return nil
+	case []byte:
+		return json.Unmarshal(v, nd)
+	case string:
+		return json.Unmarshal([]byte(v), nd)
+	default:
+		return ErrInvalidEventData
+	}
+}
+
+// Value implements the driver.Valuer interface for GORM
+func (nd NotificationData) Value() (driver.Value, error) {
+	if nd == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(nd)
+}
+
+// Notification is an inbox entry for a player. Notifications are stored so
+// players who were offline see them on their next login.
+type Notification struct {
+	ID        string           `json:"id" gorm:"primaryKey"`
+	Username  string           `json:"username" gorm:"not null;index:idx_notifications_user_created" validate:"required,min=3,max=20"`
+	Type      NotificationType `json:"type" gorm:"type:varchar(50);not null" validate:"required"`
+	Title     string           `json:"title" gorm:"not null"`
+	Body      string           `json:"body"`
+	Data      NotificationData `json:"data,omitempty" gorm:"type:jsonb"`
+	ReadAt    *time.Time       `json:"readAt,omitempty" gorm:"index"`
+	CreatedAt time.Time        `json:"createdAt" gorm:"autoCreateTime;index:idx_notifications_user_created"`
+}
+
+// TableName returns the table name for GORM
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a notification
+func (n *Notification) BeforeCreate(tx *gorm.DB) error {
+	if n.ID == "" {
+		n.ID = generateUUID()
+	}
+	return nil
+}
+
+// IsRead reports whether the player has read the notification
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
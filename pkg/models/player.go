@@ -12,6 +12,7 @@ type Player struct {
 	Username   string    `json:"username" gorm:"uniqueIndex" validate:"required,min=3,max=20"`
 	AuthUserID *string   `json:"auth_user_id,omitempty" gorm:"index"`
 	IsGuest    bool      `json:"is_guest" gorm:"default:true"`
+	IsBot      bool      `json:"is_bot" gorm:"default:false;index"` // a bot account played by a program
 	CreatedAt  time.Time `json:"createdAt" gorm:"autoCreateTime"`
 	UpdatedAt  time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
 }
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Columns is an ordered list of board columns
+type Columns []int
+
+// Scan implements the sql.Scanner interface for GORM
+func (c *Columns) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	default:
+		return ErrInvalidEventData
+	}
+}
+
+// Value implements the driver.Valuer interface for GORM
+func (c Columns) Value() (driver.Value, error) {
+	if c == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(c)
+}
+
+// Puzzle is a position from a completed game where the player to move
+// wins by force. Its rating is the puzzle's difficulty on the same scale as
+// player puzzle ratings and moves as players solve or fail it.
+type Puzzle struct {
+	ID     string `json:"id" gorm:"primaryKey"`
+	GameID string `json:"gameId" gorm:"not null;uniqueIndex:idx_puzzles_game_ply"`
+	// Ply is the number of moves played before the puzzle position
+	Ply int `json:"ply" gorm:"not null;uniqueIndex:idx_puzzles_game_ply"`
+	// Moves replays the position from the empty board, red first
+	Moves  Columns     `json:"moves" gorm:"type:jsonb"`
+	Player PlayerColor `json:"player" gorm:"type:varchar(10);not null"` // side to move and win
+	WinIn  int         `json:"winIn" gorm:"not null"`                   // moves needed, counting the winning one
+	// Solutions are the columns that win in WinIn moves; they are only
+	// revealed after an attempt
+	Solutions Columns   `json:"-" gorm:"type:jsonb"`
+	Missed    bool      `json:"missed"` // the player in the game did not find the win
+	Rating    float64   `json:"rating" gorm:"not null;index"`
+	Attempts  int       `json:"attempts" gorm:"not null;default:0"`
+	Solves    int       `json:"solves" gorm:"not null;default:0"`
+	CreatedAt time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for GORM
+func (Puzzle) TableName() string {
+	return "puzzles"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a puzzle
+func (p *Puzzle) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == "" {
+		p.ID = generateUUID()
+	}
+	return nil
+}
+
+// IsSolution reports whether playing col solves the puzzle
+func (p *Puzzle) IsSolution(col int) bool {
+	for _, solution := range p.Solutions {
+		if solution == col {
+			return true
+		}
+	}
+	return false
+}
+
+// PuzzleAttempt is a player's first try at a puzzle. Only the first try
+// is rated, so players see each puzzle once.
+type PuzzleAttempt struct {
+	ID       string `json:"id" gorm:"primaryKey"`
+	Username string `json:"username" gorm:"not null;uniqueIndex:idx_puzzle_attempts_user_puzzle" validate:"required,min=3,max=20"`
+	PuzzleID string `json:"puzzleId" gorm:"not null;uniqueIndex:idx_puzzle_attempts_user_puzzle"`
+	Column   int    `json:"column"`
+	Solved   bool   `json:"solved"`
+	// PlayerRating is the player's puzzle rating after this attempt
+	PlayerRating float64   `json:"playerRating"`
+	CreatedAt    time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for GORM
+func (PuzzleAttempt) TableName() string {
+	return "puzzle_attempts"
+}
+
+// BeforeCreate is a GORM hook that runs before creating a puzzle attempt
+func (a *PuzzleAttempt) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = generateUUID()
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+)
+
+// ReviewStatus is the state of a post-game review
+type ReviewStatus string
+
+const (
+	ReviewPending   ReviewStatus = "pending"
+	ReviewCompleted ReviewStatus = "completed"
+	ReviewFailed    ReviewStatus = "failed"
+)
+
+// MoveClassification grades a move by how much it lost against the best move
+type MoveClassification string
+
+const (
+	MoveBest       MoveClassification = "best"
+	MoveGood       MoveClassification = "good"
+	MoveInaccuracy MoveClassification = "inaccuracy"
+	MoveMistake    MoveClassification = "mistake"
+	MoveBlunder    MoveClassification = "blunder"
+)
+
+// MoveAnnotation is the review of one move. Expected scores are the mover's
+// chances from 0 (lost) to 1 (won), with draws at 0.5.
+type MoveAnnotation struct {
+	Ply            int                `json:"ply"` // 1 for the first move of the game
+	Player         PlayerColor        `json:"player"`
+	Column         int                `json:"column"`
+	BestColumn     int                `json:"bestColumn"`
+	Expected       float64            `json:"expected"`     // after the move played
+	BestExpected   float64            `json:"bestExpected"` // after the best move
+	Loss           float64            `json:"loss"`         // BestExpected - Expected
+	Classification MoveClassification `json:"classification"`
+	Solved         bool               `json:"solved"` // expectations come from perfect play
+}
+
+// MoveAnnotations is the ordered list of a game's annotated moves
+type MoveAnnotations []MoveAnnotation
+
+// Scan implements the sql.Scanner interface for GORM
+func (ma *MoveAnnotations) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*ma = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, ma)
+	case string:
+		return json.Unmarshal([]byte(v), ma)
+	default:
+		return ErrInvalidEventData
+	}
+}
+
+// Value implements the driver.Valuer interface for GORM
+func (ma MoveAnnotations) Value() (driver.Value, error) {
+	if ma == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(ma)
+}
+
+// GameReview is the engine's annotation of a completed game. Accuracies
+// range from 0 to 100 and are left at zero until the review completes.
+type GameReview struct {
+	GameID         string          `json:"gameId" gorm:"primaryKey"`
+	Status         ReviewStatus    `json:"status" gorm:"type:varchar(20);not null"`
+	RedAccuracy    float64         `json:"redAccuracy"`
+	YellowAccuracy float64         `json:"yellowAccuracy"`
+	Moves          MoveAnnotations `json:"moves" gorm:"type:jsonb"`
+	Error          string          `json:"error,omitempty"`
+	CreatedAt      time.Time       `json:"createdAt" gorm:"autoCreateTime"`
+	CompletedAt    *time.Time      `json:"completedAt,omitempty"`
+}
+
+// TableName returns the table name for GORM
+func (GameReview) TableName() string {
+	return "game_reviews"
+}
+
+// IsDone reports whether the review has finished, successfully or not
+func (r *GameReview) IsDone() bool {
+	return r.Status == ReviewCompleted || r.Status == ReviewFailed
+}
@@ -15,6 +15,7 @@ type PlayerStats struct {
 	WinRate     float64   `json:"winRate" gorm:"default:0.0" validate:"min=0,max=1"`
 	AvgGameTime int       `json:"avgGameTime" gorm:"default:0" validate:"min=0"` // In seconds
 	LastPlayed  time.Time `json:"lastPlayed"`
+	IsBot       bool      `json:"isBot" gorm:"default:false;index"` // ranked on the bot leaderboard
 	CreatedAt   time.Time `json:"createdAt" gorm:"autoCreateTime"`
 	UpdatedAt   time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
 }
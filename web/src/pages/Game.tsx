@@ -26,6 +26,7 @@ export const Game: React.FC = () => {
   // Get game mode from localStorage
   const gameMode = localStorage.getItem('connect4_gameMode') || 'matchmaking';
   const customRoomAction = localStorage.getItem('connect4_customRoomAction');
+  const botDifficulty = localStorage.getItem('connect4_botDifficulty') || 'medium';
   const roomCodeParam = searchParams.get('room');
 
   useEffect(() => {
@@ -88,16 +89,16 @@ export const Game: React.FC = () => {
             }
           } else if (gameMode === 'bot') {
             console.log("Requesting bot game for:", username);
-            wsService.send(MessageType.PlayWithBot, { username });
+            wsService.send(MessageType.PlayWithBot, { username, difficulty: botDifficulty });
           } else {
             console.log("Joining matchmaking queue for:", username);
-            wsService.send(MessageType.JoinQueue, { username });
+            wsService.send(MessageType.JoinQueue, { username, botDifficulty });
           }
        }
     }, 500);
     
     return () => clearInterval(checkInterval);
-  }, [username, gameMode, customRoomAction, roomCodeParam]);
+  }, [username, gameMode, customRoomAction, roomCodeParam, botDifficulty]);
 
   // Cleanup when leaving
   useEffect(() => {
@@ -11,6 +11,9 @@ export const Lobby: React.FC = () => {
     const [name, setName] = useState('');
     const [gameMode, setGameMode] = useState<'matchmaking' | 'bot' | 'custom'>('matchmaking');
     const [customRoomAction, setCustomRoomAction] = useState<'create' | 'join'>('create');
+    const [botDifficulty, setBotDifficulty] = useState<'easy' | 'medium' | 'hard' | 'impossible'>(
+        () => (localStorage.getItem('connect4_botDifficulty') as 'easy' | 'medium' | 'hard' | 'impossible') || 'medium'
+    );
     const [roomCode, setRoomCode] = useState('');
     const [isCreatingPlayer, setIsCreatingPlayer] = useState(false);
     const { setUsername } = usePlayer();
@@ -41,6 +44,7 @@ export const Lobby: React.FC = () => {
             
             setUsername(name.trim());
             localStorage.setItem('connect4_gameMode', gameMode);
+            localStorage.setItem('connect4_botDifficulty', botDifficulty);
             
             // For custom room join, navigate with room code as query param
             if (gameMode === 'custom' && customRoomAction === 'join' && roomCode.trim()) {
@@ -57,6 +61,7 @@ export const Lobby: React.FC = () => {
             // Continue anyway for guest users
             setUsername(name.trim());
             localStorage.setItem('connect4_gameMode', gameMode);
+            localStorage.setItem('connect4_botDifficulty', botDifficulty);
             
             if (gameMode === 'custom' && customRoomAction === 'join' && roomCode.trim()) {
                 localStorage.setItem('connect4_customRoomAction', 'join');
@@ -179,6 +184,33 @@ export const Lobby: React.FC = () => {
                             </div>
                         </div>
 
+                        {/* Bot Difficulty (also used by the matchmaking bot fallback) */}
+                        <AnimatePresence>
+                            {gameMode !== 'custom' && (
+                                <motion.div
+                                    initial={{ opacity: 0, height: 0 }}
+                                    animate={{ opacity: 1, height: 'auto' }}
+                                    exit={{ opacity: 0, height: 0 }}
+                                    className="grid grid-cols-4 gap-2 overflow-hidden"
+                                >
+                                    {(['easy', 'medium', 'hard', 'impossible'] as const).map((level) => (
+                                        <button
+                                            key={level}
+                                            onClick={() => setBotDifficulty(level)}
+                                            className={cn(
+                                                "py-2 px-3 rounded-lg text-sm font-medium capitalize transition-all",
+                                                botDifficulty === level
+                                                    ? "bg-emerald-600 text-white"
+                                                    : "bg-slate-800 text-slate-400 hover:bg-slate-700"
+                                            )}
+                                        >
+                                            {level}
+                                        </button>
+                                    ))}
+                                </motion.div>
+                            )}
+                        </AnimatePresence>
+
                         {/* Custom Room Options */}
                         <AnimatePresence>
                             {gameMode === 'custom' && (